@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/jobstate"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// Attach follows a job until it reaches a terminal state, then prints or saves
+// its result. It returns an error unless the job completed successfully.
+func Attach(c *client.Client, args []string) error {
+	fs := newFlagSet("attach")
+	out := fs.String("out", "", "Write the job result to this file instead of printing it")
+	timeout := fs.Duration("timeout", 0, "Give up after this long (0 waits forever)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs attach <job-id> [flags]", "Follow a job until it finishes, showing state and progress, then print or save its result.\nExits non-zero unless the job completed.")
+		return nil
+	}
+
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("job ID required\n\nUsage: ojs attach <job-id> [--out <file>] [--timeout <duration>]")
+	}
+	jobID := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	var deadline <-chan time.Time
+	if *timeout > 0 {
+		deadline = time.After(*timeout)
+	}
+
+	lastLine := ""
+	for {
+		data, _, err := c.Get("/jobs/" + jobID)
+		if err != nil {
+			return err
+		}
+		var job map[string]any
+		json.Unmarshal(data, &job)
+
+		state := str(job["state"])
+		if jobstate.Terminal(state) {
+			return finishAttach(c, jobID, job, *out)
+		}
+
+		if output.Format != "json" {
+			if line := attachProgressLine(job); line != lastLine {
+				fmt.Println(line)
+				lastLine = line
+			}
+		}
+
+		select {
+		case <-time.After(pollInterval):
+		case <-deadline:
+			return fmt.Errorf("job %s still %s after %s", jobID, state, *timeout)
+		case <-sigCh:
+			if output.Format != "json" {
+				fmt.Printf("\nDetached from job %s (still %s).\n", jobID, state)
+			}
+			return nil
+		}
+	}
+}
+
+// attachProgressLine describes a running job's state and progress.
+func attachProgressLine(job map[string]any) string {
+	line := colorState(str(job["state"]))
+	if job["progress"] != nil {
+		line += fmt.Sprintf(" %.0f%%", toFloat(job["progress"])*100)
+	}
+	if job["progress_data"] != nil {
+		progressJSON, _ := json.Marshal(job["progress_data"])
+		line += " " + string(progressJSON)
+	}
+	return line
+}
+
+func finishAttach(c *client.Client, jobID string, job map[string]any, out string) error {
+	state := str(job["state"])
+
+	// Prefer the result endpoint; fall back to what the job itself carries.
+	resp := map[string]any{}
+	if data, _, err := c.Get("/jobs/" + jobID + "/result"); err == nil {
+		json.Unmarshal(data, &resp)
+	}
+	result := resp["result"]
+	if result == nil {
+		result = job["result"]
+	}
+	jobErr := resp["error"]
+	if jobErr == nil {
+		jobErr = job["error"]
+	}
+
+	if out != "" && result != nil {
+		resultJSON, _ := json.MarshalIndent(result, "", "  ")
+		if err := os.WriteFile(out, append(resultJSON, '\n'), 0644); err != nil {
+			return fmt.Errorf("write result: %w", err)
+		}
+	}
+
+	if output.Format == "json" {
+		summary := map[string]any{"job_id": jobID, "state": state, "result": result}
+		if jobErr != nil {
+			summary["error"] = jobErr
+		}
+		if err := output.JSON(summary); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println(colorState(state))
+		switch {
+		case out != "" && result != nil:
+			output.Success("Result written to %s", out)
+		case result != nil:
+			resultJSON, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(resultJSON))
+		}
+		if jobErr != nil {
+			errJSON, _ := json.Marshal(jobErr)
+			fmt.Printf("Error: %s\n", errJSON)
+		}
+	}
+
+	if state != "completed" {
+		return fmt.Errorf("job %s %s", jobID, state)
+	}
+	return nil
+}
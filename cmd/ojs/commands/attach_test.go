@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// attachServer serves a job that is active for the first two polls and then
+// settles in finalState with the given result body.
+func attachServer(t *testing.T, finalState string, resultBody map[string]any) http.HandlerFunc {
+	polls := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ojs/v1/jobs/job-1":
+			polls++
+			job := map[string]any{"id": "job-1", "state": "active", "progress": 0.5 * float64(polls-1)}
+			if polls > 2 {
+				job = map[string]any{"id": "job-1", "state": finalState}
+			}
+			json.NewEncoder(w).Encode(job)
+		case "/ojs/v1/jobs/job-1/result":
+			json.NewEncoder(w).Encode(resultBody)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func fastPoll(t *testing.T) {
+	t.Helper()
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	t.Cleanup(func() { pollInterval = orig })
+}
+
+func TestAttach_Completed(t *testing.T) {
+	fastPoll(t)
+	c := newTestClient(attachServer(t, "completed", map[string]any{
+		"state":  "completed",
+		"result": map[string]any{"sent": 3},
+	}))
+
+	out := captureStdout(t, func() {
+		if err := Attach(c, []string{"job-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result struct {
+		State  string         `json:"state"`
+		Result map[string]any `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.State != "completed" || result.Result["sent"] != float64(3) {
+		t.Errorf("unexpected attach result: %+v", result)
+	}
+}
+
+func TestAttach_Failed(t *testing.T) {
+	fastPoll(t)
+	withTableOutput(t)
+	c := newTestClient(attachServer(t, "discarded", map[string]any{
+		"state": "discarded",
+		"error": map[string]any{"message": "smtp timeout"},
+	}))
+
+	var err error
+	out := captureStdout(t, func() {
+		err = Attach(c, []string{"job-1"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "discarded") {
+		t.Fatalf("expected failure error, got %v", err)
+	}
+	for _, want := range []string{"active 0%", "active 50%", "discarded", "smtp timeout"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestAttach_OutFile(t *testing.T) {
+	fastPoll(t)
+	c := newTestClient(attachServer(t, "completed", map[string]any{
+		"result": map[string]any{"url": "s3://bucket/report.pdf"},
+	}))
+	path := filepath.Join(t.TempDir(), "result.json")
+
+	captureStdout(t, func() {
+		if err := Attach(c, []string{"job-1", "--out", path}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "s3://bucket/report.pdf") {
+		t.Errorf("unexpected result file: %s", data)
+	}
+}
+
+func TestAttach_RequiresJobID(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {})
+	if err := Attach(c, []string{"--out", "x.json"}); err == nil {
+		t.Fatal("expected error without job ID")
+	}
+}
@@ -2,10 +2,11 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"strings"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/jobstate"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
@@ -14,6 +15,9 @@ func Bulk(c *client.Client, args []string) error {
 	if len(args) == 0 {
 		return printBulkUsage()
 	}
+	if isHelpArg(args[0]) {
+		return printSubcommandHelp(printBulkUsage())
+	}
 
 	switch args[0] {
 	case "cancel":
@@ -22,30 +26,54 @@ func Bulk(c *client.Client, args []string) error {
 		return bulkRetry(c, args[1:])
 	case "delete":
 		return bulkDelete(c, args[1:])
+	case "reprioritize":
+		return bulkReprioritize(c, args[1:])
+	case "archive":
+		return bulkArchive(c, args[1:])
 	default:
 		return printBulkUsage()
 	}
 }
 
 func bulkCancel(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("bulk cancel", flag.ExitOnError)
+	fs := newFlagSet("bulk cancel")
 	ids := fs.String("ids", "", "Comma-separated job IDs (required)")
 	state := fs.String("state", "", "Cancel all jobs in this state")
 	queue := fs.String("queue", "", "Filter by queue (used with --state)")
-	fs.Parse(args)
+	olderThan := fs.String("older-than", "", "Cancel jobs older than duration (e.g. 7d, 24h)")
+	var tagArgs stringList
+	fs.Var(&tagArgs, "tag", "Only jobs with tag key=value (repeatable, all must match)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs bulk cancel (--ids <id1,id2,...> | --state <state> | --tag <k=v>) [flags]", "Cancel many jobs at once, by ID or by filter.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tags, err := parseTags(tagArgs)
+	if err != nil {
+		return err
+	}
+	if *state != "" {
+		if err := validateStates("--state", *state); err != nil {
+			return err
+		}
+	}
 
 	body := map[string]any{}
 
 	if *ids != "" {
 		body["job_ids"] = splitIDs(*ids)
-	} else if *state != "" {
-		filter := map[string]any{"state": *state}
-		if *queue != "" {
-			filter["queue"] = *queue
+	} else if *state != "" || len(tags) > 0 {
+		filter := newBulkFilter(*state, *queue, tags)
+		if *olderThan != "" {
+			filter["older_than"] = *olderThan
 		}
 		body["filter"] = filter
 	} else {
-		return fmt.Errorf("--ids or --state is required\n\nUsage: ojs bulk cancel --ids <id1,id2,...>\n       ojs bulk cancel --state <state> [--queue <queue>]")
+		return fmt.Errorf("--ids, --state or --tag is required\n\nUsage: ojs bulk cancel --ids <id1,id2,...>\n       ojs bulk cancel --state <state> [--queue <queue>] [--tag k=v]... [--older-than <duration>]")
 	}
 
 	data, _, err := c.Post("/jobs/bulk/cancel", body)
@@ -69,24 +97,53 @@ func bulkCancel(c *client.Client, args []string) error {
 }
 
 func bulkRetry(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("bulk retry", flag.ExitOnError)
+	fs := newFlagSet("bulk retry")
 	ids := fs.String("ids", "", "Comma-separated job IDs (required)")
 	state := fs.String("state", "", "Retry all jobs in this state")
 	queue := fs.String("queue", "", "Filter by queue (used with --state)")
-	fs.Parse(args)
+	onlyFailed := fs.Bool("only-failed", true, "Constrain filters to failed states (retryable, discarded)")
+	force := fs.Bool("force", false, "Retry matching jobs in any state, e.g. re-run completed jobs")
+	var tagArgs stringList
+	fs.Var(&tagArgs, "tag", "Only jobs with tag key=value (repeatable, all must match)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs bulk retry (--ids <id1,id2,...> | --state <state> | --tag <k=v>) [flags]", "Retry many failed or discarded jobs at once, by ID or by filter.\nFilters only match failed states unless --force is given.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tags, err := parseTags(tagArgs)
+	if err != nil {
+		return err
+	}
+	if *state != "" {
+		if err := validateStates("--state", *state); err != nil {
+			return err
+		}
+	}
 
 	body := map[string]any{}
 
 	if *ids != "" {
 		body["job_ids"] = splitIDs(*ids)
-	} else if *state != "" {
-		filter := map[string]any{"state": *state}
-		if *queue != "" {
-			filter["queue"] = *queue
+	} else if *state != "" || len(tags) > 0 {
+		filter := newBulkFilter(*state, *queue, tags)
+		if !*force {
+			if !*onlyFailed {
+				return fmt.Errorf("--only-failed=false requires --force")
+			}
+			if *state != "" && !contains(failedStates, *state) {
+				return fmt.Errorf("refusing to retry jobs in state %q: bulk retry only matches %s\n\nUse --force to retry jobs in other states", *state, strings.Join(failedStates, " or "))
+			}
+			if *state == "" {
+				filter["states"] = failedStates
+			}
 		}
 		body["filter"] = filter
 	} else {
-		return fmt.Errorf("--ids or --state is required\n\nUsage: ojs bulk retry --ids <id1,id2,...>\n       ojs bulk retry --state <state> [--queue <queue>]")
+		return fmt.Errorf("--ids, --state or --tag is required\n\nUsage: ojs bulk retry --ids <id1,id2,...>\n       ojs bulk retry --state <state> [--queue <queue>] [--tag k=v]...")
 	}
 
 	data, _, err := c.Post("/jobs/bulk/retry", body)
@@ -109,6 +166,10 @@ func bulkRetry(c *client.Client, args []string) error {
 	return nil
 }
 
+// failedStates are the states bulk retry filters are limited to unless
+// --force is given, so completed or active jobs are not re-enqueued.
+var failedStates = []string{jobstate.Retryable, jobstate.Discarded}
+
 func splitIDs(s string) []string {
 	var ids []string
 	for _, id := range splitComma(s) {
@@ -137,34 +198,70 @@ func splitComma(s string) []string {
 func printBulkUsage() error {
 	return fmt.Errorf("subcommand required\n\nUsage: ojs bulk <subcommand>\n\n" +
 		"Subcommands:\n" +
-		"  cancel   Bulk cancel jobs by IDs or filter\n" +
-		"  retry    Bulk retry jobs by IDs or filter\n" +
-		"  delete   Bulk delete terminal jobs by IDs or filter")
+		"  cancel         Bulk cancel jobs by IDs or filter\n" +
+		"  retry          Bulk retry jobs by IDs or filter\n" +
+		"  delete         Bulk delete terminal jobs by IDs or filter\n" +
+		"  reprioritize   Bulk set priority for jobs by IDs or filter\n" +
+		"  archive        Export terminal jobs to NDJSON, then delete them")
 }
 
 func bulkDelete(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("bulk delete", flag.ExitOnError)
+	fs := newFlagSet("bulk delete")
 	ids := fs.String("ids", "", "Comma-separated job IDs")
 	state := fs.String("state", "", "Delete all jobs in this terminal state (completed, discarded, cancelled)")
 	queue := fs.String("queue", "", "Filter by queue (used with --state)")
 	olderThan := fs.String("older-than", "", "Delete jobs older than duration (e.g. 7d, 24h)")
-	fs.Parse(args)
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt")
+	var tagArgs stringList
+	fs.Var(&tagArgs, "tag", "Only jobs with tag key=value (repeatable, all must match)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs bulk delete (--ids <id1,id2,...> | --state <state> | --tag <k=v>) [flags]", "Delete many terminal jobs at once, by ID or by filter.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tags, err := parseTags(tagArgs)
+	if err != nil {
+		return err
+	}
+	if *state != "" {
+		if err := validateStates("--state", *state); err != nil {
+			return err
+		}
+	}
 
 	body := map[string]any{}
 
 	if *ids != "" {
 		body["job_ids"] = splitIDs(*ids)
-	} else if *state != "" {
-		filter := map[string]any{"state": *state}
-		if *queue != "" {
-			filter["queue"] = *queue
-		}
+	} else if *state != "" || len(tags) > 0 {
+		filter := newBulkFilter(*state, *queue, tags)
 		if *olderThan != "" {
 			filter["older_than"] = *olderThan
 		}
 		body["filter"] = filter
 	} else {
-		return fmt.Errorf("--ids or --state is required\n\nUsage: ojs bulk delete --ids <id1,id2,...>\n       ojs bulk delete --state <state> [--queue <queue>] [--older-than <duration>]")
+		return fmt.Errorf("--ids, --state or --tag is required\n\nUsage: ojs bulk delete --ids <id1,id2,...> [--yes]\n       ojs bulk delete --state <state> [--queue <queue>] [--tag k=v]... [--older-than <duration>] [--yes]")
+	}
+
+	target := fmt.Sprintf("%d job(s)", len(splitIDs(*ids)))
+	if *ids == "" {
+		target = "all jobs"
+		if *state != "" {
+			target = fmt.Sprintf("all %s jobs", *state)
+		}
+		if *queue != "" {
+			target += fmt.Sprintf(" in queue %q", *queue)
+		}
+		if len(tags) > 0 {
+			target += " tagged " + formatTags(tags)
+		}
+	}
+	if err := confirmDestructive("permanently delete "+target, "", *yes); err != nil {
+		return err
 	}
 
 	data, _, err := c.Post("/jobs/bulk/delete", body)
@@ -186,3 +283,64 @@ func bulkDelete(c *client.Client, args []string) error {
 	output.Success("Bulk delete: %d deleted, %d failed", resp.Deleted, resp.Failed)
 	return nil
 }
+
+func bulkReprioritize(c *client.Client, args []string) error {
+	fs := newFlagSet("bulk reprioritize")
+	ids := fs.String("ids", "", "Comma-separated job IDs")
+	state := fs.String("state", "", "Reprioritize all jobs in this state")
+	queue := fs.String("queue", "", "Filter by queue (used with --state)")
+	priority := fs.Int("priority", -1, "New job priority (0-10, required)")
+	var tagArgs stringList
+	fs.Var(&tagArgs, "tag", "Only jobs with tag key=value (repeatable, all must match)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs bulk reprioritize (--ids <id1,id2,...> | --state <state> | --tag <k=v>) --priority <n> [flags]", "Set the priority of many pending jobs at once, by ID or by filter.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tags, err := parseTags(tagArgs)
+	if err != nil {
+		return err
+	}
+	if *state != "" {
+		if err := validateStates("--state", *state); err != nil {
+			return err
+		}
+	}
+
+	if *priority < 0 || *priority > 10 {
+		return fmt.Errorf("--priority between 0 and 10 is required\n\nUsage: ojs bulk reprioritize --ids <id1,id2,...> --priority <n>\n       ojs bulk reprioritize --state <state> [--queue <queue>] --priority <n>")
+	}
+
+	body := map[string]any{"priority": *priority}
+
+	if *ids != "" {
+		body["job_ids"] = splitIDs(*ids)
+	} else if *state != "" || len(tags) > 0 {
+		body["filter"] = newBulkFilter(*state, *queue, tags)
+	} else {
+		return fmt.Errorf("--ids, --state or --tag is required\n\nUsage: ojs bulk reprioritize --ids <id1,id2,...> --priority <n>\n       ojs bulk reprioritize --state <state> [--queue <queue>] [--tag k=v]... --priority <n>")
+	}
+
+	data, _, err := c.Post("/jobs/bulk/reprioritize", body)
+	if err != nil {
+		return err
+	}
+
+	if output.Format == "json" {
+		var result any
+		json.Unmarshal(data, &result)
+		return output.JSON(result)
+	}
+
+	var resp struct {
+		Affected int `json:"affected"`
+		Failed   int `json:"failed"`
+	}
+	json.Unmarshal(data, &resp)
+	output.Success("Bulk reprioritize: %d updated to priority %d, %d failed", resp.Affected, *priority, resp.Failed)
+	return nil
+}
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/jobstate"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// archiveDeleteBatch is the number of job IDs sent per bulk delete request
+// when removing archived jobs.
+const archiveDeleteBatch = 100
+
+// bulkArchive writes matching terminal jobs to an NDJSON file and then
+// deletes exactly the jobs that were written. Nothing is deleted unless the
+// whole archive was written and synced to disk.
+func bulkArchive(c *client.Client, args []string) error {
+	fs := newFlagSet("bulk archive")
+	state := fs.String("state", "completed", "Archive jobs in this terminal state (completed, discarded, cancelled)")
+	queue := fs.String("queue", "", "Filter by queue")
+	olderThan := fs.String("older-than", "", "Only jobs that finished longer ago than this (e.g. 30d, 24h)")
+	out := fs.String("out", "", "NDJSON file to write the archived jobs to (required, must not exist unless --append)")
+	appendOut := fs.Bool("append", false, "Append to an existing --out file instead of refusing to touch it")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt")
+	var tagArgs stringList
+	fs.Var(&tagArgs, "tag", "Only jobs with tag key=value (repeatable, all must match)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs bulk archive --out <archive.ndjson> [flags]", "Export terminal jobs to an NDJSON file, then delete the jobs that were exported.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return fmt.Errorf("--out is required\n\nUsage: ojs bulk archive --state <state> [--queue <queue>] [--older-than <duration>] --out <archive.ndjson> [--append] [--yes]")
+	}
+	if _, err := os.Stat(*out); err == nil && !*appendOut {
+		return fmt.Errorf("%s already exists; pass --append to add to it or choose another --out file", *out)
+	}
+	if err := validateStates("--state", *state); err != nil {
+		return err
+	}
+	if !jobstate.Terminal(*state) {
+		return fmt.Errorf("refusing to archive %s jobs: only terminal states (completed, discarded, cancelled) can be archived", *state)
+	}
+	tags, err := parseTags(tagArgs)
+	if err != nil {
+		return err
+	}
+	var cutoff time.Time
+	if *olderThan != "" {
+		d, err := parseDuration(*olderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+		cutoff = time.Now().Add(-d)
+	}
+
+	filters := "&state=" + *state
+	if *queue != "" {
+		filters += "&queue=" + *queue
+	}
+	if len(tags) > 0 {
+		filters += "&" + tagQuery(tags)
+	}
+	jobs, err := listArchiveJobs(c, filters, cutoff)
+	if err != nil {
+		return err
+	}
+	if len(jobs) == 0 {
+		if output.Format == "json" {
+			return output.JSON(map[string]any{"archived": 0, "deleted": 0, "file": *out})
+		}
+		fmt.Println("No matching jobs to archive.")
+		return nil
+	}
+
+	if err := confirmDestructive(fmt.Sprintf("archive %d %s job(s) to %s and delete them from the server", len(jobs), *state, *out), "", *yes); err != nil {
+		return err
+	}
+
+	ids, err := writeArchive(*out, jobs, *appendOut)
+	if err != nil {
+		return fmt.Errorf("archive failed, no jobs were deleted: %w", err)
+	}
+
+	deleted, failed := 0, 0
+	for start := 0; start < len(ids); start += archiveDeleteBatch {
+		batch := ids[start:min(start+archiveDeleteBatch, len(ids))]
+		data, _, err := c.Post("/jobs/bulk/delete", map[string]any{"job_ids": batch})
+		if err != nil {
+			return fmt.Errorf("archived %d job(s) to %s but delete failed after %d: %w", len(ids), *out, deleted, err)
+		}
+		var resp struct {
+			Deleted int `json:"deleted"`
+			Failed  int `json:"failed"`
+		}
+		json.Unmarshal(data, &resp)
+		deleted += resp.Deleted
+		failed += resp.Failed
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"archived": len(ids), "deleted": deleted, "failed": failed, "file": *out})
+	}
+	output.Success("Archived %d job(s) to %s; %d deleted, %d failed", len(ids), *out, deleted, failed)
+	return nil
+}
+
+// listArchiveJobs pages through /jobs with the given filters, keeping jobs
+// that finished before cutoff (all jobs when cutoff is zero).
+func listArchiveJobs(c *client.Client, filters string, cutoff time.Time) ([]map[string]any, error) {
+	var jobs []map[string]any
+	for offset := 0; ; offset += searchPageSize {
+		data, _, err := c.Get(fmt.Sprintf("/jobs?limit=%d&offset=%d", searchPageSize, offset) + filters)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Jobs []map[string]any `json:"jobs"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("parse jobs: %w", err)
+		}
+		for _, j := range page.Jobs {
+			if cutoff.IsZero() || finishedBefore(j, cutoff) {
+				jobs = append(jobs, j)
+			}
+		}
+		if len(page.Jobs) < searchPageSize {
+			return jobs, nil
+		}
+	}
+}
+
+// finishedBefore reports whether a job's completed_at (or created_at when
+// it has none) is before cutoff. Jobs without a parseable time are kept out
+// of the archive.
+func finishedBefore(job map[string]any, cutoff time.Time) bool {
+	for _, key := range []string{"completed_at", "created_at"} {
+		if s, ok := job[key].(string); ok {
+			t, err := time.Parse(time.RFC3339, s)
+			return err == nil && t.Before(cutoff)
+		}
+	}
+	return false
+}
+
+// writeArchive writes one job per line to path and returns the IDs written.
+// It never truncates: path must not exist unless appendTo is set, so an
+// earlier archive of already deleted jobs can't be overwritten. The file is
+// synced before returning so callers can safely delete the jobs.
+func writeArchive(path string, jobs []map[string]any, appendTo bool) ([]string, error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if appendTo {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		id, _ := j["id"].(string)
+		if id == "" {
+			continue
+		}
+		if err := enc.Encode(j); err != nil {
+			return nil, fmt.Errorf("write %s: %w", path, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := f.Sync(); err != nil {
+		return nil, fmt.Errorf("sync %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("close %s: %w", path, err)
+	}
+	return ids, nil
+}
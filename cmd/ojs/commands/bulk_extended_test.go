@@ -0,0 +1,285 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBulk_Reprioritize_IDs(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/ojs/v1/jobs/bulk/reprioritize" {
+			t.Errorf("path = %s, want /ojs/v1/jobs/bulk/reprioritize", r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if !reflect.DeepEqual(body["job_ids"], []any{"job-1", "job-2"}) {
+			t.Errorf("job_ids = %v, want [job-1 job-2]", body["job_ids"])
+		}
+		if body["priority"] != float64(8) {
+			t.Errorf("priority = %v, want 8", body["priority"])
+		}
+		if _, ok := body["filter"]; ok {
+			t.Error("filter should not be set with --ids")
+		}
+		json.NewEncoder(w).Encode(map[string]any{"affected": 2, "failed": 0})
+	})
+	err := Bulk(c, []string{"reprioritize", "--ids", "job-1,job-2", "--priority", "8"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBulk_Reprioritize_Filter(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		want := map[string]any{"state": "available", "queue": "emails"}
+		if !reflect.DeepEqual(body["filter"], want) {
+			t.Errorf("filter = %v, want %v", body["filter"], want)
+		}
+		if body["priority"] != float64(0) {
+			t.Errorf("priority = %v, want 0", body["priority"])
+		}
+		json.NewEncoder(w).Encode(map[string]any{"affected": 42, "failed": 0})
+	})
+	err := Bulk(c, []string{"reprioritize", "--state", "available", "--queue", "emails", "--priority", "0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBulk_Reprioritize_MissingPriority(t *testing.T) {
+	c := newTestClient(nil)
+	if err := Bulk(c, []string{"reprioritize", "--ids", "job-1"}); err == nil {
+		t.Fatal("expected error when --priority is missing")
+	}
+}
+
+func TestBulk_Cancel_OlderThan(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/jobs/bulk/cancel" {
+			t.Errorf("path = %s, want /ojs/v1/jobs/bulk/cancel", r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		want := map[string]any{"state": "scheduled", "older_than": "7d"}
+		if !reflect.DeepEqual(body["filter"], want) {
+			t.Errorf("filter = %v, want %v", body["filter"], want)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"cancelled": 5, "failed": 0})
+	})
+	err := Bulk(c, []string{"cancel", "--state", "scheduled", "--older-than", "7d"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestBulk_TagFilters(t *testing.T) {
+	wantTags := map[string]any{"env": "prod", "team": "billing"}
+	for _, tt := range []struct {
+		args []string
+		path string
+		want map[string]any
+	}{
+		{[]string{"cancel", "--tag", "env=prod", "--tag", "team=billing"}, "/ojs/v1/jobs/bulk/cancel",
+			map[string]any{"tags": wantTags}},
+		{[]string{"retry", "--state", "retryable", "--tag", "team=billing", "--tag", "env=prod"}, "/ojs/v1/jobs/bulk/retry",
+			map[string]any{"state": "retryable", "tags": wantTags}},
+		{[]string{"delete", "--state", "completed", "--queue", "q", "--tag", "env=prod", "--tag", "team=billing", "--yes"}, "/ojs/v1/jobs/bulk/delete",
+			map[string]any{"state": "completed", "queue": "q", "tags": wantTags}},
+		{[]string{"reprioritize", "--tag", "env=prod", "--tag", "team=billing", "--priority", "9"}, "/ojs/v1/jobs/bulk/reprioritize",
+			map[string]any{"tags": wantTags}},
+	} {
+		c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != tt.path {
+				t.Errorf("path = %s, want %s", r.URL.Path, tt.path)
+			}
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if !reflect.DeepEqual(body["filter"], tt.want) {
+				t.Errorf("%s: filter = %v, want %v", tt.args[0], body["filter"], tt.want)
+			}
+			w.Write([]byte(`{}`))
+		})
+		captureStdout(t, func() {
+			if err := Bulk(c, tt.args); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.args[0], err)
+			}
+		})
+	}
+}
+
+func TestBulk_TagErrors(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+	for _, args := range [][]string{
+		{"cancel", "--tag", "novalue"},
+		{"retry", "--tag", "env=prod", "--tag", "env=dev"},
+		{"cancel", "--tag", "=x"},
+	} {
+		if err := Bulk(c, args); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestBulk_Retry_OnlyFailedByDefault(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want map[string]any
+	}{
+		{[]string{"retry", "--tag", "env=prod"},
+			map[string]any{"tags": map[string]any{"env": "prod"}, "states": []any{"retryable", "discarded"}}},
+		{[]string{"retry", "--state", "discarded", "--queue", "q"},
+			map[string]any{"state": "discarded", "queue": "q"}},
+		{[]string{"retry", "--tag", "env=prod", "--force"},
+			map[string]any{"tags": map[string]any{"env": "prod"}}},
+		{[]string{"retry", "--state", "completed", "--force"},
+			map[string]any{"state": "completed"}},
+	} {
+		c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if !reflect.DeepEqual(body["filter"], tt.want) {
+				t.Errorf("%v: filter = %v, want %v", tt.args, body["filter"], tt.want)
+			}
+			w.Write([]byte(`{}`))
+		})
+		captureStdout(t, func() {
+			if err := Bulk(c, tt.args); err != nil {
+				t.Fatalf("%v: unexpected error: %v", tt.args, err)
+			}
+		})
+	}
+}
+
+func TestBulk_Retry_OtherStatesRequireForce(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	for _, args := range [][]string{
+		{"retry", "--state", "completed"},
+		{"retry", "--tag", "env=prod", "--only-failed=false"},
+	} {
+		err := Bulk(c, args)
+		if err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Errorf("%v: err = %v, want error mentioning --force", args, err)
+		}
+	}
+}
+
+// archiveServer lists three completed jobs, one of them recent, and records
+// the order of requests along with the archive file's contents at the time
+// of each delete.
+func archiveServer(t *testing.T, out string, log *[]string) http.HandlerFunc {
+	old := time.Now().Add(-60 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/jobs":
+			if r.URL.Query().Get("state") != "completed" {
+				t.Errorf("list query = %s, want state=completed", r.URL.RawQuery)
+			}
+			*log = append(*log, "list")
+			json.NewEncoder(w).Encode(map[string]any{"jobs": []map[string]any{
+				{"id": "j1", "state": "completed", "completed_at": old},
+				{"id": "j2", "state": "completed", "completed_at": recent},
+				{"id": "j3", "state": "completed", "completed_at": old},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/ojs/v1/jobs/bulk/delete":
+			var body struct {
+				JobIDs []string `json:"job_ids"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+			archived, _ := os.ReadFile(out)
+			*log = append(*log, "delete "+strings.Join(body.JobIDs, ",")+" after "+
+				strings.Join(strings.Fields(string(archived)), " "))
+			json.NewEncoder(w).Encode(map[string]any{"deleted": len(body.JobIDs)})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestBulk_ArchiveExportsBeforeDelete(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "archive.ndjson")
+	var log []string
+	c := newTestClient(archiveServer(t, out, &log))
+
+	err := Bulk(c, []string{"archive", "--state", "completed", "--older-than", "30d", "--out", out, "--yes"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(log) != 2 || log[0] != "list" || !strings.HasPrefix(log[1], "delete j1,j3 after ") {
+		t.Fatalf("requests = %q, want list then delete of j1,j3", log)
+	}
+	if !strings.Contains(log[1], `"id":"j1"`) || !strings.Contains(log[1], `"id":"j3"`) {
+		t.Errorf("archive was not written before delete: %s", log[1])
+	}
+
+	data, _ := os.ReadFile(out)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("archive has %d lines, want 2:\n%s", len(lines), data)
+	}
+	var job map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &job); err != nil || job["id"] != "j1" {
+		t.Errorf("first archived line = %s", lines[0])
+	}
+}
+
+func TestBulk_ArchiveFailureSkipsDelete(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "missing-dir", "archive.ndjson")
+	var log []string
+	c := newTestClient(archiveServer(t, out, &log))
+
+	err := Bulk(c, []string{"archive", "--out", out, "--yes"})
+	if err == nil || !strings.Contains(err.Error(), "no jobs were deleted") {
+		t.Fatalf("err = %v, want an archive failure", err)
+	}
+	if !reflect.DeepEqual(log, []string{"list"}) {
+		t.Errorf("requests = %q, want no delete", log)
+	}
+}
+
+func TestBulk_ArchiveKeepsExistingFile(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "archive.ndjson")
+	previous := `{"id":"j0","state":"completed"}` + "\n"
+	os.WriteFile(out, []byte(previous), 0644)
+
+	c := newTestClient(noRequestClient(t))
+	err := Bulk(c, []string{"archive", "--out", out, "--yes"})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("err = %v, want refusal to overwrite", err)
+	}
+	if data, _ := os.ReadFile(out); string(data) != previous {
+		t.Fatalf("existing archive changed:\n%s", data)
+	}
+
+	var log []string
+	c = newTestClient(archiveServer(t, out, &log))
+	if err := Bulk(c, []string{"archive", "--older-than", "30d", "--out", out, "--append", "--yes"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := os.ReadFile(out)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || lines[0] != strings.TrimSpace(previous) {
+		t.Errorf("appended archive = %s", data)
+	}
+}
+
+func TestBulk_ArchiveRejectsActiveState(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	err := Bulk(c, []string{"archive", "--state", "active", "--out", filepath.Join(t.TempDir(), "a.ndjson")})
+	if err == nil || !strings.Contains(err.Error(), "only terminal states") {
+		t.Fatalf("err = %v, want a terminal-state error", err)
+	}
+}
@@ -1,8 +1,8 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -10,24 +10,25 @@ import (
 
 // Cancel cancels a job by ID.
 func Cancel(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs cancel <job-id>", "Cancel a pending, scheduled or active job.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("job ID required\n\nUsage: ojs cancel <job-id>")
 	}
 
 	jobID := args[0]
-	data, _, err := c.Delete("/jobs/" + jobID)
-	if err != nil {
-		return err
+	var job map[string]any
+	if err := c.DoJSON(http.MethodDelete, "/jobs/"+jobID, nil, &job); err != nil {
+		return jobError(jobID, err)
 	}
 
 	if output.Format == "json" {
-		var result any
-		json.Unmarshal(data, &result)
-		return output.JSON(result)
+		return output.JSON(job)
 	}
 
-	var job map[string]any
-	json.Unmarshal(data, &job)
 	output.Success("Job %s cancelled (state=%s)", jobID, job["state"])
 	return nil
 }
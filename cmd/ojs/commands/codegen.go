@@ -1,7 +1,6 @@
 package commands
 
 import (
-	"flag"
 	"fmt"
 
 	"github.com/openjobspec/ojs-cli/internal/codegen"
@@ -9,23 +8,19 @@ import (
 
 // Codegen generates type-safe SDK code from job type definitions.
 func Codegen(args []string) error {
-	fs := flag.NewFlagSet("codegen", flag.ExitOnError)
+	fs := newFlagSet("codegen")
 	manifest := fs.String("manifest", "ojs-jobs.yaml", "Path to job type manifest (YAML or JSON)")
 	lang := fs.String("lang", "go", "Target language: go, typescript, python")
 	outDir := fs.String("out", "./generated", "Output directory")
 	pkg := fs.String("package", "", "Package name override (Go only)")
-	fs.Parse(args)
+	watch := fs.Bool("watch", false, "Watch the manifest and regenerate on every change")
 
-	m, err := codegen.LoadManifest(*manifest)
-	if err != nil {
-		return fmt.Errorf("loading manifest: %w", err)
-	}
-
-	if *pkg != "" {
-		m.Package = *pkg
+	if helpRequested(args) {
+		printHelp(fs, "ojs codegen [flags]", "Generate type-safe SDK code for the job types declared in a manifest.")
+		return nil
 	}
-	if m.Package == "" {
-		m.Package = "ojsjobs"
+	if err := fs.Parse(args); err != nil {
+		return err
 	}
 
 	var language codegen.Language
@@ -40,11 +35,34 @@ func Codegen(args []string) error {
 		return fmt.Errorf("unsupported language: %s (supported: go, typescript, python)", *lang)
 	}
 
-	gen := codegen.NewGenerator(m, language, *outDir)
-	if err := gen.Generate(); err != nil {
-		return fmt.Errorf("code generation failed: %w", err)
+	generate := func() (string, error) {
+		m, err := codegen.LoadManifest(*manifest)
+		if err != nil {
+			return "", fmt.Errorf("loading manifest: %w", err)
+		}
+
+		if *pkg != "" {
+			m.Package = *pkg
+		}
+		if m.Package == "" {
+			m.Package = "ojsjobs"
+		}
+
+		gen := codegen.NewGenerator(m, language, *outDir)
+		if err := gen.Generate(); err != nil {
+			return "", fmt.Errorf("code generation failed: %w", err)
+		}
+		return fmt.Sprintf("Generated %s code for %d job types in %s", *lang, len(m.JobTypes), *outDir), nil
 	}
 
-	fmt.Printf("✓ Generated %s code for %d job types in %s\n", *lang, len(m.JobTypes), *outDir)
+	if *watch {
+		return codegenWatch(*manifest, generate)
+	}
+
+	summary, err := generate()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ %s\n", summary)
 	return nil
 }
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+var (
+	// codegenPollInterval is how often codegen --watch checks the manifest
+	// for changes.
+	codegenPollInterval = 500 * time.Millisecond
+	// codegenDebounce is how long the manifest must be unchanged before
+	// regenerating, so an editor's burst of writes triggers a single run.
+	codegenDebounce = 300 * time.Millisecond
+)
+
+// codegenWatch runs generate once and again after every change to the
+// manifest until interrupted. Failed runs, e.g. while the manifest is
+// half-edited and invalid, are reported and the watch continues.
+func codegenWatch(manifestPath string, generate func() (string, error)) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	stop := make(chan struct{})
+	go func() {
+		<-sigCh
+		close(stop)
+	}()
+
+	run := func() { reportCodegenRun(generate) }
+	run()
+	fmt.Printf("Watching %s for changes (Ctrl-C to stop)\n", manifestPath)
+	debounce(watchFile(manifestPath, codegenPollInterval, stop), codegenDebounce, run)
+	fmt.Println("\nCodegen watch stopped.")
+	return nil
+}
+
+func reportCodegenRun(generate func() (string, error)) {
+	stamp := time.Now().Format("15:04:05")
+	summary, err := generate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%s] ✗ %v (still watching)\n", stamp, err)
+		return
+	}
+	fmt.Printf("[%s] ✓ %s\n", stamp, summary)
+}
+
+// watchFile polls path every interval and sends on the returned channel
+// whenever its size or modification time changes, or it appears or
+// disappears. The channel is closed once stop is closed.
+func watchFile(path string, interval time.Duration, stop <-chan struct{}) <-chan struct{} {
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer close(changes)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		last := fileVersion(path)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			if v := fileVersion(path); v != last {
+				last = v
+				select {
+				case changes <- struct{}{}:
+				default: // a change is already pending
+				}
+			}
+		}
+	}()
+	return changes
+}
+
+// fileStamp identifies a file's contents well enough to notice edits.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// fileVersion stats path; a missing file has the zero stamp.
+func fileVersion(path string) fileStamp {
+	var v fileStamp
+	if info, err := os.Stat(path); err == nil {
+		v.size, v.modTime = info.Size(), info.ModTime()
+	}
+	return v
+}
+
+// debounce calls fn once changes has been quiet for wait, coalescing bursts
+// of changes into one call. It returns when changes is closed, dropping any
+// call still pending.
+func debounce(changes <-chan struct{}, wait time.Duration, fn func()) {
+	var fire <-chan time.Time
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+			fire = time.After(wait)
+		case <-fire:
+			fire = nil
+			fn()
+		}
+	}
+}
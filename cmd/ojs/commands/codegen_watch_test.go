@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDebounce_CoalescesBursts(t *testing.T) {
+	changes := make(chan struct{})
+	runs := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		debounce(changes, 20*time.Millisecond, func() { runs <- struct{}{} })
+		close(done)
+	}()
+
+	// A burst of saves regenerates once, after the burst settles.
+	for i := 0; i < 5; i++ {
+		changes <- struct{}{}
+		time.Sleep(2 * time.Millisecond)
+	}
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("no regenerate after burst")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := len(runs); n != 0 {
+		t.Errorf("burst regenerated %d extra time(s)", n)
+	}
+
+	// A later change triggers another run.
+	changes <- struct{}{}
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		t.Fatal("no regenerate after second change")
+	}
+
+	close(changes)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("debounce did not return after changes closed")
+	}
+}
+
+func TestDebounce_ClosedDropsPending(t *testing.T) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	close(changes)
+
+	runs := 0
+	debounce(changes, time.Hour, func() { runs++ })
+	if runs != 0 {
+		t.Errorf("runs = %d, want pending run dropped on stop", runs)
+	}
+}
+
+func TestWatchFile_DetectsChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ojs-jobs.yaml")
+	os.WriteFile(path, []byte("version: \"1.0\"\n"), 0o644)
+
+	stop := make(chan struct{})
+	changes := watchFile(path, time.Millisecond, stop)
+
+	time.Sleep(10 * time.Millisecond)
+	os.WriteFile(path, []byte("version: \"1.0\"\npackage: jobs\n"), 0o644)
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("change not detected")
+	}
+
+	close(stop)
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("changes channel not closed after stop")
+		}
+	}
+}
+
+func TestReportCodegenRun_InvalidManifestKeepsWatching(t *testing.T) {
+	changes := make(chan struct{}, 1)
+	results := []error{errors.New("loading manifest: yaml: line 3: did not find expected key"), nil}
+	calls := 0
+	generate := func() (string, error) {
+		err := results[calls]
+		calls++
+		if err != nil {
+			return "", err
+		}
+		return "Generated go code for 2 job types in ./generated", nil
+	}
+
+	out := captureStdout(t, func() {
+		done := make(chan struct{})
+		go func() {
+			debounce(changes, time.Millisecond, func() { reportCodegenRun(generate) })
+			close(done)
+		}()
+		changes <- struct{}{}
+		time.Sleep(20 * time.Millisecond)
+		changes <- struct{}{}
+		time.Sleep(20 * time.Millisecond)
+		close(changes)
+		<-done
+	})
+
+	if calls != 2 {
+		t.Fatalf("generate calls = %d, want 2 (watch must survive the invalid manifest)", calls)
+	}
+	if !strings.Contains(out, "✓ Generated go code for 2 job types") {
+		t.Errorf("expected success summary after the manifest was fixed, got:\n%s", out)
+	}
+}
@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"reflect"
 	"testing"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
@@ -20,6 +24,29 @@ func init() {
 	output.Format = "json"
 }
 
+// captureStdout runs fn and returns everything it wrote to stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdout = w
+
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	defer func() { os.Stdout = old }()
+	fn()
+	w.Close()
+	return <-done
+}
+
 func TestEnqueue_MissingType(t *testing.T) {
 	c := newTestClient(nil)
 	err := Enqueue(c, []string{})
@@ -260,6 +287,64 @@ func TestWorkflow_Create_Success(t *testing.T) {
 	}
 }
 
+func TestWorkflow_Create_StepTimeoutAndRetry(t *testing.T) {
+	var steps []map[string]any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Steps []map[string]any `json:"steps"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		steps = body.Steps
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": "wf-1", "state": "running"})
+	})
+	err := Workflow(c, []string{
+		"create", "--name", "etl",
+		"--steps", `[{"id":"extract","type":"etl.extract","timeout":"5m","retry":{"max_attempts":3,"backoff":"exponential","initial_interval":"2s"}},` +
+			`{"id":"load","type":"etl.load","depends_on":["extract"],"timeout":"30s"}]`,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(steps) != 2 || steps[0]["timeout"] != "5m" || steps[1]["timeout"] != "30s" {
+		t.Fatalf("steps sent = %v", steps)
+	}
+	retry, _ := steps[0]["retry"].(map[string]any)
+	if retry["max_attempts"] != float64(3) || retry["backoff"] != "exponential" || retry["delay_ms"] != float64(2000) {
+		t.Errorf("retry sent = %v", retry)
+	}
+}
+
+func TestWorkflow_Create_InvalidStepConfig(t *testing.T) {
+	tests := map[string]string{
+		"negative timeout":   `[{"id":"a","type":"t","timeout":"-5s"}]`,
+		"numeric timeout":    `[{"id":"a","type":"t","timeout":30}]`,
+		"negative attempts":  `[{"id":"a","type":"t","retry":{"max_attempts":-1}}]`,
+		"unknown backoff":    `[{"id":"a","type":"t","retry":{"backoff":"random"}}]`,
+		"unknown retry key":  `[{"id":"a","type":"t","retry":{"attempts":3}}]`,
+		"missing type":       `[{"id":"a"}]`,
+		"duplicate id":       `[{"id":"a","type":"t"},{"id":"a","type":"t"}]`,
+		"unknown depends_on": `[{"id":"a","type":"t","depends_on":["b"]}]`,
+	}
+	for name, steps := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newTestClient(noRequestClient(t))
+			if err := Workflow(c, []string{"create", "--name", "wf", "--steps", steps}); err == nil {
+				t.Errorf("expected error for %s", steps)
+			}
+		})
+	}
+}
+
+func TestFormatStepRetry(t *testing.T) {
+	if got := formatStepRetry(map[string]any{"max_attempts": float64(3), "backoff": "linear"}); got != "3x linear" {
+		t.Errorf("formatStepRetry = %q", got)
+	}
+	if got := formatStepRetry(nil); got != "-" {
+		t.Errorf("formatStepRetry(nil) = %q", got)
+	}
+}
+
 func TestWorkflow_Status_MissingID(t *testing.T) {
 	c := newTestClient(nil)
 	err := Workflow(c, []string{"status"})
@@ -339,6 +424,36 @@ func TestWorkflow_List_Empty(t *testing.T) {
 	}
 }
 
+func TestWorkflowStateCounts(t *testing.T) {
+	wfs := []workflowSummary{
+		{ID: "wf-1", State: "completed"},
+		{ID: "wf-2", State: "running"},
+		{ID: "wf-3", State: "completed"},
+		{ID: "wf-4", State: "paused"},
+		{ID: "wf-5", State: "failed"},
+		{ID: "wf-6", State: "running"},
+		{ID: "wf-7", State: "completed"},
+	}
+	got := workflowStateCounts(wfs)
+	want := []stateCount{{"running", 2}, {"completed", 3}, {"failed", 1}, {"paused", 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("workflowStateCounts = %v, want %v", got, want)
+	}
+	if line := formatStateCounts(got); line != "⟳ running 2  ✓ completed 3  ✗ failed 1  paused 1" {
+		t.Errorf("formatStateCounts = %q", line)
+	}
+	if got := workflowStateCounts(nil); len(got) != 0 {
+		t.Errorf("expected no counts for an empty list, got %v", got)
+	}
+}
+
+func TestWorkflow_List_WatchInvalidInterval(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	if err := Workflow(c, []string{"list", "--watch", "--interval", "0"}); err == nil {
+		t.Error("expected error for non-positive --interval")
+	}
+}
+
 func TestWorkers_Quiet(t *testing.T) {
 	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
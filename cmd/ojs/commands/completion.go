@@ -8,78 +8,119 @@ import (
 
 // Completion generates shell completion scripts.
 func Completion(args []string) error {
+	const usage = "Usage: ojs completion <bash|zsh|fish>\n       ojs completion install <bash|zsh|fish> [--path <file>] [--print]"
 	if len(args) == 0 {
-		return fmt.Errorf("shell type required\n\nUsage: ojs completion <bash|zsh|fish>")
+		return fmt.Errorf("shell type required\n\n" + usage)
 	}
+	if isHelpArg(args[0]) {
+		fmt.Println(usage + "\n\nPrint a shell completion script, or install it where the shell loads completions from.")
+		return nil
+	}
+
+	if args[0] == "install" {
+		return completionInstall(args[1:])
+	}
+
+	script, err := completionScript(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
 
-	switch args[0] {
+func completionScript(shell string) (string, error) {
+	switch shell {
 	case "bash":
-		fmt.Print(bashCompletion)
+		return bashCompletion, nil
 	case "zsh":
-		fmt.Print(zshCompletion)
+		return zshCompletion, nil
 	case "fish":
-		fmt.Print(fishCompletion)
+		return fishCompletion, nil
 	default:
-		return fmt.Errorf("unsupported shell: %s\n\nSupported: bash, zsh, fish", args[0])
+		return "", fmt.Errorf("unsupported shell: %s\n\nSupported: bash, zsh, fish", shell)
 	}
-	return nil
 }
 
 var commands = map[string][]string{
-	"enqueue":     {"--type", "--queue", "--priority", "--args", "--meta", "--max-attempts", "--unique-key", "--unique-within", "--batch"},
-	"status":      {"--detail"},
+	"enqueue":     {"--type", "--queue", "--validate-queue", "--priority", "--priority-name", "--args", "--meta", "--max-attempts", "--backoff", "--initial-interval", "--unique-key", "--unique-within", "--expires-at", "--tag", "--tags", "--batch", "--chunk-size", "--concurrency", "--from-template", "--param"},
+	"status":      {"--detail", "--watch"},
 	"cancel":      {},
-	"health":      {},
-	"queues":      {"--stats", "--pause", "--resume", "--create", "--delete", "--purge", "--config", "--concurrency", "--max-size", "--states", "--retention"},
-	"workers":     {"--quiet", "--resume", "--detail", "--quiet-worker", "--deregister"},
-	"dead-letter": {"--retry", "--delete", "--limit", "--purge", "--stats", "--older-than"},
-	"cron":        {"--register", "--delete", "--name", "--expression", "--type", "--queue", "--trigger", "--history", "--history-limit", "--pause", "--resume", "--detail", "--update", "--enabled"},
+	"health":      {"--deep", "--wait", "--timeout"},
+	"ping":        {"--count", "--interval", "--strict"},
+	"queues":      {"--stats", "--history", "--period", "--pause", "--resume", "--create", "--if-not-exists", "--delete", "--purge", "--dry-run", "--config", "--concurrency", "--max-size", "--states", "--retention", "--set", "--yes", "--drain", "--timeout", "--rename", "--to"},
+	"workers":     {"--quiet", "--resume", "--detail", "--quiet-worker", "--deregister", "--prune-stale", "--older-than", "--dry-run", "--quiet-all", "--wait-drain", "--timeout", "--watch"},
+	"dead-letter": {"--retry", "--delete", "--limit", "--purge", "--stats", "--older-than", "--queue", "--type", "--yes"},
+	"cron":        {"--register", "--delete", "--name", "--expression", "--type", "--queue", "--trigger", "--args", "--history", "--history-limit", "--pause", "--resume", "--detail", "--update", "--enabled", "--next", "--count", "--timezone"},
 	"monitor":     {"--interval"},
+	"top":         {"--sort", "--limit", "--queue", "--interval"},
+	"logs":        {"--follow", "--tail"},
+	"attach":      {"--out", "--timeout"},
+	"doctor":      {"--quick", "--production", "--verbose", "--baseline", "--save-baseline", "--output", "--only", "--skip", "--emit-fixes", "--apply-fixes", "--yes", "--servers", "--min-grade", "--output-dir"},
 	"workflow":    {},
 	"migrate":     {},
 	"completion":  {},
-	"jobs":        {"--state", "--queue", "--type", "--limit"},
+	"shell":       {},
+	"jobs":        {"--state", "--queue", "--type", "--limit", "--tag", "--search", "--search-field"},
 	"result":      {"--wait", "--timeout"},
 	"bulk":        {},
 	"priority":    {"--set"},
 	"retries":     {},
 	"retry":       {},
-	"metrics":     {"--format"},
-	"rate-limits": {"--inspect", "--override", "--concurrency", "--clear"},
-	"events":      {"--follow", "--types", "--queue"},
+	"diff":        {"--all"},
+	"metrics":     {"--format", "--output", "--diff", "--push-to", "--job", "--labels", "--output-dir"},
+	"rate-limits": {"--inspect", "--override", "--concurrency", "--ttl", "--clear"},
+	"events":      {"--follow", "--types", "--queue", "--forward", "--forward-retries", "--count", "--until", "--pretty"},
 	"system":      {},
 	"webhooks":    {},
-	"stats":       {"--history", "--period", "--since", "--queue"},
+	"template":    {},
+	"whoami":      {},
+	"replay-file": {"--queue", "--priority", "--dry-run", "--no-dedup", "--no-progress"},
+	"stats":       {"--history", "--period", "--since", "--queue", "--top", "--by", "--limit", "--watch", "--interval", "--alerts", "--output-dir"},
 }
 
 var workflowSubcommands = map[string][]string{
-	"create": {"--name", "--steps"},
+	"create": {"--name", "--steps", "--file"},
 	"status": {},
 	"cancel": {},
-	"list":   {"--limit", "--state"},
+	"list":   {"--limit", "--state", "--watch", "--interval"},
+	"export": {"--out"},
 }
 
 var bulkSubcommands = map[string][]string{
-	"cancel": {"--ids", "--state", "--queue"},
-	"retry":  {"--ids", "--state", "--queue"},
-	"delete": {"--ids", "--state", "--queue", "--older-than"},
+	"cancel":       {"--ids", "--state", "--queue", "--tag", "--older-than"},
+	"retry":        {"--ids", "--state", "--queue", "--tag", "--only-failed", "--force"},
+	"delete":       {"--ids", "--state", "--queue", "--tag", "--older-than", "--yes"},
+	"reprioritize": {"--ids", "--state", "--queue", "--tag", "--priority"},
+	"archive":      {"--state", "--queue", "--tag", "--older-than", "--out", "--append", "--yes"},
 }
 
 var systemSubcommands = map[string][]string{
 	"maintenance": {"--enable", "--disable", "--reason"},
-	"config":      {},
+	"config":      {"--set"},
+	"drain":       {"--timeout", "--no-maintenance", "--reason"},
 }
 
 var webhooksSubcommands = map[string][]string{
-	"create":        {"--url", "--events", "--secret"},
+	"create":        {"--url", "--events", "--secret", "--max-retries", "--retry-backoff", "--timeout-ms", "--force"},
+	"update":        {"--url", "--events", "--active", "--max-retries", "--retry-backoff", "--timeout-ms"},
 	"list":          {"--limit"},
 	"get":           {},
 	"delete":        {},
 	"test":          {},
 	"rotate-secret": {},
+	"events":        {},
+	"replay":        {"--event", "--failed-since"},
+}
+
+var templateSubcommands = map[string][]string{
+	"list":   {},
+	"show":   {},
+	"save":   {"--file", "--from-job", "--force"},
+	"delete": {},
 }
 
-var globalFlags = []string{"--url", "--json", "--version", "--help"}
+var globalFlags = []string{"--url", "--json", "--wide", "--query", "--print-request", "--print-only", "--version", "--help"}
 
 func commandNames() []string {
 	names := make([]string, 0, len(commands))
@@ -138,6 +179,8 @@ var bashCompletion = func() string {
 			b.WriteString(generateSubcommandCompletion(systemSubcommands))
 		} else if cmd == "webhooks" {
 			b.WriteString(generateSubcommandCompletion(webhooksSubcommands))
+		} else if cmd == "template" {
+			b.WriteString(generateSubcommandCompletion(templateSubcommands))
 		} else if cmd == "completion" {
 			b.WriteString(`            COMPREPLY=($(compgen -W "bash zsh fish" -- "${cur}"))
 `)
@@ -231,6 +274,18 @@ _ojs() {
             )
             _describe 'subcommand' subcommands
             ;;
+`)
+		} else if cmd == "template" {
+			b.WriteString(`        template)
+            local -a subcommands
+            subcommands=(
+                'list:List job templates'
+                'show:Show a job template'
+                'save:Save a job template'
+                'delete:Delete a job template'
+            )
+            _describe 'subcommand' subcommands
+            ;;
 `)
 		} else if cmd == "completion" {
 			b.WriteString(`        completion)
@@ -285,6 +340,7 @@ var fishCompletion = func() string {
 				"status": "Get workflow status",
 				"cancel": "Cancel a workflow",
 				"list":   "List workflows",
+				"export": "Export a workflow definition",
 			} {
 				b.WriteString(fmt.Sprintf("complete -c ojs -n '__fish_seen_subcommand_from workflow' -a %s -d '%s'\n", sub, desc))
 			}
@@ -313,6 +369,15 @@ var fishCompletion = func() string {
 			} {
 				b.WriteString(fmt.Sprintf("complete -c ojs -n '__fish_seen_subcommand_from webhooks' -a %s -d '%s'\n", sub, desc))
 			}
+		} else if cmd == "template" {
+			for sub, desc := range map[string]string{
+				"list":   "List job templates",
+				"show":   "Show a job template",
+				"save":   "Save a job template",
+				"delete": "Delete a job template",
+			} {
+				b.WriteString(fmt.Sprintf("complete -c ojs -n '__fish_seen_subcommand_from template' -a %s -d '%s'\n", sub, desc))
+			}
 		} else if cmd == "completion" {
 			for _, shell := range []string{"bash", "zsh", "fish"} {
 				b.WriteString(fmt.Sprintf("complete -c ojs -n '__fish_seen_subcommand_from completion' -a %s -d '%s completion'\n", shell, shell))
@@ -333,24 +398,34 @@ var commandDescriptions = map[string]string{
 	"status":      "Get job status",
 	"cancel":      "Cancel a job",
 	"health":      "Check server health",
+	"ping":        "Measure server latency",
 	"queues":      "List and manage queues",
 	"workers":     "List and manage workers",
 	"dead-letter": "Manage dead letter queue",
 	"cron":        "Manage cron jobs",
 	"monitor":     "Live monitoring dashboard",
+	"top":         "Live view of active jobs",
+	"logs":        "Show job execution logs",
+	"attach":      "Follow a job to completion and print its result",
+	"doctor":      "Run health and production readiness checks",
 	"workflow":    "Manage workflows",
 	"migrate":     "Migrate jobs from other systems",
 	"completion":  "Generate shell completions",
+	"shell":       "Interactive shell sharing one connection",
 	"jobs":        "List and search jobs",
 	"result":      "Get job result",
 	"bulk":        "Bulk cancel/retry/delete operations",
 	"priority":    "Update job priority",
 	"retries":     "View job retry history",
 	"retry":       "Retry a job",
+	"diff":        "Compare two jobs field by field",
 	"metrics":     "View server metrics",
 	"rate-limits": "Inspect and override rate limits",
 	"events":      "Stream server-sent events",
 	"system":      "System maintenance and config",
 	"webhooks":    "Manage webhook subscriptions",
 	"stats":       "Aggregate system statistics",
+	"template":    "Manage local job templates",
+	"whoami":      "Show the authenticated identity and token expiry",
+	"replay-file": "Re-enqueue jobs from an NDJSON export",
 }
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// completionInstall writes the completion script for a shell to the
+// directory that shell loads completions from.
+func completionInstall(args []string) error {
+	fs := newFlagSet("completion install")
+	path := fs.String("path", "", "Write the script to this file instead of the detected location")
+	printOnly := fs.Bool("print", false, "Only print the target path")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs completion install <bash|zsh|fish> [flags]", "Write the completion script to the directory the shell loads completions from.")
+		return nil
+	}
+
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("shell type required\n\nUsage: ojs completion install <bash|zsh|fish> [--path <file>] [--print]")
+	}
+	shell := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	script, err := completionScript(shell)
+	if err != nil {
+		return err
+	}
+
+	target := *path
+	if target == "" {
+		if target, err = completionInstallPath(shell); err != nil {
+			return err
+		}
+	}
+	if *printOnly {
+		fmt.Println(target)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("create completion directory: %w", err)
+	}
+	if err := os.WriteFile(target, []byte(script), 0644); err != nil {
+		return fmt.Errorf("write completion script: %w", err)
+	}
+
+	output.Success("Installed %s completions to %s", shell, target)
+	if hint := completionHint(shell, target); hint != "" {
+		fmt.Println(hint)
+	}
+	return nil
+}
+
+// completionInstallPath returns the per-user file each shell loads
+// completions from without extra configuration where possible:
+//
+//	bash  $XDG_DATA_HOME/bash-completion/completions/ojs (bash-completion 2.x)
+//	zsh   ${ZDOTDIR:-$HOME}/.zfunc/_ojs (must be on $fpath)
+//	fish  $XDG_CONFIG_HOME/fish/completions/ojs.fish
+func completionInstallPath(shell string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locate home directory: %w (use --path)", err)
+	}
+	switch shell {
+	case "bash":
+		return filepath.Join(xdgDir("XDG_DATA_HOME", home, ".local", "share"), "bash-completion", "completions", "ojs"), nil
+	case "zsh":
+		base := os.Getenv("ZDOTDIR")
+		if base == "" {
+			base = home
+		}
+		return filepath.Join(base, ".zfunc", "_ojs"), nil
+	case "fish":
+		return filepath.Join(xdgDir("XDG_CONFIG_HOME", home, ".config"), "fish", "completions", "ojs.fish"), nil
+	default:
+		return "", fmt.Errorf("unsupported shell: %s\n\nSupported: bash, zsh, fish", shell)
+	}
+}
+
+// xdgDir returns $env if set to an absolute path, else home joined with
+// the default elements.
+func xdgDir(env, home string, def ...string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(append([]string{home}, def...)...)
+}
+
+// completionHint explains any shell setup the installed script still needs.
+func completionHint(shell, target string) string {
+	switch shell {
+	case "zsh":
+		return fmt.Sprintf("Make sure your .zshrc contains, before compinit:\n  fpath=(%s $fpath)\n  autoload -Uz compinit && compinit", filepath.Dir(target))
+	case "bash":
+		return "Requires the bash-completion package; open a new shell to load it."
+	}
+	return "Open a new shell to load it."
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompletionInstallPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("ZDOTDIR", "")
+
+	for shell, want := range map[string]string{
+		"bash": filepath.Join(home, ".local", "share", "bash-completion", "completions", "ojs"),
+		"zsh":  filepath.Join(home, ".zfunc", "_ojs"),
+		"fish": filepath.Join(home, ".config", "fish", "completions", "ojs.fish"),
+	} {
+		got, err := completionInstallPath(shell)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", shell, err)
+		}
+		if got != want {
+			t.Errorf("%s: path = %s, want %s", shell, got, want)
+		}
+	}
+
+	if _, err := completionInstallPath("tcsh"); err == nil {
+		t.Error("expected error for unsupported shell")
+	}
+}
+
+func TestCompletionInstallPath_EnvOverrides(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+	t.Setenv("ZDOTDIR", filepath.Join(home, "zsh"))
+
+	for shell, want := range map[string]string{
+		"bash": filepath.Join(home, "data", "bash-completion", "completions", "ojs"),
+		"zsh":  filepath.Join(home, "zsh", ".zfunc", "_ojs"),
+		"fish": filepath.Join(home, "cfg", "fish", "completions", "ojs.fish"),
+	} {
+		if got, _ := completionInstallPath(shell); got != want {
+			t.Errorf("%s: path = %s, want %s", shell, got, want)
+		}
+	}
+}
+
+func TestCompletionInstall_WritesScript(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	// The completions directory does not exist yet and must be created.
+	out := captureStdout(t, func() {
+		if err := Completion([]string{"install", "fish"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	target := filepath.Join(home, ".config", "fish", "completions", "ojs.fish")
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatalf("script not written: %v", err)
+	}
+	if string(data) != fishCompletion {
+		t.Error("installed script differs from `ojs completion fish`")
+	}
+	if !strings.Contains(out, target) {
+		t.Errorf("expected the target in the output:\n%s", out)
+	}
+}
+
+func TestCompletionInstall_PrintAndPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	custom := filepath.Join(t.TempDir(), "nested", "ojs.bash")
+
+	out := captureStdout(t, func() {
+		if err := Completion([]string{"install", "bash", "--path", custom, "--print"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if strings.TrimSpace(out) != custom {
+		t.Errorf("--print output = %q, want %s", out, custom)
+	}
+	if _, err := os.Stat(custom); !os.IsNotExist(err) {
+		t.Error("--print must not write the script")
+	}
+
+	captureStdout(t, func() {
+		if err := Completion([]string{"install", "bash", "--path", custom}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if _, err := os.Stat(custom); err != nil {
+		t.Errorf("expected script at --path: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// confirmInput is where confirmation answers are read from.
+var confirmInput io.Reader = os.Stdin
+
+// stdinIsTerminal reports whether stdin is attached to an interactive terminal.
+var stdinIsTerminal = func() bool {
+	fi, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmDestructive gates a destructive action behind an interactive prompt.
+// When expect is non-empty the user must type it exactly (e.g. a queue name);
+// otherwise a y/N answer is requested. --yes skips the prompt, and a
+// non-interactive stdin without --yes is an error rather than a silent yes.
+func confirmDestructive(action, expect string, yes bool) error {
+	if yes {
+		return nil
+	}
+	if !stdinIsTerminal() {
+		return fmt.Errorf("refusing to %s without confirmation: stdin is not a terminal (pass --yes to proceed)", action)
+	}
+
+	if expect != "" {
+		fmt.Fprintf(os.Stderr, "This will %s. Type %q to confirm: ", action, expect)
+	} else {
+		fmt.Fprintf(os.Stderr, "This will %s. Continue? [y/N]: ", action)
+	}
+
+	line, err := bufio.NewReader(confirmInput).ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("aborted: no confirmation received")
+	}
+	answer := strings.TrimSpace(line)
+
+	if expect != "" {
+		if answer != expect {
+			return fmt.Errorf("aborted: confirmation did not match %q", expect)
+		}
+		return nil
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("aborted")
+}
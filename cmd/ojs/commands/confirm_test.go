@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// withConfirm simulates an interactive (or not) stdin for the duration of a test.
+func withConfirm(t *testing.T, tty bool, input string) {
+	t.Helper()
+	origTTY, origInput := stdinIsTerminal, confirmInput
+	stdinIsTerminal = func() bool { return tty }
+	confirmInput = strings.NewReader(input)
+	t.Cleanup(func() {
+		stdinIsTerminal, confirmInput = origTTY, origInput
+	})
+}
+
+func TestConfirmDestructive_YesSkipsPrompt(t *testing.T) {
+	withConfirm(t, false, "")
+	if err := confirmDestructive("delete queue", "default", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestConfirmDestructive_NonTTYWithoutYes(t *testing.T) {
+	withConfirm(t, false, "y\n")
+	err := confirmDestructive("purge dead letter jobs", "", false)
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected error mentioning --yes, got %v", err)
+	}
+}
+
+func TestConfirmDestructive_TypedName(t *testing.T) {
+	withConfirm(t, true, "default\n")
+	if err := confirmDestructive("delete queue", "default", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	withConfirm(t, true, "defualt\n")
+	if err := confirmDestructive("delete queue", "default", false); err == nil {
+		t.Fatal("expected error for mismatched confirmation")
+	}
+}
+
+func TestConfirmDestructive_YesNo(t *testing.T) {
+	for input, wantOK := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		withConfirm(t, true, input)
+		err := confirmDestructive("purge", "", false)
+		if (err == nil) != wantOK {
+			t.Errorf("input %q: err = %v, want ok=%v", input, err, wantOK)
+		}
+	}
+}
+
+func TestQueues_Delete_RequiresConfirmation(t *testing.T) {
+	withConfirm(t, false, "")
+	called := false
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if err := Queues(c, []string{"--delete", "old-queue"}); err == nil {
+		t.Fatal("expected error without --yes on non-interactive stdin")
+	}
+	if called {
+		t.Error("queue was deleted without confirmation")
+	}
+}
+
+func TestBulk_Delete_ConfirmedInteractively(t *testing.T) {
+	withConfirm(t, true, "y\n")
+	called := false
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Write([]byte(`{"deleted":2,"failed":0}`))
+	})
+	if err := Bulk(c, []string{"delete", "--ids", "job-1,job-2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected bulk delete request after confirmation")
+	}
+}
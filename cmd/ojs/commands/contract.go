@@ -14,7 +14,7 @@ type ContractCommand struct{}
 
 // Run executes the contract command.
 func (c *ContractCommand) Run(args []string) error {
-	if len(args) == 0 {
+	if len(args) == 0 || isHelpArg(args[0]) {
 		return c.printUsage()
 	}
 
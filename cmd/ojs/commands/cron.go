@@ -2,16 +2,26 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/cron"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
 // Cron manages cron jobs.
 func Cron(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("cron", flag.ExitOnError)
+	if len(args) > 0 {
+		switch args[0] {
+		case "export":
+			return cronExport(c, args[1:])
+		case "apply":
+			return cronApply(c, args[1:])
+		}
+	}
+
+	fs := newFlagSet("cron")
 	register := fs.Bool("register", false, "Register a new cron job")
 	deleteName := fs.String("delete", "", "Delete a cron job by name")
 	name := fs.String("name", "", "Cron job name (for register)")
@@ -19,6 +29,7 @@ func Cron(c *client.Client, args []string) error {
 	jobType := fs.String("type", "", "Job type (for register)")
 	queue := fs.String("queue", "default", "Queue (for register)")
 	trigger := fs.String("trigger", "", "Trigger a cron job immediately by name")
+	triggerArgs := fs.String("args", "", "With --trigger, run once with these args (JSON array) instead of the template's")
 	history := fs.String("history", "", "Show execution history for a cron job")
 	historyLimit := fs.Int("history-limit", 10, "Max history entries to show")
 	pause := fs.String("pause", "", "Pause a cron job by name")
@@ -26,7 +37,21 @@ func Cron(c *client.Client, args []string) error {
 	detail := fs.String("detail", "", "Show detailed info for a cron job by name")
 	update := fs.String("update", "", "Update a cron job by name")
 	enabled := fs.String("enabled", "", "Filter list by enabled status (true/false)")
-	fs.Parse(args)
+	next := fs.String("next", "", "Preview upcoming run times for a cron job by name")
+	count := fs.Int("count", 10, "Number of upcoming runs to show (with --next)")
+	timezone := fs.String("timezone", "", "IANA timezone for --next (default: the cron job's timezone, else UTC)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs cron [flags]", "List, register, update, trigger, pause and delete cron jobs.\nUse \"ojs cron export\" and \"ojs cron apply\" to manage cron jobs as a file.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *next != "" {
+		return cronNext(c, *next, *count, *timezone)
+	}
 
 	if *detail != "" {
 		return cronDetail(c, *detail)
@@ -36,8 +61,11 @@ func Cron(c *client.Client, args []string) error {
 		return cronUpdate(c, *update, *expression, *jobType, *queue)
 	}
 
+	if *triggerArgs != "" && *trigger == "" {
+		return fmt.Errorf("--args requires --trigger\n\nUsage: ojs cron --trigger <name> --args '<json>'")
+	}
 	if *trigger != "" {
-		return triggerCron(c, *trigger)
+		return triggerCron(c, *trigger, *triggerArgs)
 	}
 
 	if *history != "" {
@@ -153,8 +181,19 @@ func listCron(c *client.Client, enabledFilter string) error {
 	return nil
 }
 
-func triggerCron(c *client.Client, name string) error {
-	data, _, err := c.Post("/cron/"+name+"/trigger", nil)
+// triggerCron runs a cron job once. A non-empty argsJSON replaces the
+// template's args for this run only.
+func triggerCron(c *client.Client, name, argsJSON string) error {
+	var body any
+	if argsJSON != "" {
+		var jobArgs []json.RawMessage
+		if err := json.Unmarshal([]byte(argsJSON), &jobArgs); err != nil {
+			return fmt.Errorf("invalid --args JSON (expected an array): %w", err)
+		}
+		body = map[string]any{"args": json.RawMessage(argsJSON)}
+	}
+
+	data, _, err := c.Post("/cron/"+name+"/trigger", body)
 	if err != nil {
 		return err
 	}
@@ -167,6 +206,10 @@ func triggerCron(c *client.Client, name string) error {
 
 	var resp map[string]any
 	json.Unmarshal(data, &resp)
+	if argsJSON != "" {
+		output.Success("Cron job %q triggered with overridden args (job_id=%s)", name, str(resp["job_id"]))
+		return nil
+	}
 	output.Success("Cron job %q triggered (job_id=%s)", name, str(resp["job_id"]))
 	return nil
 }
@@ -325,3 +368,68 @@ func cronUpdate(c *client.Client, name, expression, jobType, queue string) error
 	output.Success("Cron job %q updated", name)
 	return nil
 }
+
+// cronNext computes a cron job's upcoming runs client-side from its
+// expression, so it works even when the server does not report them.
+func cronNext(c *client.Client, name string, count int, timezone string) error {
+	if count < 1 {
+		return fmt.Errorf("--count must be at least 1\n\nUsage: ojs cron --next <name> [--count <n>] [--timezone <tz>]")
+	}
+	data, _, err := c.Get("/cron/" + name)
+	if err != nil {
+		return err
+	}
+
+	var cj struct {
+		Expression string `json:"expression"`
+		Timezone   string `json:"timezone"`
+	}
+	json.Unmarshal(data, &cj)
+	if cj.Expression == "" {
+		return fmt.Errorf("cron job %q has no expression", name)
+	}
+
+	if timezone == "" {
+		timezone = cj.Timezone
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return fmt.Errorf("invalid --timezone %q: %w", timezone, err)
+	}
+
+	sched, err := cron.Parse(cj.Expression)
+	if err != nil {
+		return err
+	}
+	now := time.Now().In(loc)
+	runs := sched.NextN(now, count)
+
+	if output.Format == "json" {
+		times := make([]string, 0, len(runs))
+		for _, r := range runs {
+			times = append(times, r.Format(time.RFC3339))
+		}
+		return output.JSON(map[string]any{
+			"name":       name,
+			"expression": cj.Expression,
+			"timezone":   timezone,
+			"next_runs":  times,
+		})
+	}
+
+	fmt.Printf("Next runs for %q (%s, %s):\n\n", name, cj.Expression, timezone)
+	headers := []string{"#", "RUN AT", "IN"}
+	rows := make([][]string, 0, len(runs))
+	for i, r := range runs {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			r.Format("2006-01-02 15:04 MST"),
+			r.Sub(now).Truncate(time.Minute).String(),
+		})
+	}
+	output.Table(headers, rows)
+	return nil
+}
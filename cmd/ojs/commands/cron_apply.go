@@ -0,0 +1,301 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+// cronSpec is the declarative form of a cron job used by export and apply.
+type cronSpec struct {
+	Name       string `yaml:"name" json:"name"`
+	Expression string `yaml:"expression" json:"expression"`
+	Type       string `yaml:"type" json:"type"`
+	Queue      string `yaml:"queue,omitempty" json:"queue,omitempty"`
+	Args       []any  `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+type cronSpecFile struct {
+	CronJobs []cronSpec `yaml:"cron_jobs" json:"cron_jobs"`
+}
+
+// cronChange is one step of a cron reconcile plan.
+type cronChange struct {
+	Action  string   `json:"action"` // "register", "update", "delete"
+	Spec    cronSpec `json:"spec"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+func cronExport(c *client.Client, args []string) error {
+	fs := newFlagSet("cron export")
+	out := fs.String("out", "", "Output file (default: stdout)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs cron export [flags]", "Write all cron jobs as a YAML or JSON file suitable for \"ojs cron apply\".")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	specs, err := fetchCronSpecs(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(cronSpecFile{CronJobs: specs})
+	if err != nil {
+		return fmt.Errorf("encode cron jobs: %w", err)
+	}
+	if *out == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", *out, err)
+	}
+	output.Success("Exported %d cron jobs to %s", len(specs), *out)
+	return nil
+}
+
+func cronApply(c *client.Client, args []string) error {
+	fs := newFlagSet("cron apply")
+	file := fs.String("file", "", "Cron definitions file (required)")
+	dryRun := fs.Bool("dry-run", false, "Show the planned changes without applying them")
+	prune := fs.Bool("prune", false, "Delete cron jobs that are not in the file")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt for --prune deletions")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs cron apply --file <crons.yaml> [flags]", "Create or update cron jobs so the server matches a file.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("--file is required\n\nUsage: ojs cron apply --file <crons.yaml> [--dry-run] [--prune]")
+	}
+
+	raw, err := os.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", *file, err)
+	}
+	var desired cronSpecFile
+	if err := yaml.Unmarshal(raw, &desired); err != nil {
+		return fmt.Errorf("parse %s: %w", *file, err)
+	}
+	for i, cj := range desired.CronJobs {
+		if cj.Name == "" || cj.Expression == "" || cj.Type == "" {
+			return fmt.Errorf("%s: cron job %d needs name, expression, and type", *file, i+1)
+		}
+	}
+
+	current, err := fetchCronSpecs(c)
+	if err != nil {
+		return err
+	}
+
+	plan := planCronApply(desired.CronJobs, current, *prune)
+
+	if *dryRun || len(plan) == 0 {
+		return printCronPlan(plan, *dryRun)
+	}
+
+	var deletes []string
+	for _, ch := range plan {
+		if ch.Action == "delete" {
+			deletes = append(deletes, ch.Spec.Name)
+		}
+	}
+	if len(deletes) > 0 {
+		if err := confirmDestructive("delete cron jobs "+strings.Join(deletes, ", "), "", *yes); err != nil {
+			return err
+		}
+	}
+
+	for _, ch := range plan {
+		var err error
+		switch ch.Action {
+		case "register":
+			body := cronSpecBody(ch.Spec)
+			body["name"] = ch.Spec.Name
+			_, _, err = c.Post("/cron", body)
+		case "update":
+			_, _, err = c.Patch("/cron/"+ch.Spec.Name, cronSpecBody(ch.Spec))
+		case "delete":
+			_, _, err = c.Delete("/cron/" + ch.Spec.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("%s cron job %q: %w", ch.Action, ch.Spec.Name, err)
+		}
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"applied": plan})
+	}
+	output.Success("Applied %d cron change(s)", len(plan))
+	return nil
+}
+
+// fetchCronSpecs reads every registered cron job with its job template.
+func fetchCronSpecs(c *client.Client) ([]cronSpec, error) {
+	data, _, err := c.Get("/cron")
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		CronJobs []struct {
+			Name string `json:"name"`
+		} `json:"cron_jobs"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parse cron response: %w", err)
+	}
+
+	specs := make([]cronSpec, 0, len(resp.CronJobs))
+	for _, cj := range resp.CronJobs {
+		data, _, err := c.Get("/cron/" + cj.Name)
+		if err != nil {
+			return nil, fmt.Errorf("read cron job %q: %w", cj.Name, err)
+		}
+		var detail struct {
+			Expression  string `json:"expression"`
+			JobTemplate struct {
+				Type    string `json:"type"`
+				Args    []any  `json:"args"`
+				Options struct {
+					Queue string `json:"queue"`
+				} `json:"options"`
+			} `json:"job_template"`
+		}
+		json.Unmarshal(data, &detail)
+		specs = append(specs, cronSpec{
+			Name:       cj.Name,
+			Expression: detail.Expression,
+			Type:       detail.JobTemplate.Type,
+			Queue:      detail.JobTemplate.Options.Queue,
+			Args:       detail.JobTemplate.Args,
+		})
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+	return specs, nil
+}
+
+// planCronApply computes the changes needed to reconcile current with
+// desired. Cron jobs absent from desired are only deleted when prune is set.
+func planCronApply(desired, current []cronSpec, prune bool) []cronChange {
+	existing := make(map[string]cronSpec, len(current))
+	for _, cj := range current {
+		existing[cj.Name] = cj
+	}
+
+	var plan []cronChange
+	wanted := make(map[string]bool, len(desired))
+	for _, want := range desired {
+		wanted[want.Name] = true
+		have, ok := existing[want.Name]
+		if !ok {
+			plan = append(plan, cronChange{Action: "register", Spec: want})
+			continue
+		}
+		var changes []string
+		if want.Expression != have.Expression {
+			changes = append(changes, fmt.Sprintf("expression: %s -> %s", have.Expression, want.Expression))
+		}
+		if want.Type != have.Type {
+			changes = append(changes, fmt.Sprintf("type: %s -> %s", have.Type, want.Type))
+		}
+		if cronQueue(want) != cronQueue(have) {
+			changes = append(changes, fmt.Sprintf("queue: %s -> %s", cronQueue(have), cronQueue(want)))
+		}
+		if !sameJSON(want.Args, have.Args) {
+			changes = append(changes, "args changed")
+		}
+		if len(changes) > 0 {
+			plan = append(plan, cronChange{Action: "update", Spec: want, Changes: changes})
+		}
+	}
+
+	if prune {
+		for _, have := range current {
+			if !wanted[have.Name] {
+				plan = append(plan, cronChange{Action: "delete", Spec: have})
+			}
+		}
+	}
+	return plan
+}
+
+func cronQueue(spec cronSpec) string {
+	if spec.Queue == "" {
+		return "default"
+	}
+	return spec.Queue
+}
+
+// sameJSON compares two values by their JSON encoding, so that YAML ints and
+// JSON floats of the same number compare equal.
+func sameJSON(a, b any) bool {
+	var na, nb any
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	json.Unmarshal(ja, &na)
+	json.Unmarshal(jb, &nb)
+	if isEmptyJSON(na) && isEmptyJSON(nb) {
+		return true
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+func isEmptyJSON(v any) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(t) == 0
+	}
+	return false
+}
+
+func cronSpecBody(spec cronSpec) map[string]any {
+	template := map[string]any{
+		"type":    spec.Type,
+		"options": map[string]any{"queue": cronQueue(spec)},
+	}
+	if len(spec.Args) > 0 {
+		template["args"] = spec.Args
+	}
+	return map[string]any{
+		"expression":   spec.Expression,
+		"job_template": template,
+	}
+}
+
+func printCronPlan(plan []cronChange, dryRun bool) error {
+	if output.Format == "json" {
+		if plan == nil {
+			plan = []cronChange{}
+		}
+		return output.JSON(map[string]any{"dry_run": dryRun, "changes": plan})
+	}
+	if len(plan) == 0 {
+		fmt.Println("Cron jobs are up to date.")
+		return nil
+	}
+
+	headers := []string{"ACTION", "NAME", "CHANGES"}
+	rows := make([][]string, 0, len(plan))
+	for _, ch := range plan {
+		rows = append(rows, []string{ch.Action, ch.Spec.Name, strings.Join(ch.Changes, "; ")})
+	}
+	output.Table(headers, rows)
+	return nil
+}
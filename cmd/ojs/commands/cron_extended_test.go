@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanCronApply(t *testing.T) {
+	current := []cronSpec{
+		{Name: "daily-report", Expression: "0 9 * * *", Type: "report.generate"},
+		{Name: "cleanup", Expression: "0 * * * *", Type: "cleanup.run", Queue: "maintenance", Args: []any{float64(30)}},
+		{Name: "old-sync", Expression: "*/5 * * * *", Type: "sync.run"},
+	}
+	desired := []cronSpec{
+		{Name: "daily-report", Expression: "0 10 * * *", Type: "report.generate", Queue: "default"},
+		{Name: "cleanup", Expression: "0 * * * *", Type: "cleanup.run", Queue: "maintenance", Args: []any{30}},
+		{Name: "weekly-digest", Expression: "0 8 * * 1", Type: "digest.send"},
+	}
+
+	plan := planCronApply(desired, current, false)
+	var got []string
+	for _, ch := range plan {
+		got = append(got, ch.Action+":"+ch.Spec.Name)
+	}
+	if strings.Join(got, ",") != "update:daily-report,register:weekly-digest" {
+		t.Errorf("plan = %v, want update:daily-report,register:weekly-digest", got)
+	}
+	if len(plan[0].Changes) != 1 || !strings.HasPrefix(plan[0].Changes[0], "expression:") {
+		t.Errorf("daily-report changes = %v, want only expression", plan[0].Changes)
+	}
+
+	plan = planCronApply(desired, current, true)
+	last := plan[len(plan)-1]
+	if last.Action != "delete" || last.Spec.Name != "old-sync" {
+		t.Errorf("prune plan last = %s:%s, want delete:old-sync", last.Action, last.Spec.Name)
+	}
+}
+
+func TestPlanCronApply_TemplateChange(t *testing.T) {
+	current := []cronSpec{{Name: "a", Expression: "@hourly", Type: "x", Args: []any{"one"}}}
+	desired := []cronSpec{{Name: "a", Expression: "@hourly", Type: "y", Queue: "fast", Args: []any{"two"}}}
+
+	plan := planCronApply(desired, current, false)
+	if len(plan) != 1 || len(plan[0].Changes) != 3 {
+		t.Fatalf("plan = %+v, want one update with type, queue, and args changes", plan)
+	}
+}
+
+func TestCron_Apply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "crons.yaml")
+	os.WriteFile(path, []byte(`cron_jobs:
+  - name: daily-report
+    expression: "0 10 * * *"
+    type: report.generate
+  - name: weekly-digest
+    expression: "0 8 * * 1"
+    type: digest.send
+`), 0644)
+
+	var mutations []string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/cron":
+			json.NewEncoder(w).Encode(map[string]any{
+				"cron_jobs": []map[string]any{{"name": "daily-report"}, {"name": "old-sync"}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/cron/daily-report":
+			json.NewEncoder(w).Encode(map[string]any{
+				"expression":   "0 9 * * *",
+				"job_template": map[string]any{"type": "report.generate", "options": map[string]any{"queue": "default"}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/cron/old-sync":
+			json.NewEncoder(w).Encode(map[string]any{
+				"expression":   "*/5 * * * *",
+				"job_template": map[string]any{"type": "sync.run"},
+			})
+		default:
+			mutations = append(mutations, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{}`))
+		}
+	})
+
+	if err := Cron(c, []string{"apply", "--file", path, "--dry-run", "--prune"}); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(mutations) != 0 {
+		t.Fatalf("dry run made changes: %v", mutations)
+	}
+
+	if err := Cron(c, []string{"apply", "--file", path, "--prune", "--yes"}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	want := "PATCH /ojs/v1/cron/daily-report,POST /ojs/v1/cron,DELETE /ojs/v1/cron/old-sync"
+	if got := strings.Join(mutations, ","); got != want {
+		t.Errorf("mutations = %s, want %s", got, want)
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/cron/daily-report" {
+			t.Errorf("path = %s, want /ojs/v1/cron/daily-report", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"name": "daily-report", "expression": "0 9 * * *"})
+	})
+
+	out := captureStdout(t, func() {
+		if err := Cron(c, []string{"--next", "daily-report", "--count", "3"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var resp struct {
+		Timezone string   `json:"timezone"`
+		NextRuns []string `json:"next_runs"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parse output %q: %v", out, err)
+	}
+	if resp.Timezone != "UTC" || len(resp.NextRuns) != 3 {
+		t.Fatalf("resp = %+v, want 3 UTC runs", resp)
+	}
+	for _, run := range resp.NextRuns {
+		ts, err := time.Parse(time.RFC3339, run)
+		if err != nil || ts.Hour() != 9 || ts.Minute() != 0 {
+			t.Errorf("run %s is not at 09:00", run)
+		}
+	}
+}
+
+func TestCron_Next_InvalidTimezone(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"expression": "0 9 * * *"})
+	})
+	if err := Cron(c, []string{"--next", "daily-report", "--timezone", "Mars/Olympus"}); err == nil {
+		t.Fatal("expected error for invalid timezone")
+	}
+}
+
+func TestCron_Next_InvalidCount(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	for _, count := range []string{"0", "-1"} {
+		if err := Cron(c, []string{"--next", "daily-report", "--count", count}); err == nil {
+			t.Errorf("expected error for --count %s", count)
+		}
+	}
+}
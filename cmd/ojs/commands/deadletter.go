@@ -2,8 +2,8 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"net/url"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -11,21 +11,44 @@ import (
 
 // DeadLetter manages the dead letter queue.
 func DeadLetter(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("dead-letter", flag.ExitOnError)
+	fs := newFlagSet("dead-letter")
 	retryID := fs.String("retry", "", "Retry a dead letter job by ID")
 	deleteID := fs.String("delete", "", "Delete a dead letter job by ID")
 	limit := fs.Int("limit", 25, "Max results to return")
 	purge := fs.Bool("purge", false, "Purge all dead letter jobs")
 	stats := fs.Bool("stats", false, "Show dead letter queue statistics")
 	olderThan := fs.String("older-than", "", "Purge jobs older than duration (e.g. 7d, 24h)")
-	fs.Parse(args)
+	queue := fs.String("queue", "", "Only purge jobs from this queue (with --purge)")
+	jobType := fs.String("type", "", "Only purge jobs of this type (with --purge)")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt for --purge")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs dead-letter [flags]", "List, retry, delete and purge jobs in the dead letter queue.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	if *stats {
 		return deadLetterStats(c)
 	}
 
 	if *purge {
-		return deadLetterPurge(c, *olderThan)
+		action := "purge dead letter jobs"
+		if *queue != "" {
+			action += fmt.Sprintf(" in queue %q", *queue)
+		}
+		if *jobType != "" {
+			action += fmt.Sprintf(" of type %q", *jobType)
+		}
+		if err := confirmDestructive(action, "", *yes); err != nil {
+			return err
+		}
+		return deadLetterPurge(c, *olderThan, *queue, *jobType)
+	}
+	if *queue != "" || *jobType != "" {
+		return fmt.Errorf("--queue and --type require --purge\n\nUsage: ojs dead-letter --purge [--queue <queue>] [--type <type>] [--older-than <duration>] [--yes]")
 	}
 
 	if *retryID != "" {
@@ -136,13 +159,55 @@ func deadLetterStats(c *client.Client) error {
 	return nil
 }
 
-func deadLetterPurge(c *client.Client, olderThan string) error {
-	path := "/dead-letter/purge"
+// deadLetterPurge purges dead letter jobs. The filter goes in both the query
+// string, which older servers read, and the request body. A purge scoped by
+// queue or type first lists the matching jobs and refuses to run unless the
+// server is seen to apply the filter, since a server that ignores it would
+// purge the whole dead letter queue.
+func deadLetterPurge(c *client.Client, olderThan, queue, jobType string) error {
+	query := url.Values{}
 	if olderThan != "" {
-		path += "?older_than=" + olderThan
+		query.Set("older_than", olderThan)
+	}
+	if queue != "" {
+		query.Set("queue", queue)
+	}
+	if jobType != "" {
+		query.Set("type", jobType)
+	}
+	path := "/dead-letter/purge"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var body any
+	if queue != "" || jobType != "" {
+		matching, err := confirmDeadLetterScope(c, query, queue, jobType)
+		if err != nil {
+			return err
+		}
+		if matching == 0 {
+			if output.Format == "json" {
+				return output.JSON(map[string]any{"deleted": 0})
+			}
+			fmt.Println("No matching dead letter jobs.")
+			return nil
+		}
+
+		filter := map[string]any{}
+		if queue != "" {
+			filter["queue"] = queue
+		}
+		if jobType != "" {
+			filter["type"] = jobType
+		}
+		if olderThan != "" {
+			filter["older_than"] = olderThan
+		}
+		body = filter
 	}
 
-	data, _, err := c.Post(path, nil)
+	data, _, err := c.Post(path, body)
 	if err != nil {
 		return err
 	}
@@ -160,3 +225,35 @@ func deadLetterPurge(c *client.Client, olderThan string) error {
 	output.Success("Purged %d dead letter jobs", resp.Deleted)
 	return nil
 }
+
+// confirmDeadLetterScope lists the dead letter jobs matching query and
+// returns how many there are. It fails if any listed job falls outside the
+// queue or type filter, which means the server ignores the filter.
+func confirmDeadLetterScope(c *client.Client, query url.Values, queue, jobType string) (int, error) {
+	list := url.Values{}
+	for k, v := range query {
+		list[k] = v
+	}
+	list.Set("limit", fmt.Sprintf("%d", searchPageSize))
+	data, _, err := c.Get("/dead-letter?" + list.Encode())
+	if err != nil {
+		return 0, fmt.Errorf("check purge scope: %w", err)
+	}
+	var resp struct {
+		Jobs  []map[string]any `json:"jobs"`
+		Total *int             `json:"total"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return 0, fmt.Errorf("check purge scope: %w", err)
+	}
+	for _, j := range resp.Jobs {
+		if (queue != "" && j["queue"] != queue) || (jobType != "" && j["type"] != jobType) {
+			return 0, fmt.Errorf("refusing to purge: the server does not filter dead letter jobs by queue or type (listed job %s is %s in queue %s), so a scoped purge could delete every dead letter job",
+				str(j["id"]), str(j["type"]), str(j["queue"]))
+		}
+	}
+	if resp.Total != nil {
+		return *resp.Total, nil
+	}
+	return len(resp.Jobs), nil
+}
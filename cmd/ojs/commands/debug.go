@@ -2,7 +2,6 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
 	"strings"
 	"time"
@@ -13,7 +12,7 @@ import (
 
 // Debug provides interactive debugging commands for OJS jobs.
 func Debug(c *client.Client, args []string) error {
-	if len(args) == 0 {
+	if len(args) == 0 || isHelpArg(args[0]) {
 		return debugHelp()
 	}
 
@@ -62,6 +61,11 @@ Examples:
 
 // debugInspect shows detailed job information.
 func debugInspect(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs debug inspect <job-id>", "Show detailed job state, args, errors and metadata.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("usage: ojs debug inspect <job-id>")
 	}
@@ -122,6 +126,11 @@ func debugInspect(c *client.Client, args []string) error {
 
 // debugTrace shows trace information for a job.
 func debugTrace(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs debug trace <job-id>", "Show the distributed trace spans of a job.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("usage: ojs debug trace <job-id>")
 	}
@@ -174,10 +183,17 @@ func debugTrace(c *client.Client, args []string) error {
 
 // debugReplay re-enqueues a failed job.
 func debugReplay(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("debug replay", flag.ExitOnError)
+	fs := newFlagSet("debug replay")
 	queue := fs.String("queue", "", "Override queue for replayed job")
 	priority := fs.Int("priority", 0, "Override priority")
-	fs.Parse(args)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs debug replay <job-id> [flags]", "Re-enqueue a failed job, optionally with different args or queue.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	remaining := fs.Args()
 	if len(remaining) == 0 {
@@ -230,6 +246,11 @@ func debugReplay(c *client.Client, args []string) error {
 
 // debugHistory shows state transition timeline.
 func debugHistory(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs debug history <job-id>", "Show the state transition timeline of a job.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("usage: ojs debug history <job-id>")
 	}
@@ -278,9 +299,16 @@ func debugHistory(c *client.Client, args []string) error {
 
 // debugBottleneck identifies slowest job types and queues.
 func debugBottleneck(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("debug bottleneck", flag.ExitOnError)
+	fs := newFlagSet("debug bottleneck")
 	limit := fs.Int("limit", 10, "Number of results")
-	fs.Parse(args)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs debug bottleneck [flags]", "Identify the slowest job types and queues.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	data, _, err := c.Get(fmt.Sprintf("/admin/stats?detail=true&limit=%d", *limit))
 	if err != nil {
@@ -318,6 +346,11 @@ func debugBottleneck(c *client.Client, args []string) error {
 
 // debugQueue shows live queue information.
 func debugQueue(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs debug queue <queue-name>", "Show live queue depth, throughput and latency.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("usage: ojs debug queue <queue-name>")
 	}
@@ -348,9 +381,16 @@ func debugQueue(c *client.Client, args []string) error {
 
 // debugFailures lists recent failures.
 func debugFailures(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("debug failures", flag.ExitOnError)
+	fs := newFlagSet("debug failures")
 	limit := fs.Int("limit", 20, "Number of failures to show")
-	fs.Parse(args)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs debug failures [flags]", "List recent failures with error details.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	data, _, err := c.Get(fmt.Sprintf("/jobs?state=discarded&limit=%d", *limit))
 	if err != nil {
@@ -377,18 +417,18 @@ func debugFailures(c *client.Client, args []string) error {
 	fmt.Println("─────────────────────────────────────────────────────")
 
 	for _, j := range resp.Jobs {
-		errStr := j.Error
-		if len(errStr) > 60 {
-			errStr = errStr[:57] + "..."
-		}
-		fmt.Printf("  %s  %-20s  %s\n", j.ID[:12], j.Type, errStr)
+		fmt.Printf("  %s  %-20s  %s\n", j.ID[:12], j.Type, output.FitCell(j.Error))
 	}
 
 	return nil
 }
 
 // debugHealth shows composite system health.
-func debugHealth(c *client.Client, _ []string) error {
+func debugHealth(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs debug health", "Show a composite system health score.")
+		return nil
+	}
 	data, _, err := c.Get("/admin/observability/health")
 	if err != nil {
 		// Fallback to basic health check
@@ -447,7 +487,7 @@ func colorState(state string) string {
 	switch state {
 	case "completed":
 		return "✓ " + state
-	case "active":
+	case "active", "running":
 		return "⟳ " + state
 	case "available", "pending":
 		return "○ " + state
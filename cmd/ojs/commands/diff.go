@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// Diff compares server resources side by side.
+func Diff(c *client.Client, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("subcommand required\n\nUsage: ojs diff jobs <job-id-a> <job-id-b> [--all]")
+	}
+	if isHelpArg(args[0]) {
+		fmt.Println("Usage: ojs diff jobs <job-id-a> <job-id-b> [--all]\n\nCompare server resources side by side.")
+		return nil
+	}
+
+	switch args[0] {
+	case "jobs":
+		return diffJobs(c, args[1:])
+	default:
+		return fmt.Errorf("unknown diff subcommand: %s\n\nUsage: ojs diff jobs <job-id-a> <job-id-b> [--all]", args[0])
+	}
+}
+
+// diffedJobFields are the envelope fields compared by "diff jobs". Nested
+// values are compared leaf by leaf.
+var diffedJobFields = []string{"type", "queue", "state", "priority", "attempt", "args", "options", "meta", "error"}
+
+// fieldDiff is one compared leaf. A or B is nil when the field is absent from
+// that job.
+type fieldDiff struct {
+	Field string `json:"field"`
+	A     any    `json:"a"`
+	B     any    `json:"b"`
+	Equal bool   `json:"equal"`
+}
+
+func diffJobs(c *client.Client, args []string) error {
+	fs := newFlagSet("diff jobs")
+	all := fs.Bool("all", false, "Also show fields that are the same in both jobs")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs diff jobs <job-id-a> <job-id-b> [flags]", "Compare two jobs field by field, including nested args, options and meta.")
+		return nil
+	}
+
+	if len(args) < 2 || strings.HasPrefix(args[0], "-") || strings.HasPrefix(args[1], "-") {
+		return fmt.Errorf("two job IDs required\n\nUsage: ojs diff jobs <job-id-a> <job-id-b> [--all]")
+	}
+	idA, idB := args[0], args[1]
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+
+	jobA, err := fetchJobEnvelope(c, idA)
+	if err != nil {
+		return err
+	}
+	jobB, err := fetchJobEnvelope(c, idB)
+	if err != nil {
+		return err
+	}
+
+	fields := diffJobEnvelopes(jobA, jobB)
+	differences := 0
+	for _, f := range fields {
+		if !f.Equal {
+			differences++
+		}
+	}
+
+	if output.Format == "json" {
+		shown := fields
+		if !*all {
+			shown = []fieldDiff{}
+			for _, f := range fields {
+				if !f.Equal {
+					shown = append(shown, f)
+				}
+			}
+		}
+		return output.JSON(map[string]any{
+			"job_a":       idA,
+			"job_b":       idB,
+			"identical":   differences == 0,
+			"differences": differences,
+			"fields":      shown,
+		})
+	}
+
+	if differences == 0 && !*all {
+		output.Success("Jobs %s and %s have identical args, options, meta and error", idA, idB)
+		return nil
+	}
+
+	headers := []string{"", "FIELD", idA, idB}
+	var rows [][]string
+	for _, f := range fields {
+		if f.Equal && !*all {
+			continue
+		}
+		marker := "≠"
+		if f.Equal {
+			marker = "="
+		}
+		rows = append(rows, []string{marker, f.Field, diffValue(f.A), diffValue(f.B)})
+	}
+	output.Table(headers, rows)
+	fmt.Printf("\n%d field(s) differ\n", differences)
+	return nil
+}
+
+func fetchJobEnvelope(c *client.Client, jobID string) (map[string]any, error) {
+	data, _, err := c.Get("/admin/jobs/" + jobID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch job %s: %w", jobID, err)
+	}
+	var job map[string]any
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("parse job %s: %w", jobID, err)
+	}
+	return job, nil
+}
+
+// diffJobEnvelopes compares the diffed fields of two jobs, ordered as in
+// diffedJobFields and then by path within each field.
+func diffJobEnvelopes(a, b map[string]any) []fieldDiff {
+	var out []fieldDiff
+	for _, field := range diffedJobFields {
+		leavesA := map[string]any{}
+		leavesB := map[string]any{}
+		flattenJobField(field, a[field], leavesA)
+		flattenJobField(field, b[field], leavesB)
+
+		paths := make([]string, 0, len(leavesA)+len(leavesB))
+		for p := range leavesA {
+			paths = append(paths, p)
+		}
+		for p := range leavesB {
+			if _, ok := leavesA[p]; !ok {
+				paths = append(paths, p)
+			}
+		}
+		sort.Strings(paths)
+
+		for _, p := range paths {
+			va, vb := leavesA[p], leavesB[p]
+			out = append(out, fieldDiff{Field: p, A: va, B: vb, Equal: reflect.DeepEqual(va, vb)})
+		}
+	}
+	return out
+}
+
+// flattenJobField records the leaves of v under dotted paths, with array
+// elements as path[i]. Absent (nil) values record nothing; empty objects and
+// arrays are kept as leaves so they still show up against a populated value.
+func flattenJobField(path string, v any, out map[string]any) {
+	switch t := v.(type) {
+	case nil:
+	case map[string]any:
+		if len(t) == 0 {
+			out[path] = t
+		}
+		for k, child := range t {
+			flattenJobField(path+"."+k, child, out)
+		}
+	case []any:
+		if len(t) == 0 {
+			out[path] = t
+		}
+		for i, child := range t {
+			flattenJobField(fmt.Sprintf("%s[%d]", path, i), child, out)
+		}
+	default:
+		out[path] = t
+	}
+}
+
+func diffValue(v any) string {
+	if v == nil {
+		return "-"
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var diffFixtureJobs = map[string]string{
+	"/ojs/v1/admin/jobs/job-a": `{
+		"id": "job-a", "type": "email.send", "queue": "default", "state": "completed", "attempt": 1,
+		"args": ["user@example.com", {"template": "welcome", "locale": "en"}],
+		"options": {"max_attempts": 3, "timeout_ms": 30000},
+		"meta": {"tenant": "acme"}
+	}`,
+	"/ojs/v1/admin/jobs/job-b": `{
+		"id": "job-b", "type": "email.send", "queue": "default", "state": "discarded", "attempt": 3,
+		"args": ["user@example.com", {"template": "welcome", "locale": "de"}],
+		"options": {"max_attempts": 3, "timeout_ms": 5000, "unique_key": "u1"},
+		"meta": {"tenant": "acme"},
+		"error": {"message": "smtp timeout"}
+	}`,
+}
+
+func diffFixtureClient(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, ok := diffFixtureJobs[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}
+}
+
+func TestDiffJobs_JSON(t *testing.T) {
+	c := newTestClient(diffFixtureClient(t))
+
+	out := captureStdout(t, func() {
+		if err := Diff(c, []string{"jobs", "job-a", "job-b"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result struct {
+		Identical   bool        `json:"identical"`
+		Differences int         `json:"differences"`
+		Fields      []fieldDiff `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+
+	got := map[string]fieldDiff{}
+	var order []string
+	for _, f := range result.Fields {
+		got[f.Field] = f
+		order = append(order, f.Field)
+	}
+	want := []string{"state", "attempt", "args[1].locale", "options.timeout_ms", "options.unique_key", "error.message"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Fatalf("differing fields = %v, want %v", order, want)
+	}
+	if result.Identical || result.Differences != len(want) {
+		t.Errorf("unexpected summary: identical=%v differences=%d", result.Identical, result.Differences)
+	}
+	if f := got["args[1].locale"]; f.A != "en" || f.B != "de" {
+		t.Errorf("unexpected args diff: %+v", f)
+	}
+	if f := got["options.unique_key"]; f.A != nil || f.B != "u1" {
+		t.Errorf("expected unique_key only in job-b: %+v", f)
+	}
+}
+
+func TestDiffJobs_TableAll(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(diffFixtureClient(t))
+
+	out := captureStdout(t, func() {
+		if err := Diff(c, []string{"jobs", "job-a", "job-b", "--all"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"options.timeout_ms", "30000", "5000", "meta.tenant", "6 field(s) differ"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDiffJobEnvelopes_Identical(t *testing.T) {
+	job := map[string]any{"type": "t", "args": []any{1.0, "x"}, "options": map[string]any{}}
+	for _, f := range diffJobEnvelopes(job, job) {
+		if !f.Equal {
+			t.Errorf("expected %s to be equal", f.Field)
+		}
+	}
+}
+
+func TestDiff_Usage(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {})
+	for _, args := range [][]string{nil, {"queues"}, {"jobs", "job-a"}} {
+		if err := Diff(c, args); err == nil {
+			t.Errorf("%v: expected usage error", args)
+		}
+	}
+}
@@ -1,15 +1,18 @@
 package commands
 
 import (
+	"context"
 	"encoding/json"
-	"flag"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/doctor"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
@@ -20,23 +23,405 @@ type checkResult struct {
 }
 
 func Doctor(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
-	production := fs.Bool("production", false, "Run production readiness checks")
+	fs := newFlagSet("doctor")
+	quick := fs.Bool("quick", false, "Run only the lightweight connectivity checks")
+	production := fs.Bool("production", false, "With --quick, add production readiness checks")
 	verbose := fs.Bool("verbose", false, "Show all checks including passed")
+	baseline := fs.String("baseline", "", "Compare the audit against a saved baseline report")
+	saveBaseline := fs.String("save-baseline", "", "Run the audit and save the report as a baseline")
+	format := fs.String("output", "", "Report format: text, json, or sarif")
+	only := fs.String("only", "", "Run only these check categories (comma-separated)")
+	skip := fs.String("skip", "", "Skip these check categories (comma-separated)")
+	emitFixes := fs.Bool("emit-fixes", false, "Print runnable ojs commands that remediate failing checks")
+	applyFixes := fs.Bool("apply-fixes", false, "Run the safe remediation commands after confirmation")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt for --apply-fixes")
+	servers := fs.String("servers", "", "Audit every server URL listed in this file (one per line)")
+	minGrade := fs.String("min-grade", "", "With --servers, fail if any server grades below this (A-F)")
+	outputDir := fs.String("output-dir", "", "Save the JSON report to a timestamped file in this directory")
 	fs.Usage = func() {
 		fmt.Print(`Usage: ojs doctor [flags]
 
-Run health and configuration checks against an OJS server.
+Audit an OJS server for production readiness and print a graded report.
 
 Flags:
-  --production  Run production readiness checks (TLS, auth, metrics, etc.)
+  --quick       Run only the lightweight connectivity checks
+  --production  With --quick, add TLS, auth, metrics, and worker checks
   --verbose     Show all checks including passed ones
+  --baseline <file>
+                Run the audit and fail if the score dropped or any check
+                regressed compared to the saved report
+  --save-baseline <file>
+                Run the audit and save the report for later --baseline runs
+  --output <text|json|sarif>
+                Report format (default: text, or json with --json)
+  --only <categories>
+                Run only these categories (security, reliability,
+                observability, operations, compliance); the score covers
+                only the checks that ran
+  --skip <categories>
+                Skip these categories
+  --emit-fixes  Print runnable ojs commands for failing checks that map to
+                a CLI action; ones that need review are commented out
+  --apply-fixes Run the safe remediation commands after confirmation;
+                ones that need review are printed but not executed
+  --yes         Skip the confirmation prompt for --apply-fixes
+  --servers <file>
+                Audit every server URL in the file (one per line, # for
+                comments) and print a combined scorecard
+  --min-grade <A-F>
+                With --servers, fail if any server grades below this;
+                without it, fail if any server has critical findings
+  --output-dir <dir>
+                Save the JSON report as doctor-<timestamp>.json in the
+                directory instead of printing it, for scheduled captures
 `)
 	}
+	if helpRequested(args) {
+		fs.Usage()
+		return nil
+	}
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
+	switch *format {
+	case "":
+	case "text":
+		output.Format = "table"
+	case "json":
+		output.Format = "json"
+	case "sarif":
+		if *quick || *baseline != "" || *saveBaseline != "" {
+			return fmt.Errorf("--output sarif only applies to the full audit, not --quick or baselines")
+		}
+	default:
+		return fmt.Errorf("unsupported output format %q\n\nUsage: ojs doctor --output <text|json|sarif>", *format)
+	}
+
+	categories, err := doctor.SelectCategories(splitCommaStr(*only), splitCommaStr(*skip))
+	if err != nil {
+		return err
+	}
+	if categories != nil && (*quick || *baseline != "" || *saveBaseline != "") {
+		return fmt.Errorf("--only and --skip only apply to the full audit, not --quick or baselines")
+	}
+	if (*emitFixes || *applyFixes) && (*quick || *baseline != "" || *saveBaseline != "" || *format == "sarif") {
+		return fmt.Errorf("--emit-fixes and --apply-fixes only apply to the full audit, not --quick, baselines or sarif")
+	}
+
+	if *outputDir != "" && (*quick || *baseline != "" || *saveBaseline != "" || *emitFixes || *applyFixes || *servers != "" || *format == "sarif") {
+		return fmt.Errorf("--output-dir only applies to the full audit, not --quick, baselines, fixes, --servers or sarif")
+	}
+	if *minGrade != "" && *servers == "" {
+		return fmt.Errorf("--min-grade requires --servers\n\nUsage: ojs doctor --servers <file> --min-grade <A-F>")
+	}
+	if *servers != "" {
+		if *quick || *baseline != "" || *saveBaseline != "" || *emitFixes || *applyFixes || *format == "sarif" {
+			return fmt.Errorf("--servers only applies to the full audit, not --quick, baselines, fixes or sarif")
+		}
+		return doctorFleet(c, *servers, *minGrade, categories)
+	}
+
+	if *baseline != "" || *saveBaseline != "" {
+		return doctorBaseline(c, *baseline, *saveBaseline)
+	}
+	if *quick {
+		return doctorQuick(c, *production, *verbose)
+	}
+
+	report := newAuditor(c).WithCategories(categories).Run(context.Background())
+	if *applyFixes {
+		return applyDoctorFixes(c, doctorFixes(report), *yes)
+	}
+	if *emitFixes {
+		return emitDoctorFixes(doctorFixes(report))
+	}
+	switch {
+	case *outputDir != "":
+		if err := saveReport(*outputDir, "doctor", report); err != nil {
+			return err
+		}
+	case *format == "sarif":
+		if err := output.JSON(report.SARIF()); err != nil {
+			return err
+		}
+	case output.Format == "json":
+		if err := output.JSON(report); err != nil {
+			return err
+		}
+	default:
+		printAuditReport(report, *verbose)
+	}
+
+	critical := 0
+	for _, cat := range report.Categories {
+		critical += cat.Critical
+	}
+	if critical > 0 {
+		return fmt.Errorf("%d critical check(s) failed", critical)
+	}
+	return nil
+}
+
+// doctorFixCommands maps audit check IDs to ojs commands that remediate
+// them. Safe fixes only add configuration and may be run by --apply-fixes;
+// the others need an operator's judgement, so they are emitted commented out
+// with a hint and never run.
+var doctorFixCommands = map[string]struct {
+	args []string
+	safe bool
+	hint string
+}{
+	"OPS-001": {[]string{"queues", "--create", "default"}, true, ""},
+	"OPS-002": {[]string{"dead-letter"}, false, "inspect the jobs, then replay each with ojs dead-letter --retry <id> or purge them"},
+}
+
+// doctorFix is a remediation command for one failing check.
+type doctorFix struct {
+	CheckID string   `json:"check_id"`
+	Check   string   `json:"check"`
+	Message string   `json:"message"`
+	Command string   `json:"command"`
+	Safe    bool     `json:"safe"`
+	Hint    string   `json:"hint,omitempty"`
+	args    []string // command line without the "ojs" prefix
+}
+
+// doctorFixes returns the remediation commands for the report's checks that
+// did not pass, in report order.
+func doctorFixes(report *doctor.Report) []doctorFix {
+	fixes := []doctorFix{}
+	for _, ch := range report.Checks {
+		if ch.Severity == doctor.SevPass || ch.Severity == doctor.SevSkip {
+			continue
+		}
+		cmd, ok := doctorFixCommands[ch.ID]
+		if !ok {
+			continue
+		}
+		fixes = append(fixes, doctorFix{
+			CheckID: ch.ID,
+			Check:   ch.Name,
+			Message: ch.Message,
+			Command: "ojs " + strings.Join(cmd.args, " "),
+			Safe:    cmd.safe,
+			Hint:    cmd.hint,
+			args:    cmd.args,
+		})
+	}
+	return fixes
+}
+
+// emitDoctorFixes prints the fixes as a shell script, one command per
+// failing check. Fixes that need review are commented out.
+func emitDoctorFixes(fixes []doctorFix) error {
+	if output.Format == "json" {
+		return output.JSON(fixes)
+	}
+	if len(fixes) == 0 {
+		fmt.Println("# No failing checks map to an ojs command")
+		return nil
+	}
+	for _, f := range fixes {
+		fmt.Printf("# %s %s: %s\n", f.CheckID, f.Check, f.Message)
+		if !f.Safe {
+			fmt.Printf("# Review first: %s\n# %s\n", f.Hint, f.Command)
+			continue
+		}
+		fmt.Println(f.Command)
+	}
+	return nil
+}
+
+// applyDoctorFixes runs the safe fixes after confirmation and lists the
+// others for the operator to run by hand.
+func applyDoctorFixes(c *client.Client, fixes []doctorFix, yes bool) error {
+	safe := 0
+	for _, f := range fixes {
+		if f.Safe {
+			safe++
+		}
+	}
+	if safe > 0 {
+		if err := confirmDestructive(fmt.Sprintf("run %d remediation command(s)", safe), "", yes); err != nil {
+			return err
+		}
+	}
+
+	type fixResult struct {
+		Command string `json:"command"`
+		Status  string `json:"status"` // applied, failed, skipped
+		Error   string `json:"error,omitempty"`
+		Hint    string `json:"hint,omitempty"`
+	}
+	results := []fixResult{}
+	failed := 0
+	for _, f := range fixes {
+		r := fixResult{Command: f.Command, Status: "skipped", Hint: f.Hint}
+		if f.Safe {
+			if output.Format != "json" {
+				fmt.Printf("→ %s\n", f.Command)
+			}
+			r.Status = "applied"
+			if err := runDoctorFix(c, f.args); err != nil {
+				r.Status, r.Error = "failed", err.Error()
+				failed++
+			}
+		}
+		results = append(results, r)
+	}
+
+	if output.Format == "json" {
+		if err := output.JSON(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch r.Status {
+			case "skipped":
+				output.Warn("Not applied, needs review: %s (%s)", r.Command, r.Hint)
+			case "failed":
+				fmt.Printf("  ❌ %s: %s\n", r.Command, r.Error)
+			}
+		}
+		if len(results) == 0 {
+			output.Success("No failing checks map to an ojs command")
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d remediation command(s) failed", failed)
+	}
+	return nil
+}
+
+// runDoctorFix runs one remediation command in-process. With JSON output
+// the command's own messages are suppressed so only the summary is printed.
+func runDoctorFix(c *client.Client, args []string) error {
+	if output.Format == "json" {
+		origFormat, origQuiet := output.Format, output.Quiet
+		output.Format, output.Quiet = "table", true
+		defer func() { output.Format, output.Quiet = origFormat, origQuiet }()
+	}
+	switch args[0] {
+	case "queues":
+		return Queues(c, args[1:])
+	default:
+		return fmt.Errorf("no runner for fix command %q", args[0])
+	}
+}
+
+// doctorFleet audits every server in the list with the client's token and
+// transport and prints one scorecard row per server.
+func doctorFleet(c *client.Client, listPath, minGrade string, categories []string) error {
+	if minGrade != "" && !doctor.ValidGrade(minGrade) {
+		return fmt.Errorf("invalid --min-grade %q (valid: %s)", minGrade, strings.Join(doctor.Grades, ", "))
+	}
+	urls, err := doctor.ReadServerList(listPath)
+	if err != nil {
+		return err
+	}
+
+	reports := make([]*doctor.Report, 0, len(urls))
+	for _, url := range urls {
+		auditor := doctor.NewAuditor(url, c.AuthToken()).WithTransport(c.Transport()).WithCategories(categories)
+		reports = append(reports, auditor.Run(context.Background()))
+	}
+	rows := doctor.Scorecard(reports)
+
+	var failing []string
+	for _, r := range rows {
+		if (minGrade != "" && doctor.GradeBelow(r.Grade, minGrade)) || (minGrade == "" && r.Critical > 0) {
+			failing = append(failing, r.ServerURL)
+		}
+	}
+
+	if output.Format == "json" {
+		if err := output.JSON(map[string]any{"servers": rows, "failing": nonNil(failing)}); err != nil {
+			return err
+		}
+	} else {
+		headers := []string{"SERVER", "GRADE", "SCORE", "CRITICAL", "WARNINGS"}
+		tableRows := make([][]string, 0, len(rows))
+		for _, r := range rows {
+			tableRows = append(tableRows, []string{r.ServerURL, r.Grade, fmt.Sprintf("%d/%d", r.Score, r.MaxScore),
+				fmt.Sprintf("%d", r.Critical), fmt.Sprintf("%d", r.Warnings)})
+		}
+		output.Table(headers, tableRows)
+	}
+
+	if len(failing) > 0 {
+		if minGrade != "" {
+			return fmt.Errorf("%d of %d server(s) below grade %s: %s", len(failing), len(rows), minGrade, strings.Join(failing, ", "))
+		}
+		return fmt.Errorf("%d of %d server(s) have critical findings: %s", len(failing), len(rows), strings.Join(failing, ", "))
+	}
+	return nil
+}
+
+// newAuditor builds an auditor that talks to the server with the client's
+// URL, token, and TLS/proxy settings.
+func newAuditor(c *client.Client) *doctor.Auditor {
+	return doctor.NewAuditor(c.BaseURL(), c.AuthToken()).WithTransport(c.Transport())
+}
+
+// printAuditReport renders an audit report grouped by category. Passing,
+// informational, and skipped checks are only listed with verbose.
+func printAuditReport(report *doctor.Report, verbose bool) {
+	pct := 0
+	if report.MaxScore > 0 {
+		pct = report.Score * 100 / report.MaxScore
+	}
+	fmt.Printf("Production readiness audit for %s\n", report.ServerURL)
+	fmt.Printf("Grade: %s (score %d/%d, %d%%)\n", report.Grade, report.Score, report.MaxScore, pct)
+
+	categories := make([]string, 0, len(report.Categories))
+	for name := range report.Categories {
+		categories = append(categories, name)
+	}
+	sort.Strings(categories)
+
+	for _, name := range categories {
+		cat := report.Categories[name]
+		fmt.Printf("\n%s: %d/%d passed", name, cat.Passed, cat.Total)
+		if cat.Warnings > 0 {
+			fmt.Printf(", %d warnings", cat.Warnings)
+		}
+		if cat.Critical > 0 {
+			fmt.Printf(", %d critical", cat.Critical)
+		}
+		fmt.Println()
+
+		for _, ch := range report.Checks {
+			if ch.Category != name {
+				continue
+			}
+			var icon string
+			switch ch.Severity {
+			case doctor.SevWarning:
+				icon = "⚠️ "
+			case doctor.SevCritical:
+				icon = "❌"
+			default:
+				if !verbose {
+					continue
+				}
+				icon = "✅"
+				if ch.Severity != doctor.SevPass {
+					icon = "ℹ️ "
+				}
+			}
+			fmt.Printf("  %s %s %s: %s\n", icon, ch.ID, ch.Name, ch.Message)
+			if ch.Fix != "" {
+				fmt.Printf("       Fix: %s\n", ch.Fix)
+			}
+		}
+	}
+}
+
+// doctorQuick runs the lightweight connectivity checks, plus the basic
+// production checks when production is set.
+func doctorQuick(c *client.Client, production, verbose bool) error {
 	results := []checkResult{}
 
 	// Basic connectivity
@@ -48,7 +433,7 @@ Flags:
 	results = append(results, checkEnqueueDequeue(c))
 	results = append(results, checkQueuesEndpoint(c))
 
-	if *production {
+	if production {
 		results = append(results, checkTLS(c))
 		results = append(results, checkAuth(c))
 		results = append(results, checkMetrics(c))
@@ -68,7 +453,7 @@ Flags:
 		switch r.Status {
 		case "pass":
 			passed++
-			if *verbose {
+			if verbose {
 				fmt.Printf("  ✅ %s: %s\n", r.Name, r.Message)
 			}
 		case "warn":
@@ -83,11 +468,11 @@ Flags:
 	fmt.Println()
 	fmt.Printf("Results: %d passed, %d warnings, %d failed\n", passed, warned, failed)
 
-	if *production && failed == 0 && warned == 0 {
+	if production && failed == 0 && warned == 0 {
 		fmt.Println("\n🎉 Production readiness: PASS")
-	} else if *production && failed > 0 {
+	} else if production && failed > 0 {
 		fmt.Println("\n🚨 Production readiness: FAIL — address the issues above before deploying")
-	} else if *production && warned > 0 {
+	} else if production && warned > 0 {
 		fmt.Println("\n⚠️  Production readiness: WARN — review warnings before deploying")
 	}
 
@@ -97,9 +482,96 @@ Flags:
 	return nil
 }
 
+// doctorBaseline runs the production audit and either saves it as a baseline
+// or compares it against one, failing on a score drop or check regression.
+func doctorBaseline(c *client.Client, baselinePath, savePath string) error {
+	var base *doctor.Report
+	if baselinePath != "" {
+		var err error
+		if base, err = doctor.LoadReport(baselinePath); err != nil {
+			return err
+		}
+	}
+
+	report := newAuditor(c).Run(context.Background())
+
+	if savePath != "" {
+		if err := report.Save(savePath); err != nil {
+			return err
+		}
+		if base == nil {
+			if output.Format == "json" {
+				return output.JSON(report)
+			}
+			output.Success("Saved baseline to %s (score %d/%d, grade %s)", savePath, report.Score, report.MaxScore, report.Grade)
+			return nil
+		}
+	}
+
+	cmp := doctor.Compare(base, report)
+	regressions := cmp.Regressions()
+
+	if output.Format == "json" {
+		if err := output.JSON(map[string]any{
+			"baseline_score": cmp.BaselineScore,
+			"score":          cmp.Score,
+			"baseline_grade": cmp.BaselineGrade,
+			"grade":          cmp.Grade,
+			"changes":        cmp.Changes,
+			"regressed":      cmp.Failed(),
+		}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Baseline: %d/%d (%s)  Current: %d/%d (%s)\n",
+			cmp.BaselineScore, base.MaxScore, cmp.BaselineGrade, cmp.Score, report.MaxScore, cmp.Grade)
+		if len(cmp.Changes) == 0 {
+			fmt.Println("No check changes.")
+		} else {
+			fmt.Println()
+			headers := []string{"CHECK", "NAME", "BEFORE", "AFTER", ""}
+			rows := make([][]string, 0, len(cmp.Changes))
+			for _, ch := range cmp.Changes {
+				mark := ""
+				if ch.Regression {
+					mark = "REGRESSED"
+				}
+				rows = append(rows, []string{ch.ID, ch.Name, orDash(string(ch.Before)), orDash(string(ch.After)), mark})
+			}
+			output.Table(headers, rows)
+		}
+	}
+
+	switch {
+	case cmp.ScoreDropped() && len(regressions) > 0:
+		return fmt.Errorf("score dropped from %d to %d and %d check(s) regressed", cmp.BaselineScore, cmp.Score, len(regressions))
+	case cmp.ScoreDropped():
+		return fmt.Errorf("score dropped from %d to %d", cmp.BaselineScore, cmp.Score)
+	case len(regressions) > 0:
+		return fmt.Errorf("%d check(s) regressed", len(regressions))
+	}
+	if output.Format != "json" {
+		fmt.Println()
+		output.Success("No regressions against baseline")
+	}
+	return nil
+}
+
+// quickGet fetches an API path through the configured client, so the quick
+// checks use the same token, TLS and proxy settings as every other command.
+// HTTP error responses come back as their status rather than as an error.
+func quickGet(c *client.Client, path string) ([]byte, int, error) {
+	data, status, err := c.Get(path)
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return data, apiErr.StatusCode, nil
+	}
+	return data, status, err
+}
+
 func checkConnectivity(c *client.Client) checkResult {
 	start := time.Now()
-	resp, err := http.Get(c.BaseURL() + "/v1/health")
+	_, _, err := quickGet(c, "/health")
 	latency := time.Since(start)
 
 	if err != nil {
@@ -109,7 +581,6 @@ func checkConnectivity(c *client.Client) checkResult {
 			Message: fmt.Sprintf("Cannot reach server at %s: %v", c.BaseURL(), err),
 		}
 	}
-	resp.Body.Close()
 
 	return checkResult{
 		Name:    "Server Connectivity",
@@ -119,22 +590,21 @@ func checkConnectivity(c *client.Client) checkResult {
 }
 
 func checkHealthEndpoint(c *client.Client) checkResult {
-	resp, err := http.Get(c.BaseURL() + "/v1/health")
+	data, status, err := quickGet(c, "/health")
 	if err != nil {
 		return checkResult{Name: "Health Endpoint", Status: "fail", Message: "Health endpoint unreachable"}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
+	if status != 200 {
 		return checkResult{
 			Name:    "Health Endpoint",
 			Status:  "fail",
-			Message: fmt.Sprintf("Health endpoint returned %d", resp.StatusCode),
+			Message: fmt.Sprintf("Health endpoint returned %d", status),
 		}
 	}
 
 	var body map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
+	if err := json.Unmarshal(data, &body); err == nil {
 		if status, ok := body["status"].(string); ok {
 			return checkResult{Name: "Health Endpoint", Status: "pass", Message: fmt.Sprintf("Status: %s", status)}
 		}
@@ -144,14 +614,13 @@ func checkHealthEndpoint(c *client.Client) checkResult {
 }
 
 func checkAPIVersion(c *client.Client) checkResult {
-	resp, err := http.Get(c.BaseURL() + "/v1/health")
+	data, _, err := quickGet(c, "/health")
 	if err != nil {
 		return checkResult{Name: "API Version", Status: "fail", Message: "Cannot determine API version"}
 	}
-	defer resp.Body.Close()
 
 	var body map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
+	if err := json.Unmarshal(data, &body); err == nil {
 		if version, ok := body["version"].(string); ok {
 			return checkResult{Name: "API Version", Status: "pass", Message: fmt.Sprintf("Server version: %s", version)}
 		}
@@ -162,38 +631,36 @@ func checkAPIVersion(c *client.Client) checkResult {
 
 func checkEnqueueDequeue(c *client.Client) checkResult {
 	// Try a dry-run style check by hitting the jobs endpoint
-	resp, err := http.Get(c.BaseURL() + "/v1/queues")
+	_, status, err := quickGet(c, "/queues")
 	if err != nil {
 		return checkResult{Name: "Job Operations", Status: "fail", Message: "Cannot access job API"}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == 200 || resp.StatusCode == 401 {
+	if status == 200 || status == 401 {
 		return checkResult{Name: "Job Operations", Status: "pass", Message: "Job API accessible"}
 	}
 
 	return checkResult{
 		Name:    "Job Operations",
 		Status:  "warn",
-		Message: fmt.Sprintf("Queues endpoint returned %d", resp.StatusCode),
+		Message: fmt.Sprintf("Queues endpoint returned %d", status),
 	}
 }
 
 func checkQueuesEndpoint(c *client.Client) checkResult {
-	resp, err := http.Get(c.BaseURL() + "/v1/queues")
+	_, status, err := quickGet(c, "/queues")
 	if err != nil {
 		return checkResult{Name: "Queue Management", Status: "fail", Message: "Cannot list queues"}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == 200 {
+	if status == 200 {
 		return checkResult{Name: "Queue Management", Status: "pass", Message: "Queue management available"}
 	}
 
 	return checkResult{
 		Name:    "Queue Management",
 		Status:  "warn",
-		Message: fmt.Sprintf("Queue endpoint returned %d", resp.StatusCode),
+		Message: fmt.Sprintf("Queue endpoint returned %d", status),
 	}
 }
 
@@ -209,8 +676,10 @@ func checkTLS(c *client.Client) checkResult {
 }
 
 func checkAuth(c *client.Client) checkResult {
-	// Try to access without auth to see if server requires it
-	resp, err := http.Get(c.BaseURL() + "/v1/queues")
+	// Try to access without auth to see if server requires it. The request
+	// still goes through the client's transport for its TLS and proxy settings.
+	hc := &http.Client{Transport: c.Transport(), Timeout: client.DefaultTimeout}
+	resp, err := hc.Get(c.BaseURL() + "/ojs/v1/queues")
 	if err != nil {
 		return checkResult{Name: "Authentication", Status: "fail", Message: "Cannot check auth config"}
 	}
@@ -228,7 +697,15 @@ func checkAuth(c *client.Client) checkResult {
 }
 
 func checkMetrics(c *client.Client) checkResult {
-	resp, err := http.Get(c.BaseURL() + "/metrics")
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL()+"/metrics", nil)
+	if err != nil {
+		return checkResult{Name: "Metrics Export", Status: "warn", Message: "Metrics endpoint not available"}
+	}
+	if token := c.AuthToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	hc := &http.Client{Transport: c.Transport(), Timeout: client.DefaultTimeout}
+	resp, err := hc.Do(req)
 	if err != nil {
 		return checkResult{Name: "Metrics Export", Status: "warn", Message: "Metrics endpoint not available"}
 	}
@@ -246,13 +723,12 @@ func checkMetrics(c *client.Client) checkResult {
 }
 
 func checkDeadLetterConfig(c *client.Client) checkResult {
-	resp, err := http.Get(c.BaseURL() + "/v1/dead-letter")
+	_, status, err := quickGet(c, "/dead-letter")
 	if err != nil {
 		return checkResult{Name: "Dead Letter Queue", Status: "warn", Message: "Cannot check DLQ configuration"}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == 200 {
+	if status == 200 {
 		return checkResult{Name: "Dead Letter Queue", Status: "pass", Message: "Dead letter queue accessible"}
 	}
 
@@ -264,15 +740,14 @@ func checkDeadLetterConfig(c *client.Client) checkResult {
 }
 
 func checkWorkerRegistration(c *client.Client) checkResult {
-	resp, err := http.Get(c.BaseURL() + "/v1/workers")
+	data, status, err := quickGet(c, "/workers")
 	if err != nil {
 		return checkResult{Name: "Worker Registration", Status: "warn", Message: "Cannot check worker status"}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode == 200 {
+	if status == 200 {
 		var body map[string]interface{}
-		if err := json.NewDecoder(resp.Body).Decode(&body); err == nil {
+		if err := json.Unmarshal(data, &body); err == nil {
 			if workers, ok := body["workers"].([]interface{}); ok && len(workers) > 0 {
 				return checkResult{
 					Name:    "Worker Registration",
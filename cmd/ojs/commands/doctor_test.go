@@ -0,0 +1,456 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/config"
+	"github.com/openjobspec/ojs-cli/internal/doctor"
+)
+
+func auditHandler(healthy *bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-OJS-Version", "1.0.0")
+		w.Header().Set("X-Request-Id", "req-1")
+		if !*healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		switch r.URL.Path {
+		case "/ojs/v1/health":
+			json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
+		case "/ojs/v1/queues":
+			json.NewEncoder(w).Encode(map[string]any{"queues": []map[string]string{{"name": "default"}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestDoctor_GradedReport(t *testing.T) {
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+
+	var err error
+	out := captureStdout(t, func() {
+		err = Doctor(c, nil)
+	})
+	// The test server has no auth, which the audit reports as critical.
+	if err == nil || !strings.Contains(err.Error(), "critical") {
+		t.Errorf("expected critical findings error, got %v", err)
+	}
+
+	var report struct {
+		Grade      string         `json:"grade"`
+		Score      int            `json:"score"`
+		MaxScore   int            `json:"max_score"`
+		Categories map[string]any `json:"categories"`
+		Checks     []struct {
+			ID  string `json:"id"`
+			Fix string `json:"fix"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if report.Grade == "" || report.MaxScore == 0 || report.Score == 0 {
+		t.Errorf("expected a graded report, got %+v", report)
+	}
+	if len(report.Categories) == 0 || len(report.Checks) == 0 {
+		t.Errorf("expected categories and checks, got %+v", report)
+	}
+	for _, ch := range report.Checks {
+		if ch.ID == "SEC-002" && ch.Fix == "" {
+			t.Error("expected a fix for the missing auth finding")
+		}
+	}
+}
+
+func TestDoctor_GradedReportTable(t *testing.T) {
+	withTableOutput(t)
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+
+	out := captureStdout(t, func() {
+		Doctor(c, []string{"--verbose"})
+	})
+	for _, want := range []string{"Grade: ", "security:", "SEC-001 Health Endpoint", "Fix: "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDoctor_OutputSARIF(t *testing.T) {
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+
+	out := captureStdout(t, func() {
+		Doctor(c, []string{"--output", "sarif"})
+	})
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(out), &log); err != nil {
+		t.Fatalf("invalid SARIF: %v\n%s", err, out)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) == 0 {
+		t.Fatalf("unexpected SARIF log: %s", out)
+	}
+	for _, r := range log.Runs[0].Results {
+		if r.RuleID == "SEC-002" && r.Level != "error" {
+			t.Errorf("expected missing auth to be an error, got %s", r.Level)
+		}
+	}
+}
+
+func TestDoctor_OutputInvalid(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {})
+	if err := Doctor(c, []string{"--output", "xml"}); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
+
+func TestDoctor_OnlySecurity(t *testing.T) {
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+
+	out := captureStdout(t, func() {
+		Doctor(c, []string{"--only", "security"})
+	})
+
+	var report struct {
+		Score      int            `json:"score"`
+		MaxScore   int            `json:"max_score"`
+		Categories map[string]any `json:"categories"`
+		Checks     []struct {
+			Category string `json:"category"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(report.Categories) != 1 || report.Categories["security"] == nil {
+		t.Errorf("categories = %v, want only security", report.Categories)
+	}
+	for _, ch := range report.Checks {
+		if ch.Category != "security" {
+			t.Errorf("unexpected %s check with --only security", ch.Category)
+		}
+	}
+	if report.MaxScore != len(report.Checks)*5 {
+		t.Errorf("max score = %d, want %d for %d checks", report.MaxScore, len(report.Checks)*5, len(report.Checks))
+	}
+}
+
+func TestDoctor_CategoryFilterErrors(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	for _, args := range [][]string{
+		{"--only", "performance"},
+		{"--skip", "security,reliability,observability,operations,compliance"},
+		{"--only", "security", "--quick"},
+	} {
+		if err := Doctor(c, args); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestDoctorFixes_MapsFailingChecks(t *testing.T) {
+	report := &doctor.Report{Checks: []doctor.Check{
+		{ID: "SEC-002", Name: "Authentication", Severity: doctor.SevCritical},
+		{ID: "OPS-001", Name: "Queues Configured", Severity: doctor.SevInfo, Message: "No queues found"},
+		{ID: "OPS-002", Name: "Dead Letter Queue", Severity: doctor.SevWarning, Message: "DLQ has 3 jobs"},
+	}}
+	fixes := doctorFixes(report)
+	if len(fixes) != 2 {
+		t.Fatalf("fixes = %+v, want OPS-001 and OPS-002", fixes)
+	}
+	if fixes[0].Command != "ojs queues --create default" || !fixes[0].Safe {
+		t.Errorf("OPS-001 fix = %+v", fixes[0])
+	}
+	if fixes[1].Command != "ojs dead-letter" || fixes[1].Safe || fixes[1].Hint == "" {
+		t.Errorf("OPS-002 fix = %+v", fixes[1])
+	}
+
+	report.Checks[2].Severity = doctor.SevPass
+	if fixes := doctorFixes(report); len(fixes) != 1 {
+		t.Errorf("passing checks should not get fixes, got %+v", fixes)
+	}
+}
+
+// fixableAuditHandler serves an audit with no queues and a non-empty dead
+// letter queue, and counts queue creations.
+func fixableAuditHandler(created *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ojs/v1/queues" && r.Method == http.MethodPost:
+			*created++
+			w.Write([]byte(`{"name":"default"}`))
+		case r.URL.Path == "/ojs/v1/queues":
+			w.Write([]byte(`{"queues":[]}`))
+		case r.URL.Path == "/ojs/v1/dead-letter":
+			w.Write([]byte(`{"jobs":[],"pagination":{"total":3}}`))
+		case strings.HasPrefix(r.URL.Path, "/ojs/v1/jobs/bulk"):
+			http.Error(w, "unexpected bulk request", http.StatusTeapot)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestDoctor_EmitFixes(t *testing.T) {
+	withTableOutput(t)
+	created := 0
+	c := newTestClient(fixableAuditHandler(&created))
+
+	var err error
+	out := captureStdout(t, func() { err = Doctor(c, []string{"--emit-fixes"}) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"# OPS-002 Dead Letter Queue: DLQ has 3 jobs", "\n# ojs dead-letter\n", "\nojs queues --create default\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if created != 0 {
+		t.Error("--emit-fixes must not run commands")
+	}
+}
+
+func TestDoctor_ApplyFixesRunsOnlySafe(t *testing.T) {
+	created := 0
+	c := newTestClient(fixableAuditHandler(&created))
+
+	var err error
+	out := captureStdout(t, func() { err = Doctor(c, []string{"--apply-fixes", "--yes"}) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out)
+	}
+	if created != 1 {
+		t.Errorf("queue creations = %d, want 1", created)
+	}
+	var results []struct {
+		Command string `json:"command"`
+		Status  string `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	got := map[string]string{}
+	for _, r := range results {
+		got[r.Command] = r.Status
+	}
+	if got["ojs queues --create default"] != "applied" || got["ojs dead-letter"] != "skipped" {
+		t.Errorf("results = %v", got)
+	}
+}
+
+func TestDoctor_ApplyFixesNeedsConfirmation(t *testing.T) {
+	withConfirm(t, false, "")
+	created := 0
+	c := newTestClient(fixableAuditHandler(&created))
+	if err := Doctor(c, []string{"--apply-fixes"}); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("err = %v, want confirmation error", err)
+	}
+	if created != 0 {
+		t.Error("fix ran without confirmation")
+	}
+}
+
+func TestDoctor_Servers(t *testing.T) {
+	healthy, down := true, false
+	good := httptest.NewServer(auditHandler(&healthy))
+	defer good.Close()
+	bad := httptest.NewServer(auditHandler(&down))
+	defer bad.Close()
+
+	list := filepath.Join(t.TempDir(), "servers.txt")
+	os.WriteFile(list, []byte("# fleet\n"+good.URL+"\n"+bad.URL+"\n"), 0o644)
+	c := newTestClient(noRequestClient(t))
+
+	var err error
+	out := captureStdout(t, func() { err = Doctor(c, []string{"--servers", list, "--min-grade", "F"}) })
+	if err != nil {
+		t.Fatalf("unexpected error with --min-grade F: %v", err)
+	}
+	var card struct {
+		Servers []struct {
+			ServerURL string `json:"server_url"`
+			Grade     string `json:"grade"`
+			Critical  int    `json:"critical"`
+		} `json:"servers"`
+	}
+	if err := json.Unmarshal([]byte(out), &card); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(card.Servers) != 2 || card.Servers[0].ServerURL != good.URL || card.Servers[1].ServerURL != bad.URL {
+		t.Fatalf("scorecard = %+v", card.Servers)
+	}
+	if card.Servers[1].Critical <= card.Servers[0].Critical {
+		t.Errorf("down server should have more criticals: %+v", card.Servers)
+	}
+
+	captureStdout(t, func() { err = Doctor(c, []string{"--servers", list, "--min-grade", "A"}) })
+	if err == nil || !strings.Contains(err.Error(), bad.URL) || !strings.Contains(err.Error(), "below grade A") {
+		t.Errorf("err = %v, want %s listed below grade A", err, bad.URL)
+	}
+
+	// Without --min-grade, any critical finding fails the run.
+	captureStdout(t, func() { err = Doctor(c, []string{"--servers", list}) })
+	if err == nil || !strings.Contains(err.Error(), "2 of 2 server(s) have critical findings") {
+		t.Errorf("err = %v, want both servers failing on criticals", err)
+	}
+}
+
+func TestDoctor_ServersErrors(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	list := filepath.Join(t.TempDir(), "servers.txt")
+	os.WriteFile(list, []byte("http://localhost:1\n"), 0o644)
+	for _, args := range [][]string{
+		{"--min-grade", "B"},
+		{"--servers", list, "--min-grade", "E"},
+		{"--servers", list, "--quick"},
+		{"--servers", filepath.Join(t.TempDir(), "missing.txt")},
+	} {
+		if err := Doctor(c, args); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestDoctor_SendsToken(t *testing.T) {
+	var unauthorized int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			unauthorized++
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+	}))
+	defer srv.Close()
+	c := client.New(&config.Config{ServerURL: srv.URL, AuthToken: "s3cret"})
+
+	captureStdout(t, func() {
+		Doctor(c, nil)
+	})
+	if unauthorized != 0 {
+		t.Errorf("expected every audit request to carry the token, %d did not", unauthorized)
+	}
+}
+
+func TestDoctor_Quick(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/ojs/v1/") || r.Header.Get("Authorization") != "Bearer s3cret" {
+			t.Errorf("quick check sent %s without the API prefix or token", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+	c := client.New(&config.Config{ServerURL: srv.URL, AuthToken: "s3cret"})
+
+	out := captureStdout(t, func() {
+		if err := Doctor(c, []string{"--quick"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var results []checkResult
+	if err := json.Unmarshal([]byte(out), &results); err != nil {
+		t.Fatalf("quick mode should print check results: %v\n%s", err, out)
+	}
+	if len(results) != 5 {
+		t.Errorf("expected 5 quick checks, got %d", len(results))
+	}
+}
+
+func TestDoctor_BaselineNoRegression(t *testing.T) {
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	captureStdout(t, func() {
+		if err := Doctor(c, []string{"--save-baseline", path}); err != nil {
+			t.Fatalf("save baseline: %v", err)
+		}
+	})
+
+	out := captureStdout(t, func() {
+		if err := Doctor(c, []string{"--baseline", path}); err != nil {
+			t.Fatalf("unexpected regression: %v", err)
+		}
+	})
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result["regressed"] != false {
+		t.Errorf("expected regressed=false, got %v", result["regressed"])
+	}
+}
+
+func TestDoctor_BaselineRegression(t *testing.T) {
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	captureStdout(t, func() {
+		if err := Doctor(c, []string{"--save-baseline", path}); err != nil {
+			t.Fatalf("save baseline: %v", err)
+		}
+	})
+
+	healthy = false
+	var err error
+	out := captureStdout(t, func() {
+		err = Doctor(c, []string{"--baseline", path})
+	})
+	if err == nil || !strings.Contains(err.Error(), "score dropped") || !strings.Contains(err.Error(), "regressed") {
+		t.Fatalf("expected score drop and regression error, got %v", err)
+	}
+
+	var result struct {
+		Changes []struct {
+			ID         string `json:"id"`
+			Before     string `json:"before"`
+			After      string `json:"after"`
+			Regression bool   `json:"regression"`
+		} `json:"changes"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	found := false
+	for _, ch := range result.Changes {
+		if ch.ID == "SEC-001" {
+			found = ch.Regression && ch.Before == "pass" && ch.After == "critical"
+		}
+	}
+	if !found {
+		t.Errorf("expected SEC-001 pass -> critical regression in %+v", result.Changes)
+	}
+}
+
+func TestDoctor_BaselineMissingFile(t *testing.T) {
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+	if err := Doctor(c, []string{"--baseline", filepath.Join(t.TempDir(), "nope.json")}); err == nil {
+		t.Fatal("expected error for missing baseline")
+	}
+}
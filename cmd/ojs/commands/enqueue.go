@@ -2,56 +2,139 @@ package commands
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
+	"gopkg.in/yaml.v3"
 )
 
 // Enqueue creates a new job.
 func Enqueue(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("enqueue", flag.ExitOnError)
+	fs := newFlagSet("enqueue")
 	jobType := fs.String("type", "", "Job type (required)")
 	queue := fs.String("queue", "default", "Target queue")
+	validateQueue := fs.Bool("validate-queue", false, "Fail if the target queue does not exist on the server")
 	priority := fs.Int("priority", 0, "Job priority (0-10)")
+	priorityName := fs.String("priority-name", "", "Symbolic priority: "+describePriorityNames(c.Priorities())+" (override with OJS_PRIORITY_NAMES)")
 	argsJSON := fs.String("args", "[]", "Job args as JSON array")
 	metaJSON := fs.String("meta", "", "Job metadata as JSON object")
 	maxAttempts := fs.Int("max-attempts", 0, "Max retry attempts")
+	backoff := fs.String("backoff", "", "Retry backoff: "+strings.Join(retryBackoffs, ", "))
+	initialInterval := fs.String("initial-interval", "", "Delay before the first retry (e.g. 500ms, 30s, 5m)")
 	uniqueKey := fs.String("unique-key", "", "Unique job key for deduplication")
 	uniqueWithin := fs.String("unique-within", "", "Uniqueness window (e.g. 1h, 30m)")
-	batchFile := fs.String("batch", "", "NDJSON file for bulk enqueue")
-	fs.Parse(args)
+	expiresAt := fs.String("expires-at", "", "Discard the job if not started by this time (RFC3339, or a duration from now such as 30m or 2d)")
+	var tagArgs stringList
+	fs.Var(&tagArgs, "tag", "Tag the job with key=value (repeatable)")
+	tagList := fs.String("tags", "", "Comma-separated tags (k1=v1,k2=v2)")
+	batchFile := fs.String("batch", "", "NDJSON, JSON array, or YAML list file for bulk enqueue")
+	chunkSize := fs.Int("chunk-size", 1000, "Max jobs per batch request (with --batch)")
+	concurrency := fs.Int("concurrency", 1, "Number of batch requests to send in parallel (with --batch)")
+	fromTemplate := fs.String("from-template", "", "Start from a stored job template (see OJS_TEMPLATE_DIR)")
+	var paramArgs stringList
+	fs.Var(&paramArgs, "param", "Template parameter name=value (repeatable, with --from-template)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs enqueue (--type <type> | --from-template <name>) [flags]", "Enqueue a new job, or many jobs from a file with --batch.\n"+
+			"Templates are JSON job envelopes in ~/.ojs/templates/<name>.json whose {{name}}\n"+
+			"placeholders are filled from --param; flags given explicitly override the template.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	if *batchFile != "" {
-		return batchEnqueue(c, *batchFile)
+		return batchEnqueue(c, *batchFile, *chunkSize, *concurrency)
+	}
+
+	// A template supplies the starting envelope; explicit flags override it.
+	body := map[string]any{}
+	if *fromTemplate != "" {
+		tmpl, err := loadTemplate(*fromTemplate)
+		if err != nil {
+			return err
+		}
+		params, err := parseParams(paramArgs)
+		if err != nil {
+			return err
+		}
+		if body, err = renderTemplate(tmpl, params); err != nil {
+			return fmt.Errorf("template %q: %w", *fromTemplate, err)
+		}
+	} else if len(paramArgs) > 0 {
+		return fmt.Errorf("--param requires --from-template")
+	}
+	override := func(name string) bool {
+		return *fromTemplate == "" || flagWasSet(fs, name)
 	}
 
-	if *jobType == "" {
+	if override("type") {
+		body["type"] = *jobType
+	}
+	if t, _ := body["type"].(string); t == "" {
 		return fmt.Errorf("--type is required\n\nUsage: ojs enqueue --type <type> [--queue <queue>] [--args '<json>']")
 	}
 
-	body := map[string]any{
-		"type": *jobType,
+	prioritySet := *priority > 0
+	if *priorityName != "" {
+		if flagWasSet(fs, "priority") {
+			return fmt.Errorf("--priority and --priority-name are mutually exclusive")
+		}
+		names := c.Priorities()
+		value, ok := names[strings.ToLower(*priorityName)]
+		if !ok {
+			return fmt.Errorf("unknown --priority-name %q (valid: %s)", *priorityName, describePriorityNames(names))
+		}
+		*priority, prioritySet = value, true
+	}
+
+	if *tagList != "" {
+		tagArgs = append(tagArgs, strings.Split(*tagList, ",")...)
+	}
+	tags, err := parseTags(tagArgs)
+	if err != nil {
+		return err
 	}
 
-	var jobArgs json.RawMessage
-	if err := json.Unmarshal([]byte(*argsJSON), &jobArgs); err != nil {
-		return fmt.Errorf("invalid --args JSON: %w", err)
+	if override("args") {
+		var jobArgs json.RawMessage
+		if err := json.Unmarshal([]byte(*argsJSON), &jobArgs); err != nil {
+			return fmt.Errorf("invalid --args JSON: %w", err)
+		}
+		body["args"] = jobArgs
 	}
-	body["args"] = jobArgs
 
-	opts := map[string]any{
-		"queue": *queue,
+	opts, _ := body["options"].(map[string]any)
+	if opts == nil {
+		opts = map[string]any{}
+	}
+	if override("queue") {
+		opts["queue"] = *queue
 	}
-	if *priority > 0 {
+	if prioritySet {
 		opts["priority"] = *priority
 	}
 	if *maxAttempts > 0 {
 		opts["max_attempts"] = *maxAttempts
 	}
+	if *maxAttempts > 0 || *backoff != "" || *initialInterval != "" {
+		retry, err := retryPolicy(opts["retry"], *maxAttempts, *backoff, *initialInterval)
+		if err != nil {
+			return err
+		}
+		opts["retry"] = retry
+	}
 	if *uniqueKey != "" {
 		unique := map[string]any{
 			"key": *uniqueKey,
@@ -61,8 +144,34 @@ func Enqueue(c *client.Client, args []string) error {
 		}
 		opts["unique"] = unique
 	}
+	if *expiresAt != "" {
+		deadline, err := parseExpiresAt(*expiresAt, time.Now())
+		if err != nil {
+			return err
+		}
+		opts["expires_at"] = deadline
+	}
+	if len(tags) > 0 {
+		if existing, ok := opts["tags"].(map[string]any); ok {
+			for k, v := range tags {
+				existing[k] = v
+			}
+		} else {
+			opts["tags"] = tags
+		}
+	}
 	body["options"] = opts
 
+	if *validateQueue {
+		name, _ := opts["queue"].(string)
+		if name == "" {
+			name = "default"
+		}
+		if err := checkQueueExists(c, name); err != nil {
+			return err
+		}
+	}
+
 	if *metaJSON != "" {
 		var meta json.RawMessage
 		if err := json.Unmarshal([]byte(*metaJSON), &meta); err != nil {
@@ -89,50 +198,330 @@ func Enqueue(c *client.Client, args []string) error {
 	return nil
 }
 
-func batchEnqueue(c *client.Client, filePath string) error {
-	f, err := os.Open(filePath)
+// checkQueueExists returns an error naming close matches if the server has
+// no queue called name.
+func checkQueueExists(c *client.Client, name string) error {
+	data, _, err := c.Get("/queues")
+	if err != nil {
+		return fmt.Errorf("validate queue: %w", err)
+	}
+	var resp struct {
+		Queues []struct {
+			Name string `json:"name"`
+		} `json:"queues"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("parse queues response: %w", err)
+	}
+
+	names := make([]string, len(resp.Queues))
+	for i, q := range resp.Queues {
+		if q.Name == name {
+			return nil
+		}
+		names[i] = q.Name
+	}
+	msg := fmt.Sprintf("queue %q does not exist", name)
+	if matches := closestNames(name, names); len(matches) > 0 {
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(matches, ", "))
+	}
+	return fmt.Errorf("%s\n\nCreate it with: ojs queues --create %s", msg, name)
+}
+
+// closestNames returns up to three candidates within a small edit distance
+// of name, closest first.
+func closestNames(name string, candidates []string) []string {
+	limit := max(2, len(name)/3)
+	type match struct {
+		name string
+		dist int
+	}
+	var matches []match
+	for _, cand := range candidates {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(cand)); d <= limit {
+			matches = append(matches, match{cand, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].dist < matches[j].dist })
+
+	var out []string
+	for i := 0; i < len(matches) && i < 3; i++ {
+		out = append(out, matches[i].name)
+	}
+	return out
+}
+
+// editDistance is the edit distance between a and b, counting insertions,
+// deletions, substitutions and swaps of adjacent characters (typos such as
+// "emial") as one edit each.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+// retryBackoffs are the accepted --backoff values.
+var retryBackoffs = []string{"exponential", "linear", "fixed"}
+
+// retryPolicy builds options.retry in the shape the migrate converters emit
+// (max_attempts, backoff, delay_ms), layering the flags over any policy a
+// template already supplies.
+func retryPolicy(existing any, maxAttempts int, backoff, initialInterval string) (map[string]any, error) {
+	retry, _ := existing.(map[string]any)
+	if retry == nil {
+		retry = map[string]any{}
+	}
+	if maxAttempts > 0 {
+		retry["max_attempts"] = maxAttempts
+	}
+	if backoff != "" {
+		if !contains(retryBackoffs, backoff) {
+			return nil, fmt.Errorf("invalid --backoff %q (use %s)", backoff, strings.Join(retryBackoffs, ", "))
+		}
+		retry["backoff"] = backoff
+	}
+	if initialInterval != "" {
+		d, err := parseDuration(initialInterval)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid --initial-interval %q (e.g. 500ms, 30s, 5m)", initialInterval)
+		}
+		retry["delay_ms"] = d.Milliseconds()
+	}
+	return retry, nil
+}
+
+// parseExpiresAt converts an --expires-at value, either an RFC3339 time or a
+// duration from now, to an absolute RFC3339 UTC time in the future.
+func parseExpiresAt(s string, now time.Time) (string, error) {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		d, derr := parseDuration(s)
+		if derr != nil || d <= 0 {
+			return "", fmt.Errorf("invalid --expires-at %q: expected an RFC3339 time or a positive duration such as 30m or 2d", s)
+		}
+		t = now.Add(d)
+	}
+	if !t.After(now) {
+		return "", fmt.Errorf("invalid --expires-at %q: time is in the past", s)
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+func batchEnqueue(c *client.Client, filePath string, chunkSize, concurrency int) error {
+	if chunkSize <= 0 {
+		return fmt.Errorf("--chunk-size must be positive")
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	raw, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("open batch file: %w", err)
 	}
-	defer f.Close()
 
+	jobs, err := parseBatchJobs(filePath, raw)
+	if err != nil {
+		return err
+	}
+
+	if len(jobs) == 0 {
+		return fmt.Errorf("batch file is empty")
+	}
+
+	var chunks [][]json.RawMessage
+	for start := 0; start < len(jobs); start += chunkSize {
+		end := min(start+chunkSize, len(jobs))
+		chunks = append(chunks, jobs[start:end])
+	}
+
+	type chunkResult struct {
+		data []byte
+		err  error
+	}
+	results := make([]chunkResult, len(chunks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk []json.RawMessage) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			data, _, err := c.Post("/jobs/batch", map[string]any{"jobs": chunk})
+			results[i] = chunkResult{data: data, err: err}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	// A single chunk keeps the server's response shape untouched.
+	if len(chunks) == 1 {
+		if results[0].err != nil {
+			return results[0].err
+		}
+		data := results[0].data
+		if output.Format == "json" {
+			var result any
+			json.Unmarshal(data, &result)
+			return output.JSON(result)
+		}
+		var resp struct {
+			Enqueued int `json:"enqueued"`
+			Failed   int `json:"failed"`
+		}
+		json.Unmarshal(data, &resp)
+		output.Success("Batch enqueue: %d enqueued, %d failed (from %d jobs)", resp.Enqueued, resp.Failed, len(jobs))
+		return nil
+	}
+
+	var enqueued, failed, failedChunks int
+	var firstErr error
+	for i, r := range results {
+		if r.err != nil {
+			failedChunks++
+			failed += len(chunks[i])
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+		var resp struct {
+			Enqueued int `json:"enqueued"`
+			Failed   int `json:"failed"`
+		}
+		json.Unmarshal(r.data, &resp)
+		enqueued += resp.Enqueued
+		failed += resp.Failed
+	}
+
+	if output.Format == "json" {
+		if err := output.JSON(map[string]any{
+			"enqueued":      enqueued,
+			"failed":        failed,
+			"total":         len(jobs),
+			"chunks":        len(chunks),
+			"failed_chunks": failedChunks,
+		}); err != nil {
+			return err
+		}
+	} else {
+		output.Success("Batch enqueue: %d enqueued, %d failed (from %d jobs in %d chunks)",
+			enqueued, failed, len(jobs), len(chunks))
+	}
+
+	if firstErr != nil {
+		return fmt.Errorf("%d of %d batch chunks failed: %w", failedChunks, len(chunks), firstErr)
+	}
+	return nil
+}
+
+// parseBatchJobs normalizes a batch file into individual job objects. The
+// format is chosen by extension (.json, .yaml/.yml, .ndjson/.jsonl); other
+// files are sniffed, treating a leading '[' as a JSON array and anything else
+// as NDJSON.
+func parseBatchJobs(filePath string, data []byte) ([]json.RawMessage, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return parseBatchJSONArray(data)
+	case ".yaml", ".yml":
+		return parseBatchYAML(data)
+	case ".ndjson", ".jsonl":
+		return parseBatchNDJSON(data)
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return parseBatchJSONArray(data)
+	}
+	return parseBatchNDJSON(data)
+}
+
+func parseBatchNDJSON(data []byte) ([]json.RawMessage, error) {
 	var jobs []json.RawMessage
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
 	for scanner.Scan() {
-		line := scanner.Bytes()
+		line := bytes.TrimSpace(scanner.Bytes())
 		if len(line) == 0 {
 			continue
 		}
 		jobs = append(jobs, json.RawMessage(append([]byte{}, line...)))
 	}
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("read batch file: %w", err)
+		return nil, fmt.Errorf("read batch file: %w", err)
 	}
+	return jobs, nil
+}
 
-	if len(jobs) == 0 {
-		return fmt.Errorf("batch file is empty")
+func parseBatchJSONArray(data []byte) ([]json.RawMessage, error) {
+	var jobs []json.RawMessage
+	if err := json.Unmarshal(data, &jobs); err != nil {
+		return nil, fmt.Errorf("parse batch file as JSON array: %w", err)
 	}
+	return jobs, nil
+}
 
-	body := map[string]any{
-		"jobs": jobs,
+func parseBatchYAML(data []byte) ([]json.RawMessage, error) {
+	var items []any
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("parse batch file as YAML list: %w", err)
 	}
-
-	data, _, err := c.Post("/jobs/batch", body)
-	if err != nil {
-		return err
+	jobs := make([]json.RawMessage, 0, len(items))
+	for i, item := range items {
+		b, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("batch item %d: %w", i+1, err)
+		}
+		jobs = append(jobs, b)
 	}
+	return jobs, nil
+}
 
-	if output.Format == "json" {
-		var result any
-		json.Unmarshal(data, &result)
-		return output.JSON(result)
+// describePriorityNames lists symbolic priorities from highest to lowest,
+// e.g. "critical (10), high (7), normal (5), low (1)".
+func describePriorityNames(names map[string]int) string {
+	keys := make([]string, 0, len(names))
+	for k := range names {
+		keys = append(keys, k)
 	}
-
-	var resp struct {
-		Enqueued int `json:"enqueued"`
-		Failed   int `json:"failed"`
+	sort.Slice(keys, func(i, j int) bool {
+		if names[keys[i]] != names[keys[j]] {
+			return names[keys[i]] > names[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = fmt.Sprintf("%s (%d)", k, names[k])
 	}
-	json.Unmarshal(data, &resp)
-	output.Success("Batch enqueue: %d enqueued, %d failed (from %d jobs)", resp.Enqueued, resp.Failed, len(jobs))
-	return nil
+	return strings.Join(parts, ", ")
+}
+
+// flagWasSet reports whether the named flag was given on the command line,
+// as opposed to holding its default.
+func flagWasSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
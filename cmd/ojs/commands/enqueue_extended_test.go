@@ -0,0 +1,439 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/config"
+)
+
+// captureBatchBody enqueues the given batch file and returns the decoded
+// jobs array that was posted to /jobs/batch.
+func captureBatchBody(t *testing.T, path string) []any {
+	t.Helper()
+	var jobs []any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/jobs/batch" {
+			t.Errorf("path = %s, want /ojs/v1/jobs/batch", r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		jobs, _ = body["jobs"].([]any)
+		json.NewEncoder(w).Encode(map[string]any{"enqueued": len(jobs), "failed": 0})
+	})
+	if err := Enqueue(c, []string{"--batch", path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return jobs
+}
+
+func writeBatchFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+	return path
+}
+
+const batchNDJSON = `{"type":"email.send","args":["hello"],"options":{"queue":"default","priority":2}}
+{"type":"report.gen","args":["world"],"options":{"queue":"reports"}}
+`
+
+func TestEnqueue_Batch_JSONArray(t *testing.T) {
+	want := captureBatchBody(t, writeBatchFile(t, "jobs.ndjson", batchNDJSON))
+	got := captureBatchBody(t, writeBatchFile(t, "jobs.json", `[
+  {"type":"email.send","args":["hello"],"options":{"queue":"default","priority":2}},
+  {"type":"report.gen","args":["world"],"options":{"queue":"reports"}}
+]`))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON array batch = %v, want %v", got, want)
+	}
+}
+
+func TestEnqueue_Batch_YAMLList(t *testing.T) {
+	want := captureBatchBody(t, writeBatchFile(t, "jobs.ndjson", batchNDJSON))
+	got := captureBatchBody(t, writeBatchFile(t, "jobs.yaml", `- type: email.send
+  args:
+    - hello
+  options:
+    queue: default
+    priority: 2
+- type: report.gen
+  args:
+    - world
+  options:
+    queue: reports
+`))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("YAML batch = %v, want %v", got, want)
+	}
+}
+
+func TestEnqueue_Batch_SniffsJSONArray(t *testing.T) {
+	got := captureBatchBody(t, writeBatchFile(t, "jobs.txt", `[{"type":"a"},{"type":"b"}]`))
+	if len(got) != 2 {
+		t.Errorf("jobs len = %d, want 2", len(got))
+	}
+}
+
+func TestEnqueue_Batch_ChunkSize(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 2500; i++ {
+		fmt.Fprintf(&b, "{\"type\":\"email.send\",\"args\":[%d]}\n", i)
+	}
+	path := writeBatchFile(t, "jobs.ndjson", b.String())
+
+	var mu sync.Mutex
+	var sizes []int
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Jobs []json.RawMessage `json:"jobs"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		sizes = append(sizes, len(body.Jobs))
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"enqueued": len(body.Jobs) - 1, "failed": 1})
+	})
+
+	out := captureStdout(t, func() {
+		if err := Enqueue(c, []string{"--batch", path, "--chunk-size", "1000", "--concurrency", "2"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	sort.Ints(sizes)
+	if !reflect.DeepEqual(sizes, []int{500, 1000, 1000}) {
+		t.Errorf("chunk sizes = %v, want [500 1000 1000]", sizes)
+	}
+	var summary map[string]any
+	if err := json.Unmarshal([]byte(out), &summary); err != nil {
+		t.Fatalf("parse summary %q: %v", out, err)
+	}
+	if summary["enqueued"] != float64(2497) || summary["failed"] != float64(3) {
+		t.Errorf("summary = %v, want enqueued=2497 failed=3", summary)
+	}
+}
+
+// enqueuedOptions returns a handler that records the options object of each
+// enqueue request in *handlerOpts.
+func enqueuedOptions(t *testing.T, handlerOpts *map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		*handlerOpts, _ = body["options"].(map[string]any)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"job": map[string]any{"id": "job-1"}})
+	}
+}
+
+func TestEnqueue_PriorityName(t *testing.T) {
+	for name, want := range map[string]float64{"critical": 10, "high": 7, "normal": 5, "low": 1, "HIGH": 7} {
+		var opts map[string]any
+		c := newTestClient(enqueuedOptions(t, &opts))
+		captureStdout(t, func() {
+			if err := Enqueue(c, []string{"--type", "email.send", "--priority-name", name}); err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+		})
+		if opts["priority"] != want {
+			t.Errorf("%s: priority = %v, want %v", name, opts["priority"], want)
+		}
+	}
+}
+
+func TestEnqueue_PriorityNameConfigured(t *testing.T) {
+	var opts map[string]any
+	server := httptest.NewServer(enqueuedOptions(t, &opts))
+	defer server.Close()
+	c := client.New(&config.Config{
+		ServerURL:     server.URL,
+		PriorityNames: map[string]int{"critical": 100, "bulk": 0},
+	})
+
+	for name, want := range map[string]float64{"critical": 100, "bulk": 0, "low": 1} {
+		captureStdout(t, func() {
+			if err := Enqueue(c, []string{"--type", "report", "--priority-name", name}); err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+		})
+		if got, ok := opts["priority"]; !ok || got != want {
+			t.Errorf("%s: priority = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestEnqueue_PriorityNameErrors(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+	err := Enqueue(c, []string{"--type", "t", "--priority", "3", "--priority-name", "high"})
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected mutual exclusion error, got %v", err)
+	}
+	err = Enqueue(c, []string{"--type", "t", "--priority-name", "urgent"})
+	if err == nil || !strings.Contains(err.Error(), "critical (10), high (7), normal (5), low (1)") {
+		t.Errorf("expected unknown name error listing the mapping, got %v", err)
+	}
+}
+
+func TestEnqueue_Tags(t *testing.T) {
+	var opts map[string]any
+	c := newTestClient(enqueuedOptions(t, &opts))
+	captureStdout(t, func() {
+		err := Enqueue(c, []string{"--type", "email.send", "--tag", "env=prod", "--tags", "team=billing, tenant=acme"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := map[string]any{"env": "prod", "team": "billing", "tenant": "acme"}
+	if !reflect.DeepEqual(opts["tags"], want) {
+		t.Errorf("tags = %v, want %v", opts["tags"], want)
+	}
+
+	captureStdout(t, func() {
+		if err := Enqueue(c, []string{"--type", "email.send"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if _, ok := opts["tags"]; ok {
+		t.Errorf("tags should be omitted when none are given, got %v", opts["tags"])
+	}
+}
+
+func TestEnqueue_TagErrors(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+	for _, args := range [][]string{
+		{"--type", "t", "--tag", "env"},
+		{"--type", "t", "--tags", "env=prod,=x"},
+		{"--type", "t", "--tag", "env=prod", "--tags", "env=dev"},
+	} {
+		if err := Enqueue(c, args); err == nil {
+			t.Errorf("%v: expected error", args)
+		}
+	}
+}
+
+func TestEnqueue_PrintOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("--print-only must not send %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	old := os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stderr = w
+	c := client.New(&config.Config{ServerURL: server.URL, PrintRequest: true, PrintOnly: true})
+	out := captureStdout(t, func() {
+		err = Enqueue(c, []string{"--type", "email.send", "--args", `["a@b.c"]`})
+	})
+	w.Close()
+	os.Stderr = old
+	printed, _ := io.ReadAll(r)
+
+	if !errors.Is(err, client.ErrRequestNotSent) {
+		t.Fatalf("err = %v, want ErrRequestNotSent", err)
+	}
+	if out != "" {
+		t.Errorf("nothing should be printed to stdout, got %q", out)
+	}
+	for _, want := range []string{"POST " + server.URL + "/ojs/v1/jobs\n", `"type": "email.send"`, `"a@b.c"`} {
+		if !strings.Contains(string(printed), want) {
+			t.Errorf("printed request missing %q:\n%s", want, printed)
+		}
+	}
+}
+
+func TestEnqueue_ExpiresAt(t *testing.T) {
+	var opts map[string]any
+	c := newTestClient(enqueuedOptions(t, &opts))
+
+	deadline := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	captureStdout(t, func() {
+		if err := Enqueue(c, []string{"--type", "report.build", "--expires-at", deadline}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if opts["expires_at"] != deadline {
+		t.Errorf("expires_at = %v, want %s", opts["expires_at"], deadline)
+	}
+
+	before := time.Now()
+	captureStdout(t, func() {
+		if err := Enqueue(c, []string{"--type", "report.build", "--expires-at", "30m"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	got, err := time.Parse(time.RFC3339, fmt.Sprint(opts["expires_at"]))
+	if err != nil {
+		t.Fatalf("expires_at %v is not RFC3339: %v", opts["expires_at"], err)
+	}
+	if want := before.Add(30 * time.Minute); got.Before(want.Add(-time.Second)) || got.After(want.Add(time.Minute)) {
+		t.Errorf("expires_at = %s, want about %s", got, want)
+	}
+}
+
+func TestEnqueue_ExpiresAtInvalid(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+	for _, v := range []string{"tomorrow", "-5m", "0s", "2001-01-01T00:00:00Z"} {
+		err := Enqueue(c, []string{"--type", "t", "--expires-at", v})
+		if err == nil || !strings.Contains(err.Error(), "invalid --expires-at") {
+			t.Errorf("--expires-at %s: err = %v", v, err)
+		}
+	}
+}
+
+func TestParseExpiresAt(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	got, err := parseExpiresAt("2d", now)
+	if err != nil || got != "2024-03-03T12:00:00Z" {
+		t.Errorf("2d: got %q, %v", got, err)
+	}
+	got, err = parseExpiresAt("2024-03-01T14:00:00+02:00", now)
+	if err == nil {
+		t.Errorf("a time equal to now should be rejected, got %q", got)
+	}
+	got, err = parseExpiresAt("2024-03-01T15:00:00+02:00", now)
+	if err != nil || got != "2024-03-01T13:00:00Z" {
+		t.Errorf("offset time should be converted to UTC: got %q, %v", got, err)
+	}
+}
+
+func TestEnqueue_RetryPolicy(t *testing.T) {
+	var opts map[string]any
+	c := newTestClient(enqueuedOptions(t, &opts))
+
+	captureStdout(t, func() {
+		err := Enqueue(c, []string{"--type", "email.send", "--max-attempts", "5", "--backoff", "linear", "--initial-interval", "30s"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := map[string]any{"max_attempts": float64(5), "backoff": "linear", "delay_ms": float64(30000)}
+	if !reflect.DeepEqual(opts["retry"], want) {
+		t.Errorf("retry = %v, want %v", opts["retry"], want)
+	}
+	if opts["max_attempts"] != float64(5) {
+		t.Errorf("max_attempts = %v, want 5", opts["max_attempts"])
+	}
+
+	captureStdout(t, func() {
+		if err := Enqueue(c, []string{"--type", "email.send"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if _, ok := opts["retry"]; ok {
+		t.Errorf("retry should be omitted without retry flags, got %v", opts["retry"])
+	}
+}
+
+func TestEnqueue_RetryPolicyInvalid(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	tests := map[string][]string{
+		"invalid --backoff":          {"--backoff", "random"},
+		"invalid --initial-interval": {"--initial-interval", "soon"},
+	}
+	for want, flags := range tests {
+		err := Enqueue(c, append([]string{"--type", "t"}, flags...))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%v: err = %v, want %q", flags, err, want)
+		}
+	}
+}
+
+// queueListHandler serves GET /queues with the given names and records the
+// requests it sees.
+func queueListHandler(names []string, seen *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*seen = append(*seen, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/queues" {
+			var queues []map[string]string
+			for _, n := range names {
+				queues = append(queues, map[string]string{"name": n})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"queues": queues})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "job-1", "state": "available"})
+	}
+}
+
+func TestEnqueue_ValidateQueue(t *testing.T) {
+	var seen []string
+	c := newTestClient(queueListHandler([]string{"default", "emails"}, &seen))
+
+	captureStdout(t, func() {
+		if err := Enqueue(c, []string{"--type", "email.send", "--queue", "emails", "--validate-queue"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := []string{"GET /ojs/v1/queues", "POST /ojs/v1/jobs"}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("requests = %v, want %v", seen, want)
+	}
+}
+
+func TestEnqueue_ValidateQueueMissing(t *testing.T) {
+	var seen []string
+	c := newTestClient(queueListHandler([]string{"default", "emails", "billing"}, &seen))
+
+	err := Enqueue(c, []string{"--type", "email.send", "--queue", "emial", "--validate-queue"})
+	if err == nil || !strings.Contains(err.Error(), `queue "emial" does not exist (did you mean emails?)`) {
+		t.Errorf("err = %v, want missing queue error suggesting emails", err)
+	}
+	if len(seen) != 1 {
+		t.Errorf("requests = %v, job must not be enqueued", seen)
+	}
+}
+
+func TestEnqueue_ValidateQueueOffByDefault(t *testing.T) {
+	var seen []string
+	c := newTestClient(queueListHandler(nil, &seen))
+
+	captureStdout(t, func() {
+		if err := Enqueue(c, []string{"--type", "email.send", "--queue", "nowhere"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !reflect.DeepEqual(seen, []string{"POST /ojs/v1/jobs"}) {
+		t.Errorf("requests = %v, want only the enqueue", seen)
+	}
+}
+
+func TestClosestNames(t *testing.T) {
+	candidates := []string{"default", "emails", "email-digest", "billing", "critical"}
+	for _, tt := range []struct {
+		name string
+		want []string
+	}{
+		{"emial", []string{"emails"}},
+		{"Billing", []string{"billing"}},
+		{"defualt", []string{"default"}},
+		{"reports", nil},
+	} {
+		if got := closestNames(tt.name, candidates); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("closestNames(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
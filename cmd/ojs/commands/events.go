@@ -3,7 +3,6 @@ package commands
 import (
 	"bufio"
 	"encoding/json"
-	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -11,17 +10,47 @@ import (
 	"strings"
 	"time"
 
+	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/config"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
 // Events streams server-sent events from the OJS server.
 func Events(cfg *config.Config, args []string) error {
-	fs := flag.NewFlagSet("events", flag.ExitOnError)
+	fs := newFlagSet("events")
 	follow := fs.Bool("follow", true, "Stream events continuously")
 	types := fs.String("types", "", "Filter by event types (comma-separated)")
 	queue := fs.String("queue", "", "Filter by queue name")
-	fs.Parse(args)
+	forward := fs.String("forward", "", "POST each event's JSON to this URL")
+	retries := fs.Int("forward-retries", 3, "Retries per event when forwarding fails")
+	count := fs.Int("count", 0, "Exit after receiving this many events")
+	until := fs.Duration("until", 0, "Exit after this much time (e.g. 30s, 5m)")
+	pretty := fs.Bool("pretty", false, "Render each event as a one-line summary with its state transition")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs events [flags]", "Stream server-sent events, optionally filtered and forwarded to a URL.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// With --count or --until under --json, events are collected and printed
+	// as one array when the stream ends.
+	bounded := *count > 0 || *until > 0
+	collect := bounded && output.Format == "json"
+	collected := []json.RawMessage{}
+	finish := func() error {
+		if collect {
+			return output.JSON(collected)
+		}
+		return nil
+	}
+
+	var fwd *eventForwarder
+	if *forward != "" {
+		fwd = newEventForwarder(*forward, *retries)
+	}
 
 	path := "/ojs/v1/events/stream"
 	params := []string{}
@@ -46,8 +75,13 @@ func Events(cfg *config.Config, args []string) error {
 		req.Header.Set("Authorization", "Bearer "+cfg.AuthToken)
 	}
 
+	transport, err := client.NewTransport(cfg)
+	if err != nil {
+		return err
+	}
 	httpClient := &http.Client{
-		Timeout: 0, // no timeout for SSE
+		Timeout:   0, // no timeout for SSE
+		Transport: transport,
 	}
 
 	resp, err := httpClient.Do(req)
@@ -63,12 +97,21 @@ func Events(cfg *config.Config, args []string) error {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt)
 
-	if !*follow {
-		fmt.Println("Streaming events (press Ctrl+C to stop)...")
-	} else {
-		fmt.Println("Following events (press Ctrl+C to stop)...")
+	if output.Format != "json" {
+		if !*follow {
+			fmt.Println("Streaming events (press Ctrl+C to stop)...")
+		} else {
+			fmt.Println("Following events (press Ctrl+C to stop)...")
+		}
+		fmt.Println()
+	}
+
+	var deadline <-chan time.Time
+	if *until > 0 {
+		timer := time.NewTimer(*until)
+		defer timer.Stop()
+		deadline = timer.C
 	}
-	fmt.Println()
 
 	scanner := bufio.NewScanner(resp.Body)
 	eventCh := make(chan string, 1)
@@ -80,33 +123,58 @@ func Events(cfg *config.Config, args []string) error {
 		close(eventCh)
 	}()
 
+	seen := 0
 	for {
 		select {
 		case line, ok := <-eventCh:
 			if !ok {
-				fmt.Println("\nEvent stream closed.")
-				return nil
+				if output.Format != "json" {
+					fmt.Println("\nEvent stream closed.")
+				}
+				return finish()
 			}
 			if strings.HasPrefix(line, "data:") {
 				data := strings.TrimPrefix(line, "data:")
 				data = strings.TrimSpace(data)
-				if output.Format == "json" {
+				if fwd != nil {
+					if err := fwd.send([]byte(data)); err != nil {
+						fmt.Fprintf(os.Stderr, "⚠ forward failed: %v\n", err)
+					}
+				}
+				if collect {
+					if json.Valid([]byte(data)) {
+						collected = append(collected, json.RawMessage(data))
+					} else {
+						quoted, _ := json.Marshal(data)
+						collected = append(collected, quoted)
+					}
+				} else if output.Format == "json" {
 					fmt.Println(data)
 				} else {
 					var event map[string]any
-					if json.Unmarshal([]byte(data), &event) == nil {
+					if json.Unmarshal([]byte(data), &event) != nil {
+						fmt.Println(data)
+					} else if *pretty {
+						fmt.Println(prettyEvent(event, time.Now()))
+					} else {
 						ts := time.Now().Format("15:04:05")
 						fmt.Printf("[%s] %s: %s (job=%s, queue=%s)\n",
 							ts, str(event["type"]), str(event["event"]),
 							str(event["job_id"]), str(event["queue"]))
-					} else {
-						fmt.Println(data)
 					}
 				}
+				seen++
+				if *count > 0 && seen >= *count {
+					return finish()
+				}
 			}
+		case <-deadline:
+			return finish()
 		case <-sigCh:
-			fmt.Println("\nEvent stream stopped.")
-			return nil
+			if output.Format != "json" {
+				fmt.Println("\nEvent stream stopped.")
+			}
+			return finish()
 		}
 	}
 }
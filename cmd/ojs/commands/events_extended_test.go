@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/config"
+)
+
+func sseServer(t *testing.T, events ...string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/events/stream" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, e := range events {
+			fmt.Fprintf(w, "event: job\ndata: %s\n\n", e)
+		}
+	}))
+}
+
+func TestEvents_Forward(t *testing.T) {
+	orig := forwardBackoff
+	forwardBackoff = time.Millisecond
+	defer func() { forwardBackoff = orig }()
+
+	var mu sync.Mutex
+	var received []map[string]any
+	attempts := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("unexpected content type %q", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var event map[string]any
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Errorf("forwarded body is not JSON: %s", body)
+		}
+		received = append(received, event)
+	}))
+	defer target.Close()
+
+	src := sseServer(t,
+		`{"type":"job.completed","job_id":"j1"}`,
+		`{"type":"job.failed","job_id":"j2"}`,
+	)
+	defer src.Close()
+
+	captureStdout(t, func() {
+		if err := Events(&config.Config{ServerURL: src.URL}, []string{"--forward", target.URL}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 forwarded events, got %d", len(received))
+	}
+	if received[0]["job_id"] != "j1" || received[1]["job_id"] != "j2" {
+		t.Errorf("events forwarded out of order: %v", received)
+	}
+	if attempts != 3 {
+		t.Errorf("expected first event to be retried once (3 attempts total), got %d", attempts)
+	}
+}
+
+func TestEventForwarder_GivesUp(t *testing.T) {
+	orig := forwardBackoff
+	forwardBackoff = time.Millisecond
+	defer func() { forwardBackoff = orig }()
+
+	attempts := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer target.Close()
+
+	if err := newEventForwarder(target.URL, 2).send([]byte(`{}`)); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestEventForwarder_NoRetryOnClientError(t *testing.T) {
+	attempts := 0
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer target.Close()
+
+	if err := newEventForwarder(target.URL, 3).send([]byte(`{}`)); err == nil {
+		t.Fatal("expected error for 400 response")
+	}
+	if attempts != 1 {
+		t.Errorf("expected no retries on 400, got %d attempts", attempts)
+	}
+}
+
+// openSSEServer writes events and then holds the stream open until the
+// client disconnects, like a live server.
+func openSSEServer(events ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, e := range events {
+			fmt.Fprintf(w, "data: %s\n\n", e)
+		}
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+}
+
+func TestEvents_Count(t *testing.T) {
+	src := openSSEServer(`{"job_id":"j1"}`, `{"job_id":"j2"}`, `{"job_id":"j3"}`)
+	defer src.Close()
+
+	done := make(chan string)
+	go func() {
+		done <- captureStdout(t, func() {
+			if err := Events(&config.Config{ServerURL: src.URL}, []string{"--count", "2"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}()
+
+	select {
+	case out := <-done:
+		var events []map[string]any
+		if err := json.Unmarshal([]byte(out), &events); err != nil {
+			t.Fatalf("expected a JSON array: %v\n%s", err, out)
+		}
+		if len(events) != 2 || events[1]["job_id"] != "j2" {
+			t.Errorf("expected the first 2 events, got %v", events)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events --count did not exit")
+	}
+}
+
+func TestEvents_Until(t *testing.T) {
+	src := openSSEServer(`{"job_id":"j1"}`)
+	defer src.Close()
+
+	start := time.Now()
+	out := captureStdout(t, func() {
+		if err := Events(&config.Config{ServerURL: src.URL}, []string{"--until", "100ms"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond || elapsed > 3*time.Second {
+		t.Errorf("expected to exit at the deadline, took %v", elapsed)
+	}
+	var events []map[string]any
+	if err := json.Unmarshal([]byte(out), &events); err != nil {
+		t.Fatalf("expected a JSON array: %v\n%s", err, out)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected 1 event before the deadline, got %d", len(events))
+	}
+}
+
+func TestPrettyEvent(t *testing.T) {
+	at := "2026-03-01T12:34:56Z"
+	ts, _ := time.Parse(time.RFC3339, at)
+	event := map[string]any{
+		"type": "job.completed",
+		"time": at,
+		"data": map[string]any{
+			"job_id":     "j1",
+			"queue":      "billing",
+			"from_state": "active",
+			"to_state":   "completed",
+		},
+	}
+	got := prettyEvent(event, time.Now())
+	want := ts.Local().Format("15:04:05") + "  job.completed     j1  active → ✓ completed  queue=billing"
+	if got != want {
+		t.Errorf("prettyEvent =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestPrettyEvent_Minimal(t *testing.T) {
+	now := time.Date(2026, 3, 1, 8, 0, 0, 0, time.Local)
+	got := prettyEvent(map[string]any{"job_id": "j2", "state": "discarded"}, now)
+	if want := "08:00:00  event             j2  ✗ discarded"; got != want {
+		t.Errorf("prettyEvent = %q, want %q", got, want)
+	}
+}
+
+func TestEvents_Pretty(t *testing.T) {
+	withTableOutput(t)
+	src := sseServer(t, `{"type":"job.failed","job_id":"j1","from_state":"active","to_state":"retryable"}`)
+	defer src.Close()
+
+	out := captureStdout(t, func() {
+		if err := Events(&config.Config{ServerURL: src.URL}, []string{"--pretty"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "job.failed") || !strings.Contains(out, "j1  active → ↻ retryable") {
+		t.Errorf("expected a pretty line, got:\n%s", out)
+	}
+}
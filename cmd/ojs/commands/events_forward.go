@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// forwardBackoff is the delay before the first forward retry; it doubles on
+// each further attempt. Tests shorten it.
+var forwardBackoff = 500 * time.Millisecond
+
+// eventForwarder POSTs stream events to an HTTP endpoint, acting as a small
+// local webhook bridge.
+type eventForwarder struct {
+	url     string
+	retries int
+	http    *http.Client
+}
+
+func newEventForwarder(url string, retries int) *eventForwarder {
+	if retries < 0 {
+		retries = 0
+	}
+	return &eventForwarder{
+		url:     url,
+		retries: retries,
+		http:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// send delivers one event, retrying network errors, 429s, and 5xx responses.
+// Other 4xx responses are not retried since resending will not help.
+func (f *eventForwarder) send(event []byte) error {
+	backoff := forwardBackoff
+	var lastErr error
+	for attempt := 0; attempt <= f.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		resp, err := f.http.Post(f.url, "application/json", bytes.NewReader(event))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+			lastErr = fmt.Errorf("%s returned HTTP %d", f.url, resp.StatusCode)
+		default:
+			return fmt.Errorf("%s returned HTTP %d", f.url, resp.StatusCode)
+		}
+	}
+	return fmt.Errorf("giving up after %d attempt(s): %w", f.retries+1, lastErr)
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// prettyEvent renders a stream event as a single scannable line:
+// timestamp, event type, job id and state transition. The event's own
+// timestamp is used when present, falling back to now.
+func prettyEvent(event map[string]any, now time.Time) string {
+	data, _ := event["data"].(map[string]any)
+	field := func(keys ...string) string {
+		for _, k := range keys {
+			for _, m := range []map[string]any{event, data} {
+				if v, ok := m[k]; ok && v != nil && fmt.Sprint(v) != "" {
+					return fmt.Sprint(v)
+				}
+			}
+		}
+		return ""
+	}
+
+	ts := now
+	if raw := field("time", "timestamp", "occurred_at"); raw != "" {
+		if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			ts = t
+		}
+	}
+
+	eventType := field("type", "event")
+	if eventType == "" {
+		eventType = "event"
+	}
+
+	parts := []string{ts.Local().Format("15:04:05"), fmt.Sprintf("%-16s", eventType)}
+	if id := field("job_id", "subject"); id != "" {
+		parts = append(parts, id)
+	}
+
+	from := field("from_state", "previous_state", "from")
+	to := field("to_state", "state", "to")
+	switch {
+	case from != "" && to != "":
+		parts = append(parts, from+" → "+colorState(to))
+	case to != "":
+		parts = append(parts, colorState(to))
+	}
+
+	if q := field("queue"); q != "" {
+		parts = append(parts, "queue="+q)
+	}
+	return strings.Join(parts, "  ")
+}
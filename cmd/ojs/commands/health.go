@@ -3,6 +3,11 @@ package commands
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -10,11 +15,34 @@ import (
 
 // Health checks the server health.
 func Health(c *client.Client, args []string) error {
-	data, _, err := c.Get("/health")
+	fs := newFlagSet("health")
+	deep := fs.Bool("deep", false, "Also check readiness, liveness and each dependency; exit non-zero if any is unhealthy")
+	wait := fs.Bool("wait", false, "Poll until the server reports healthy; exit non-zero on timeout")
+	timeout := fs.Int("timeout", 60, "Seconds to wait with --wait")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs health [flags]", "Check server health and show the status of its backend.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var data []byte
+	var err error
+	if *wait {
+		data, err = waitForHealthy(c, time.Duration(*timeout)*time.Second)
+	} else {
+		data, _, err = c.Get("/health")
+	}
 	if err != nil {
 		return fmt.Errorf("server health check failed: %w", err)
 	}
 
+	if *deep {
+		return deepHealth(c, data)
+	}
+
 	if output.Format == "json" {
 		var result any
 		json.Unmarshal(data, &result)
@@ -47,3 +75,165 @@ func Health(c *client.Client, args []string) error {
 	output.Table(headers, rows)
 	return nil
 }
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// waitForHealthy polls /health until it succeeds with a healthy status or
+// the timeout elapses, returning the last healthy payload. Progress goes to
+// stderr: a spinner on a terminal, one line per attempt otherwise.
+func waitForHealthy(c *client.Client, timeout time.Duration) ([]byte, error) {
+	start := time.Now()
+	deadline := start.Add(timeout)
+	interactive := stdoutIsTerminal() && !output.Quiet
+	for attempt := 0; ; attempt++ {
+		data, _, err := c.Get("/health")
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			var health map[string]any
+			json.Unmarshal(data, &health)
+			status := str(health["status"])
+			if contains(healthyStatuses, strings.ToLower(status)) {
+				if interactive {
+					fmt.Fprint(os.Stderr, "\r\x1b[K")
+				}
+				return data, nil
+			}
+			reason = "status " + status
+		}
+
+		if time.Now().After(deadline) {
+			if interactive {
+				fmt.Fprintln(os.Stderr)
+			}
+			return nil, fmt.Errorf("timed out after %s waiting for server to become healthy (last: %s)", timeout, reason)
+		}
+		elapsed := time.Since(start).Truncate(time.Second)
+		if interactive {
+			fmt.Fprintf(os.Stderr, "\r%s Waiting for server to become healthy (%s, %s)\x1b[K", spinnerFrames[attempt%len(spinnerFrames)], elapsed, reason)
+		} else if !output.Quiet {
+			fmt.Fprintf(os.Stderr, "Waiting for server to become healthy (%s, %s)\n", elapsed, reason)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// healthyStatuses are the dependency statuses treated as healthy; anything
+// else, including "degraded", fails --deep.
+var healthyStatuses = []string{"ok", "healthy", "up", "pass", "passing", "ready", "live", "alive", "connected", "running"}
+
+// dependencyHealth is one row of the --deep report.
+type dependencyHealth struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Healthy bool   `json:"healthy"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// deepHealth reports every dependency in the /health payload plus the
+// readiness and liveness probes, and fails if any is unhealthy.
+func deepHealth(c *client.Client, data []byte) error {
+	var health map[string]any
+	json.Unmarshal(data, &health)
+
+	deps := healthDependencies(health)
+	for _, probe := range []string{"ready", "live"} {
+		if dep, ok := probeHealth(c, probe); ok {
+			deps = append(deps, dep)
+		}
+	}
+
+	var unhealthy []string
+	for _, d := range deps {
+		if !d.Healthy {
+			unhealthy = append(unhealthy, d.Name)
+		}
+	}
+
+	if output.Format == "json" {
+		if err := output.JSON(map[string]any{"status": health["status"], "dependencies": deps}); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("Status: %s\n\n", str(health["status"]))
+		headers := []string{"DEPENDENCY", "STATUS", "DETAIL"}
+		rows := make([][]string, 0, len(deps))
+		for _, d := range deps {
+			mark := "✓ "
+			if !d.Healthy {
+				mark = "✗ "
+			}
+			rows = append(rows, []string{d.Name, mark + d.Status, d.Detail})
+		}
+		output.Table(headers, rows)
+	}
+
+	if len(unhealthy) > 0 {
+		return fmt.Errorf("unhealthy: %s", strings.Join(unhealthy, ", "))
+	}
+	return nil
+}
+
+// healthDependencies collects the sub-checks of a /health payload: every
+// top-level object with a "status" (backend, scheduler, metrics, ...) and
+// the entries of a "checks" object, in name order.
+func healthDependencies(health map[string]any) []dependencyHealth {
+	var deps []dependencyHealth
+	for name, v := range health {
+		if obj, ok := v.(map[string]any); ok && obj["status"] != nil {
+			deps = append(deps, newDependencyHealth(name, obj))
+		}
+	}
+	checks, _ := health["checks"].(map[string]any)
+	for name, v := range checks {
+		switch v := v.(type) {
+		case map[string]any:
+			deps = append(deps, newDependencyHealth(name, v))
+		case string:
+			deps = append(deps, newDependencyHealth(name, map[string]any{"status": v}))
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].Name < deps[j].Name })
+	return deps
+}
+
+func newDependencyHealth(name string, obj map[string]any) dependencyHealth {
+	status := str(obj["status"])
+	var detail []string
+	for _, key := range []string{"type", "message", "error"} {
+		if obj[key] != nil {
+			detail = append(detail, str(obj[key]))
+		}
+	}
+	if obj["latency_ms"] != nil {
+		detail = append(detail, str(obj["latency_ms"])+"ms")
+	}
+	return dependencyHealth{
+		Name:    name,
+		Status:  status,
+		Healthy: contains(healthyStatuses, strings.ToLower(status)),
+		Detail:  strings.Join(detail, ", "),
+	}
+}
+
+// probeHealth checks /health/<probe>. Servers without the endpoint are
+// skipped (ok is false).
+func probeHealth(c *client.Client, probe string) (dep dependencyHealth, ok bool) {
+	dep.Name = probe
+	data, _, err := c.Get("/health/" + probe)
+	if client.IsStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented) {
+		return dep, false
+	}
+	if err != nil {
+		dep.Status = "failing"
+		dep.Detail = err.Error()
+		return dep, true
+	}
+	var body map[string]any
+	if json.Unmarshal(data, &body) == nil && body["status"] != nil {
+		return newDependencyHealth(probe, body), true
+	}
+	dep.Status, dep.Healthy = "ok", true
+	return dep, true
+}
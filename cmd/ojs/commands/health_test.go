@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealth_DeepDegradedBackend(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ojs/v1/health":
+			json.NewEncoder(w).Encode(map[string]any{
+				"status":    "degraded",
+				"version":   "1.2.0",
+				"backend":   map[string]any{"type": "redis", "status": "degraded", "latency_ms": 850},
+				"scheduler": map[string]any{"status": "running"},
+				"checks":    map[string]any{"metrics": "ok"},
+			})
+		case "/ojs/v1/health/ready":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"code":"unavailable","message":"backend slow"}}`))
+		case "/ojs/v1/health/live":
+			w.Write([]byte(`{"status":"ok"}`))
+		}
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = Health(c, []string{"--deep"}) })
+	if err == nil || err.Error() != "unhealthy: backend, ready" {
+		t.Errorf("err = %v, want unhealthy: backend, ready", err)
+	}
+
+	var report struct {
+		Dependencies []dependencyHealth `json:"dependencies"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	var got []string
+	for _, d := range report.Dependencies {
+		got = append(got, d.Name+"="+d.Status)
+	}
+	want := []string{"backend=degraded", "metrics=ok", "scheduler=running", "ready=failing", "live=ok"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("dependencies = %v, want %v", got, want)
+	}
+	if report.Dependencies[0].Detail != "redis, 850ms" {
+		t.Errorf("backend detail = %q", report.Dependencies[0].Detail)
+	}
+}
+
+func TestHealth_DeepHealthyTable(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"status":  "ok",
+			"backend": map[string]any{"type": "postgres", "status": "connected"},
+		})
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = Health(c, []string{"--deep"}) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "✓ connected") || strings.Contains(out, "ready") {
+		t.Errorf("unexpected table (missing probes should be skipped):\n%s", out)
+	}
+}
+
+func TestHealth_WaitUntilHealthy(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	calls := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"code":"unavailable","message":"starting"}}`))
+			return
+		}
+		w.Write([]byte(`{"status":"ok","version":"1.2.0"}`))
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = Health(c, []string{"--wait", "--timeout", "5"}) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if !strings.Contains(out, `"version": "1.2.0"`) {
+		t.Errorf("expected health payload in output, got:\n%s", out)
+	}
+}
+
+func TestHealth_WaitTimeout(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"degraded"}`))
+	})
+
+	err := Health(c, []string{"--wait", "--timeout", "0"})
+	if err == nil || !strings.Contains(err.Error(), "timed out") || !strings.Contains(err.Error(), "status degraded") {
+		t.Errorf("err = %v, want timeout mentioning the last status", err)
+	}
+}
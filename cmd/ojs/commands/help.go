@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// isHelpArg reports whether arg is one of the help flags.
+func isHelpArg(arg string) bool {
+	return arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+// flagErrorHandling is how command flag sets handle a bad flag. The CLI
+// exits with a usage error; the shell switches to flag.ContinueOnError so
+// the error is returned to it and the session carries on.
+var flagErrorHandling = flag.ExitOnError
+
+// newFlagSet returns the flag set for a command, using flagErrorHandling.
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flagErrorHandling)
+}
+
+// helpRequested reports whether args contain a help flag. Arguments after a
+// "--" terminator belong to the command and are not checked.
+func helpRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if isHelpArg(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+// printHelp prints a command's usage line, description and flags to stdout.
+// Commands check helpRequested before validating arguments or contacting the
+// server, so --help always works. fs may be nil for commands without flags.
+func printHelp(fs *flag.FlagSet, usage, description string) {
+	fmt.Printf("Usage: %s\n\n%s\n", usage, description)
+	if fs == nil {
+		return
+	}
+
+	var lines []string
+	fs.VisitAll(func(f *flag.Flag) {
+		name, text := flag.UnquoteUsage(f)
+		line := "  --" + f.Name
+		if name != "" {
+			line += " <" + name + ">"
+		}
+		if !isZeroDefault(f.DefValue) {
+			text += fmt.Sprintf(" (default: %s)", f.DefValue)
+		}
+		// Long flag names get their description on the next line, as in
+		// the hand-written doctor usage.
+		if len(line) > 21 {
+			line += "\n" + strings.Repeat(" ", 24)
+		} else {
+			line += strings.Repeat(" ", 24-len(line))
+		}
+		lines = append(lines, line+text)
+	})
+	if len(lines) > 0 {
+		fmt.Printf("\nFlags:\n%s\n", strings.Join(lines, "\n"))
+	}
+}
+
+func isZeroDefault(v string) bool {
+	switch v {
+	case "", "0", "false", "0s":
+		return true
+	}
+	return false
+}
+
+// printSubcommandHelp prints the usage text carried by a dispatcher's
+// "subcommand required" error, for "ojs <command> --help".
+func printSubcommandHelp(usage error) error {
+	_, text, _ := strings.Cut(usage.Error(), "\n\n")
+	fmt.Println(text)
+	return nil
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// noRequestClient fails the test if the command contacts the server.
+func noRequestClient(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}
+}
+
+func TestHelp_PrintsUsageWithoutRequest(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+
+	tests := []struct {
+		name string
+		run  func() error
+		want []string
+	}{
+		{"logs", func() error { return Logs(c, []string{"--help"}) },
+			[]string{"Usage: ojs logs <job-id>", "--follow", "--tail <int>"}},
+		{"enqueue", func() error { return Enqueue(c, []string{"--type", "email.send", "-h"}) },
+			[]string{"Usage: ojs enqueue", "--type <string>", "--queue <string>", "(default: default)"}},
+		{"cancel", func() error { return Cancel(c, []string{"--help"}) },
+			[]string{"Usage: ojs cancel <job-id>"}},
+		{"bulk cancel", func() error { return Bulk(c, []string{"cancel", "--help"}) },
+			[]string{"Usage: ojs bulk cancel", "--ids <string>", "--tag <value>"}},
+		{"bulk", func() error { return Bulk(c, []string{"--help"}) },
+			[]string{"Usage: ojs bulk <subcommand>", "reprioritize"}},
+		{"webhooks update", func() error { return Webhooks(c, []string{"update", "--help"}) },
+			[]string{"Usage: ojs webhooks update <subscription-id>", "--url <string>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			out := captureStdout(t, func() { err = tt.run() })
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("help output missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestHelpRequested(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{nil, false},
+		{[]string{"job-1"}, false},
+		{[]string{"job-1", "--help"}, true},
+		{[]string{"-h"}, true},
+		{[]string{"-help"}, true},
+		{[]string{"--", "--help"}, false},
+	}
+	for _, tt := range tests {
+		if got := helpRequested(tt.args); got != tt.want {
+			t.Errorf("helpRequested(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
@@ -2,34 +2,81 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/jobstate"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
 // Jobs lists jobs with optional filtering.
 func Jobs(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("jobs", flag.ExitOnError)
+	fs := newFlagSet("jobs")
 	state := fs.String("state", "", "Filter by state (available, active, completed, retryable, discarded, cancelled)")
 	queue := fs.String("queue", "", "Filter by queue name")
 	jobType := fs.String("type", "", "Filter by job type")
 	limit := fs.Int("limit", 25, "Max results to return")
-	fs.Parse(args)
+	var tagArgs stringList
+	fs.Var(&tagArgs, "tag", "Filter by tag key=value (repeatable, all must match)")
+	search := fs.String("search", "", "Find jobs whose args or meta contain this text")
+	searchField := fs.String("search-field", "", "Limit --search to args or meta")
 
-	path := fmt.Sprintf("/jobs?limit=%d", *limit)
+	if helpRequested(args) {
+		printHelp(fs, "ojs jobs [flags]", "List and search jobs, filtered by state, queue, type or tags.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	tags, err := parseTags(tagArgs)
+	if err != nil {
+		return err
+	}
+	if *searchField != "" && *searchField != "args" && *searchField != "meta" {
+		return fmt.Errorf("invalid --search-field %q (use args or meta)", *searchField)
+	}
+	if *searchField != "" && *search == "" {
+		return fmt.Errorf("--search-field requires --search")
+	}
+	if *state != "" {
+		if err := validateStates("--state", *state); err != nil {
+			return err
+		}
+	}
+
+	filters := ""
 	if *state != "" {
-		path += "&state=" + *state
+		filters += "&state=" + *state
 	}
 	if *queue != "" {
-		path += "&queue=" + *queue
+		filters += "&queue=" + *queue
 	}
 	if *jobType != "" {
-		path += "&type=" + *jobType
+		filters += "&type=" + *jobType
+	}
+	if len(tags) > 0 {
+		filters += "&" + tagQuery(tags)
+	}
+
+	path := fmt.Sprintf("/jobs?limit=%d", *limit) + filters
+	if *search != "" {
+		path += "&search=" + url.QueryEscape(*search)
+		if *searchField != "" {
+			path += "&search_field=" + *searchField
+		}
 	}
 
 	data, _, err := c.Get(path)
+	if *search != "" && (client.IsStatus(err, http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented) ||
+		err == nil && searchIgnored(data, *search)) {
+		output.Warn("Server does not support job search; searching the %d most recent jobs locally", searchScanLimit)
+		data, err = searchJobsLocally(c, filters, *search, *searchField, *limit)
+	}
 	if err != nil {
 		return err
 	}
@@ -65,3 +112,88 @@ func Jobs(c *client.Client, args []string) error {
 	return nil
 }
 
+// searchScanLimit caps how many jobs the client-side search fallback reads.
+const searchScanLimit = 1000
+
+// searchPageSize is the page size used by the client-side search fallback.
+const searchPageSize = 100
+
+// searchJobsLocally pages through /jobs with the given filters and keeps the
+// jobs whose args or meta (or only field, if set) contain text, ignoring
+// case. It returns a /jobs-shaped response with at most limit jobs.
+func searchJobsLocally(c *client.Client, filters, text, field string, limit int) ([]byte, error) {
+	matches := []map[string]any{}
+	for offset := 0; offset < searchScanLimit && len(matches) < limit; offset += searchPageSize {
+		data, _, err := c.Get(fmt.Sprintf("/jobs?limit=%d&offset=%d", searchPageSize, offset) + filters)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Jobs []map[string]any `json:"jobs"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("parse jobs: %w", err)
+		}
+		for _, j := range page.Jobs {
+			if len(matches) < limit && jobMatches(j, text, field) {
+				matches = append(matches, j)
+			}
+		}
+		if len(page.Jobs) < searchPageSize {
+			break
+		}
+	}
+	return json.Marshal(map[string]any{"jobs": matches, "total": len(matches)})
+}
+
+// searchIgnored reports whether a /jobs response says the server did not
+// apply the search: it echoes a "search" field that is empty or differs from
+// text. Responses without the field are trusted, since servers may match in
+// ways a substring check can't reproduce.
+func searchIgnored(data []byte, text string) bool {
+	var resp map[string]json.RawMessage
+	if json.Unmarshal(data, &resp) != nil {
+		return false
+	}
+	raw, ok := resp["search"]
+	if !ok {
+		return false
+	}
+	var echoed string
+	json.Unmarshal(raw, &echoed)
+	return echoed != text
+}
+
+// jobMatches reports whether the JSON encoding of the job's args or meta
+// (or only field) contains text, ignoring case.
+func jobMatches(job map[string]any, text, field string) bool {
+	text = strings.ToLower(text)
+	for _, f := range []string{"args", "meta"} {
+		if field != "" && f != field || job[f] == nil {
+			continue
+		}
+		var encoded strings.Builder
+		enc := json.NewEncoder(&encoded)
+		enc.SetEscapeHTML(false) // keep <, > and & searchable
+		enc.Encode(job[f])
+		if strings.Contains(strings.ToLower(encoded.String()), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStates rejects job states that are not part of the lifecycle,
+// suggesting the closest valid state for typos such as "complete".
+func validateStates(flagName string, states ...string) error {
+	err := jobstate.Validate(states...)
+	var unknown *jobstate.UnknownError
+	if !errors.As(err, &unknown) {
+		return err
+	}
+	msg := fmt.Sprintf("invalid %s %q", flagName, unknown.State)
+	if matches := closestNames(unknown.State, jobstate.All); len(matches) > 0 {
+		msg += fmt.Sprintf(" (did you mean %s?)", strings.Join(matches, ", "))
+	}
+	return fmt.Errorf("%s\n\nValid states: %s", msg, strings.Join(jobstate.All, ", "))
+}
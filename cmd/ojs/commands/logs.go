@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// Logs prints the captured execution logs of a job.
+func Logs(c *client.Client, args []string) error {
+	fs := newFlagSet("logs")
+	follow := fs.Bool("follow", false, "Stream new log lines as they are written")
+	tail := fs.Int("tail", 0, "Only show the last N lines")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs logs <job-id> [flags]", "Show the captured execution logs of a job.")
+		return nil
+	}
+
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("job ID required\n\nUsage: ojs logs <job-id> [--follow] [--tail N]")
+	}
+	jobID := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	path := "/jobs/" + jobID + "/logs"
+	params := []string{}
+	if *tail > 0 {
+		params = append(params, fmt.Sprintf("tail=%d", *tail))
+	}
+	if *follow {
+		params = append(params, "follow=true")
+	}
+	if len(params) > 0 {
+		path += "?" + strings.Join(params, "&")
+	}
+
+	if *follow {
+		return followLogs(c, jobID, path)
+	}
+
+	data, _, err := c.Get(path)
+	if err != nil {
+		return logsError(c, jobID, err)
+	}
+
+	entries := parseLogEntries(data)
+	if *tail > 0 && len(entries) > *tail {
+		entries = entries[len(entries)-*tail:]
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"job_id": jobID, "logs": entries})
+	}
+	if len(entries) == 0 {
+		fmt.Println("No logs captured for this job.")
+		return nil
+	}
+	for _, e := range entries {
+		fmt.Println(formatLogEntry(e))
+	}
+	return nil
+}
+
+// followLogs streams log lines until the server closes the stream or the
+// user presses Ctrl-C. Lines may arrive as SSE "data:" frames or plain text.
+func followLogs(c *client.Client, jobID, path string) error {
+	resp, err := c.Stream(path)
+	if err != nil {
+		return logsError(c, jobID, err)
+	}
+	defer resp.Body.Close()
+
+	sse := strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			if sse {
+				if !strings.HasPrefix(line, "data:") {
+					continue
+				}
+				line = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			}
+			if output.Format == "json" {
+				fmt.Println(line)
+				continue
+			}
+			var entry any = line
+			if json.Valid([]byte(line)) {
+				json.Unmarshal([]byte(line), &entry)
+			}
+			fmt.Println(formatLogEntry(entry))
+		case <-sigCh:
+			return nil
+		}
+	}
+}
+
+// parseLogEntries accepts either a bare JSON array or an object with a
+// "logs" (or "lines") array. Entries are strings or structured objects.
+func parseLogEntries(data []byte) []any {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		// Plain-text body: one entry per line.
+		entries := []any{}
+		for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+			if line != "" {
+				entries = append(entries, line)
+			}
+		}
+		return entries
+	}
+	switch v := raw.(type) {
+	case []any:
+		return v
+	case map[string]any:
+		for _, key := range []string{"logs", "lines"} {
+			if items, ok := v[key].([]any); ok {
+				return items
+			}
+		}
+	}
+	return []any{}
+}
+
+// formatLogEntry renders "timestamp [stream] message" for structured entries
+// and prints string entries as-is.
+func formatLogEntry(e any) string {
+	m, ok := e.(map[string]any)
+	if !ok {
+		return fmt.Sprint(e)
+	}
+	field := func(key string) string {
+		if v, ok := m[key]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+		return ""
+	}
+
+	msg := field("message")
+	if msg == "" {
+		msg = field("line")
+	}
+	var prefix []string
+	if ts := field("timestamp"); ts != "" {
+		prefix = append(prefix, ts)
+	}
+	if stream := field("stream"); stream != "" {
+		prefix = append(prefix, "["+stream+"]")
+	} else if level := field("level"); level != "" {
+		prefix = append(prefix, "["+level+"]")
+	}
+	if len(prefix) == 0 {
+		return msg
+	}
+	return strings.Join(prefix, " ") + " " + msg
+}
+
+// logsError turns a 404/405/501 on the logs endpoint into a clear message
+// when the job itself exists, since that means the server lacks job logs.
+func logsError(c *client.Client, jobID string, err error) error {
+	if !client.IsStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented) {
+		return err
+	}
+	if _, _, jobErr := c.Get("/jobs/" + jobID); jobErr != nil {
+		return jobErr
+	}
+	return fmt.Errorf("this server does not support job logs (GET /jobs/%s/logs: %v)", jobID, err)
+}
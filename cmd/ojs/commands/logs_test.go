@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLogs_Batch(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/jobs/job-1/logs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"logs": []any{
+			map[string]any{"timestamp": "12:00:01", "stream": "stdout", "message": "starting"},
+			map[string]any{"timestamp": "12:00:02", "stream": "stderr", "message": "boom"},
+			"plain line",
+		}})
+	})
+
+	out := captureStdout(t, func() {
+		if err := Logs(c, []string{"job-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := "12:00:01 [stdout] starting\n12:00:02 [stderr] boom\nplain line\n"
+	if out != want {
+		t.Errorf("unexpected output:\n%q\nwant:\n%q", out, want)
+	}
+}
+
+func TestLogs_Tail(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tail") != "2" {
+			t.Errorf("expected tail=2, got %q", r.URL.RawQuery)
+		}
+		// A server that ignores tail still gets trimmed client-side.
+		w.Write([]byte(`["one", "two", "three", "four"]`))
+	})
+
+	out := captureStdout(t, func() {
+		if err := Logs(c, []string{"job-1", "--tail", "2"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result struct {
+		Logs []string `json:"logs"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if strings.Join(result.Logs, ",") != "three,four" {
+		t.Errorf("expected last 2 lines, got %v", result.Logs)
+	}
+}
+
+func TestLogs_Follow(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("follow") != "true" {
+			t.Errorf("expected follow=true, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"stream\":\"stdout\",\"message\":\"step 1\"}\n\n")
+		fmt.Fprint(w, "data: step 2\n\n")
+	})
+
+	out := captureStdout(t, func() {
+		if err := Logs(c, []string{"job-1", "--follow"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if out != "[stdout] step 1\nstep 2\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestLogs_Unsupported(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/logs") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"job": {"id": "job-1"}}`))
+	})
+
+	err := Logs(c, []string{"job-1"})
+	if err == nil || !strings.Contains(err.Error(), "does not support job logs") {
+		t.Errorf("expected unsupported message, got %v", err)
+	}
+}
+
+func TestLogs_MissingJob(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": {"code": "not_found", "message": "job not found"}}`))
+	})
+
+	err := Logs(c, []string{"job-1"})
+	if err == nil || !strings.Contains(err.Error(), "job not found") {
+		t.Errorf("expected job not found error, got %v", err)
+	}
+}
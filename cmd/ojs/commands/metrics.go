@@ -2,8 +2,8 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"os"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -11,9 +11,37 @@ import (
 
 // Metrics retrieves server metrics.
 func Metrics(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("metrics", flag.ExitOnError)
+	fs := newFlagSet("metrics")
 	format := fs.String("format", "", "Output format: prometheus or json (default: auto)")
-	fs.Parse(args)
+	out := fs.String("output", "", "Save the JSON metrics snapshot to a file")
+	diff := fs.Bool("diff", false, "Compare two saved snapshots: --diff <before.json> <after.json>")
+	pushTo := fs.String("push-to", "", "Push Prometheus metrics to this Pushgateway URL")
+	job := fs.String("job", "ojs-cli", "Pushgateway job name (with --push-to)")
+	labels := fs.String("labels", "", "Pushgateway grouping labels as k=v,k2=v2 (with --push-to)")
+	outputDir := fs.String("output-dir", "", "Save the JSON metrics snapshot to a timestamped file in this directory")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs metrics [flags]", "Show server metrics, save snapshots, compare them or push them to a Pushgateway.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *outputDir != "" && (*diff || *pushTo != "" || *format == "prometheus" || *out != "") {
+		return fmt.Errorf("--output-dir saves JSON snapshots and cannot be combined with --diff, --push-to, --format prometheus or --output")
+	}
+
+	if *diff {
+		if fs.NArg() != 2 {
+			return fmt.Errorf("--diff needs two snapshot files\n\nUsage: ojs metrics --diff <before.json> <after.json>")
+		}
+		return metricsDiff(fs.Arg(0), fs.Arg(1))
+	}
+
+	if *pushTo != "" {
+		return metricsPush(c, *pushTo, *job, *labels)
+	}
 
 	if *format == "prometheus" {
 		data, _, err := c.Get("/metrics?format=prometheus")
@@ -29,6 +57,18 @@ func Metrics(c *client.Client, args []string) error {
 		return err
 	}
 
+	if *outputDir != "" {
+		return saveReport(*outputDir, "metrics", json.RawMessage(data))
+	}
+
+	if *out != "" {
+		if err := os.WriteFile(*out, data, 0644); err != nil {
+			return fmt.Errorf("write %s: %w", *out, err)
+		}
+		output.Success("Saved metrics snapshot to %s", *out)
+		return nil
+	}
+
 	if output.Format == "json" || *format == "json" {
 		var result any
 		json.Unmarshal(data, &result)
@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// metricDelta is the change of one metric between two snapshots. Before or
+// After is nil when the metric is missing from that snapshot.
+type metricDelta struct {
+	Name          string   `json:"name"`
+	Before        *float64 `json:"before"`
+	After         *float64 `json:"after"`
+	Delta         float64  `json:"delta"`
+	PercentChange *float64 `json:"percent_change"`
+	Status        string   `json:"status"` // "", "added", "removed", "regressed"
+}
+
+// metricsDiff compares two saved metrics snapshots without contacting the
+// server.
+func metricsDiff(pathA, pathB string) error {
+	before, err := loadMetricsSnapshot(pathA)
+	if err != nil {
+		return err
+	}
+	after, err := loadMetricsSnapshot(pathB)
+	if err != nil {
+		return err
+	}
+
+	deltas := diffMetrics(before, after)
+	regressions := 0
+	for _, d := range deltas {
+		if d.Status == "regressed" {
+			regressions++
+		}
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"metrics": deltas, "regressions": regressions})
+	}
+
+	headers := []string{"METRIC", "BEFORE", "AFTER", "DELTA", "CHANGE", "STATUS"}
+	rows := make([][]string, 0, len(deltas))
+	for _, d := range deltas {
+		change := "-"
+		if d.PercentChange != nil {
+			change = fmt.Sprintf("%+.1f%%", *d.PercentChange)
+		}
+		delta := "-"
+		if d.Before != nil && d.After != nil {
+			delta = formatMetric(d.Delta, true)
+		}
+		rows = append(rows, []string{d.Name, formatMetricPtr(d.Before), formatMetricPtr(d.After), delta, change, d.Status})
+	}
+	output.Table(headers, rows)
+	if regressions > 0 {
+		output.Warn("%d metric(s) regressed", regressions)
+	}
+	return nil
+}
+
+func loadMetricsSnapshot(path string) (map[string]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %w", err)
+	}
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse snapshot %s: %w", path, err)
+	}
+	metrics := map[string]float64{}
+	flattenMetrics("", raw, metrics)
+	return metrics, nil
+}
+
+// flattenMetrics collects the numeric leaves of v, naming nested values by
+// their dotted path (e.g. "queues.default.depth").
+func flattenMetrics(prefix string, v any, out map[string]float64) {
+	switch t := v.(type) {
+	case float64:
+		out[prefix] = t
+	case map[string]any:
+		for k, child := range t {
+			name := k
+			if prefix != "" {
+				name = prefix + "." + k
+			}
+			flattenMetrics(name, child, out)
+		}
+	}
+}
+
+// diffMetrics returns the per-metric changes between two snapshots, sorted by
+// name. Unchanged metrics are included so the diff shows the full picture.
+func diffMetrics(before, after map[string]float64) []metricDelta {
+	names := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		names[k] = true
+	}
+	for k := range after {
+		names[k] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for k := range names {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	deltas := make([]metricDelta, 0, len(sorted))
+	for _, name := range sorted {
+		d := metricDelta{Name: name}
+		b, hasB := before[name]
+		a, hasA := after[name]
+		switch {
+		case !hasB:
+			d.After = &a
+			d.Status = "added"
+		case !hasA:
+			d.Before = &b
+			d.Status = "removed"
+		default:
+			d.Before, d.After = &b, &a
+			d.Delta = a - b
+			if b != 0 {
+				pct := d.Delta / math.Abs(b) * 100
+				d.PercentChange = &pct
+			}
+			if metricRegressed(name, d.Delta) {
+				d.Status = "regressed"
+			}
+		}
+		deltas = append(deltas, d)
+	}
+	return deltas
+}
+
+// metricRegressed reports whether a change in the named metric is a change
+// for the worse: more failures or latency, or less throughput or completions.
+func metricRegressed(name string, delta float64) bool {
+	n := strings.ToLower(name)
+	for _, bad := range []string{"fail", "error", "latency", "duration", "dead", "retr"} {
+		if strings.Contains(n, bad) {
+			return delta > 0
+		}
+	}
+	for _, good := range []string{"throughput", "completed", "succeeded"} {
+		if strings.Contains(n, good) {
+			return delta < 0
+		}
+	}
+	return false
+}
+
+func formatMetricPtr(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return formatMetric(*v, false)
+}
+
+func formatMetric(v float64, signed bool) string {
+	prec := 2
+	if v == math.Trunc(v) {
+		prec = 0
+	}
+	s := strconv.FormatFloat(v, 'f', prec, 64)
+	if signed && v > 0 {
+		s = "+" + s
+	}
+	return s
+}
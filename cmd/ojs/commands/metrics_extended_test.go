@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSnapshot(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiffMetrics(t *testing.T) {
+	before := map[string]float64{
+		"jobs_completed_total": 100,
+		"jobs_failed_total":    4,
+		"avg_latency_ms":       12.5,
+		"legacy_metric":        7,
+	}
+	after := map[string]float64{
+		"jobs_completed_total": 150,
+		"jobs_failed_total":    10,
+		"avg_latency_ms":       10,
+		"workers_active":       3,
+	}
+
+	deltas := diffMetrics(before, after)
+	byName := map[string]metricDelta{}
+	for _, d := range deltas {
+		byName[d.Name] = d
+	}
+	if len(deltas) != 5 {
+		t.Fatalf("expected 5 metrics, got %d", len(deltas))
+	}
+	if deltas[0].Name != "avg_latency_ms" || deltas[4].Name != "workers_active" {
+		t.Errorf("expected metrics sorted by name, got %s..%s", deltas[0].Name, deltas[4].Name)
+	}
+
+	completed := byName["jobs_completed_total"]
+	if completed.Delta != 50 || completed.PercentChange == nil || *completed.PercentChange != 50 || completed.Status != "" {
+		t.Errorf("unexpected completed delta: %+v", completed)
+	}
+	if failed := byName["jobs_failed_total"]; failed.Status != "regressed" || *failed.PercentChange != 150 {
+		t.Errorf("expected failed total to regress by 150%%, got %+v", failed)
+	}
+	if latency := byName["avg_latency_ms"]; latency.Status != "" || latency.Delta != -2.5 {
+		t.Errorf("lower latency should not regress: %+v", latency)
+	}
+	if added := byName["workers_active"]; added.Status != "added" || added.Before != nil || *added.After != 3 {
+		t.Errorf("expected workers_active to be added: %+v", added)
+	}
+	if removed := byName["legacy_metric"]; removed.Status != "removed" || removed.After != nil || *removed.Before != 7 {
+		t.Errorf("expected legacy_metric to be removed: %+v", removed)
+	}
+}
+
+func TestMetrics_Diff(t *testing.T) {
+	dir := t.TempDir()
+	a := writeSnapshot(t, dir, "a.json", `{"jobs_failed_total": 2, "throughput_per_second": 40, "queues": {"default": {"depth": 5}}}`)
+	b := writeSnapshot(t, dir, "b.json", `{"jobs_failed_total": 2, "throughput_per_second": 30}`)
+
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("--diff should not contact the server, got %s", r.URL.Path)
+	})
+	out := captureStdout(t, func() {
+		if err := Metrics(c, []string{"--diff", a, b}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var result struct {
+		Metrics     []metricDelta `json:"metrics"`
+		Regressions int           `json:"regressions"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Regressions != 1 {
+		t.Errorf("expected 1 regression (throughput), got %d", result.Regressions)
+	}
+	if len(result.Metrics) != 3 || result.Metrics[0].Name != "jobs_failed_total" || result.Metrics[1].Name != "queues.default.depth" {
+		t.Errorf("unexpected metrics: %+v", result.Metrics)
+	}
+	if result.Metrics[1].Status != "removed" {
+		t.Errorf("expected nested metric to be removed, got %q", result.Metrics[1].Status)
+	}
+}
+
+func TestMetrics_DiffNeedsTwoFiles(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {})
+	if err := Metrics(c, []string{"--diff", "only-one.json"}); err == nil {
+		t.Fatal("expected error with a single snapshot")
+	}
+}
+
+func TestMetrics_OutputSnapshot(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"jobs_enqueued_total": 9}`))
+	})
+	path := filepath.Join(t.TempDir(), "snap.json")
+	captureStdout(t, func() {
+		if err := Metrics(c, []string{"--output", path}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	snap, err := loadMetricsSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snap["jobs_enqueued_total"] != 9 {
+		t.Errorf("unexpected snapshot: %v", snap)
+	}
+}
+
+func TestMetrics_PushTo(t *testing.T) {
+	const promText = "# TYPE ojs_jobs_total counter\nojs_jobs_total 42"
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") != "prometheus" {
+			t.Errorf("expected prometheus format, got %q", r.URL.RawQuery)
+		}
+		w.Write([]byte(promText))
+	})
+
+	var gotPath, gotBody, gotType, gotUser string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		gotPath = r.URL.Path
+		gotType = r.Header.Get("Content-Type")
+		gotUser, _, _ = r.BasicAuth()
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	pushURL := strings.Replace(gateway.URL, "http://", "http://ci:pw@", 1)
+	captureStdout(t, func() {
+		err := Metrics(c, []string{"--push-to", pushURL, "--job", "nightly", "--labels", "region=eu,env=prod"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if gotPath != "/metrics/job/nightly/env/prod/region/eu" {
+		t.Errorf("unexpected push path %q", gotPath)
+	}
+	if gotBody != promText+"\n" {
+		t.Errorf("unexpected push body %q", gotBody)
+	}
+	if !strings.HasPrefix(gotType, "text/plain") {
+		t.Errorf("unexpected content type %q", gotType)
+	}
+	if gotUser != "ci" {
+		t.Errorf("expected basic auth user from URL, got %q", gotUser)
+	}
+}
+
+func TestPushgatewayURL_Escaping(t *testing.T) {
+	for _, tt := range []struct {
+		job    string
+		labels map[string]string
+		want   string
+	}{
+		{"my job", map[string]string{"env": "prod eu"}, "http://gw:9091/base/metrics/job/my%20job/env/prod%20eu"},
+		{"a/b", map[string]string{"path": "/var/tmp"}, "http://gw:9091/base/metrics/job@base64/YS9i/path@base64/L3Zhci90bXA"},
+		{"nightly", map[string]string{"shard": ""}, "http://gw:9091/base/metrics/job/nightly/shard@base64/="},
+	} {
+		got, err := pushgatewayURL("http://gw:9091/base/", tt.job, tt.labels)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.job, err)
+		}
+		if got != tt.want {
+			t.Errorf("pushgatewayURL(%q, %v) = %s, want %s", tt.job, tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestMetrics_PushToEscapedGroupingKey(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ojs_jobs_total 1\n"))
+	})
+	var gotPath string
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+	}))
+	defer gateway.Close()
+
+	captureStdout(t, func() {
+		if err := Metrics(c, []string{"--push-to", gateway.URL, "--job", "my job", "--labels", "team=a/b"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if gotPath != "/metrics/job/my%20job/team@base64/YS9i" {
+		t.Errorf("gateway saw path %q", gotPath)
+	}
+}
+
+func TestMetrics_PushToErrors(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ojs_jobs_total 1\n"))
+	})
+
+	for _, tt := range []struct {
+		status int
+		want   string
+	}{
+		{http.StatusUnauthorized, "credentials"},
+		{http.StatusBadRequest, "HTTP 400: bad metric"},
+	} {
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte("bad metric"))
+		}))
+		err := Metrics(c, []string{"--push-to", gateway.URL})
+		gateway.Close()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("status %d: expected error containing %q, got %v", tt.status, tt.want, err)
+		}
+	}
+
+	if err := Metrics(c, []string{"--push-to", "http://gw:9091", "--labels", "novalue"}); err == nil {
+		t.Error("expected error for malformed label")
+	}
+}
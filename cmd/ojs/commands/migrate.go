@@ -5,17 +5,23 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/codegen"
 	"github.com/openjobspec/ojs-cli/internal/migrate"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
 // Migrate implements the migration wizard with subcommands: analyze, export, import, validate,
-// generate, sidekiq, bullmq, celery, detect, validate-config.
+// verify, generate, sidekiq, bullmq, celery, detect, validate-config, backend.
 func Migrate(c *client.Client, args []string) error {
 	if len(args) == 0 {
-		return fmt.Errorf("missing subcommand\n\nUsage:\n  ojs migrate analyze <source> --redis <url>\n  ojs migrate export <source> --redis <url> --output <file>\n  ojs migrate import --file <file> [--dry-run]\n  ojs migrate validate --file <file>\n  ojs migrate generate --source <system> [--output <dir>]\n  ojs migrate sidekiq <config-file> [--output <file>] [--dry-run]\n  ojs migrate bullmq <config-file> [--output <file>] [--dry-run]\n  ojs migrate celery <config-file> [--output <file>] [--dry-run]\n  ojs migrate detect <directory>\n  ojs migrate validate-config <ojs-config.json>\n\nSupported sources: sidekiq, bullmq, celery, faktory, river")
+		return fmt.Errorf("missing subcommand\n\n" + migrateUsage)
+	}
+	if isHelpArg(args[0]) {
+		fmt.Println(migrateUsage)
+		return nil
 	}
 
 	// Parse shared flags for config-file converters
@@ -30,6 +36,8 @@ func Migrate(c *client.Client, args []string) error {
 		return migrateImport(c, args[1:])
 	case "validate":
 		return migrateValidate(args[1:])
+	case "verify":
+		return migrateVerify(c, args[1:])
 	case "generate":
 		return MigrateGenerate(args[1:])
 	case "sidekiq":
@@ -42,11 +50,15 @@ func Migrate(c *client.Client, args []string) error {
 		return migrateDetect(args[1:])
 	case "validate-config":
 		return migrateValidateConfig(args[1:])
+	case "backend":
+		return migrateBackend(args[1:])
 	default:
-		return fmt.Errorf("unknown migrate subcommand: %s\n\nSubcommands: analyze, export, import, validate, generate, sidekiq, bullmq, celery, detect, validate-config", args[0])
+		return fmt.Errorf("unknown migrate subcommand: %s\n\nSubcommands: analyze, export, import, validate, verify, generate, sidekiq, bullmq, celery, detect, validate-config, backend", args[0])
 	}
 }
 
+const migrateUsage = "Usage:\n  ojs migrate analyze <source> --redis <url> [--output <file>] [--compare <file>]\n  ojs migrate export <source> --redis <url> --output <file>\n  ojs migrate import --file <file> [--dry-run] [--no-progress] [--map-queue old=new] [--map-type old=new]\n  ojs migrate validate --file <file> [--schema <manifest>]\n  ojs migrate verify --source <system> --redis <url>\n  ojs migrate generate --source <system> [--output <dir>]\n  ojs migrate sidekiq <config-file> [--output <file>] [--dry-run]\n  ojs migrate bullmq <config-file> [--output <file>] [--dry-run]\n  ojs migrate celery <config-file> [--output <file>] [--dry-run]\n  ojs migrate detect <directory>\n  ojs migrate validate-config <ojs-config.json>\n  ojs migrate backend --source-url <url> --target-url <url> [--batch-size <n>] [--checkpoint <file>] [--dry-run]\n\nSupported sources: sidekiq, bullmq, celery, faktory, river"
+
 // parseMigrateFlags extracts --dry-run and --output flags, returning remaining positional args.
 func parseMigrateFlags(args []string) (dryRun bool, outputFile string, remaining []string) {
 	for i := 0; i < len(args); i++ {
@@ -66,18 +78,35 @@ func parseMigrateFlags(args []string) (dryRun bool, outputFile string, remaining
 }
 
 func migrateAnalyze(args []string) error {
+	fs := flag.NewFlagSet("migrate analyze", flag.ContinueOnError)
+	redisURL := fs.String("redis", "redis://localhost:6379", "Redis connection URL")
+	faktory := faktoryFlags(fs)
+	outFile := fs.String("output", "", "Save the analysis report as JSON to this file")
+	compare := fs.String("compare", "", "Show what changed since a report saved with --output")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs migrate analyze <source> [flags]", "Inspect a source system's queues and job types before migrating.\nSupported sources: sidekiq, bullmq, celery, faktory, river")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("missing source\n\nSupported sources: sidekiq, bullmq, celery, faktory, river")
 	}
 
 	sourceName := args[0]
-	fs := flag.NewFlagSet("migrate analyze", flag.ContinueOnError)
-	redisURL := fs.String("redis", "redis://localhost:6379", "Redis connection URL")
 	if err := fs.Parse(args[1:]); err != nil {
 		return fmt.Errorf("parse flags: %w", err)
 	}
 
-	src, err := newSource(sourceName, *redisURL)
+	var prev *migrate.AnalysisResult
+	if *compare != "" {
+		var err error
+		if prev, err = migrate.LoadAnalysis(*compare); err != nil {
+			return err
+		}
+	}
+
+	src, err := openSource(sourceName, *redisURL, *faktory)
 	if err != nil {
 		return err
 	}
@@ -88,6 +117,16 @@ func migrateAnalyze(args []string) error {
 		return fmt.Errorf("analyze failed: %w", err)
 	}
 
+	if *outFile != "" {
+		if err := migrate.SaveAnalysis(*outFile, result); err != nil {
+			return err
+		}
+	}
+
+	if prev != nil {
+		return printAnalysisDiff(migrate.CompareAnalyses(prev, result), *compare)
+	}
+
 	if output.Format == "json" {
 		return output.JSON(result)
 	}
@@ -111,23 +150,70 @@ func migrateAnalyze(args []string) error {
 	output.Table(headers, rows)
 
 	fmt.Printf("\n%s\n", result.Summary)
+	if *outFile != "" {
+		output.Success("Analysis saved to %s", *outFile)
+	}
+	return nil
+}
+
+// printAnalysisDiff shows the queues and job counts that changed since the
+// analysis saved in prevPath.
+func printAnalysisDiff(diff *migrate.AnalysisDiff, prevPath string) error {
+	if output.Format == "json" {
+		return output.JSON(diff)
+	}
+
+	fmt.Printf("Changes since %s: %s\n", prevPath, diff.Source)
+	fmt.Printf("Total Jobs: %d → %d (%+d)\n\n", diff.PreviousTotal, diff.CurrentTotal, diff.TotalDelta)
+	if !diff.Changed() {
+		fmt.Println("No changes.")
+		return nil
+	}
+
+	headers := []string{"QUEUE", "STATUS", "PENDING", "DELTA", "JOB TYPES"}
+	var rows [][]string
+	for _, q := range diff.Queues {
+		if q.Status == migrate.QueueUnchanged {
+			continue
+		}
+		types := make([]string, 0, len(q.JobTypes))
+		for _, t := range q.JobTypes {
+			types = append(types, fmt.Sprintf("%s(%+d)", t.Type, t.Delta))
+		}
+		rows = append(rows, []string{
+			q.Name, q.Status,
+			fmt.Sprintf("%d → %d", q.PreviousPending, q.CurrentPending),
+			fmt.Sprintf("%+d", q.PendingDelta),
+			strings.Join(types, ", "),
+		})
+	}
+	output.Table(headers, rows)
 	return nil
 }
 
 func migrateExport(args []string) error {
+	fs := flag.NewFlagSet("migrate export", flag.ContinueOnError)
+	redisURL := fs.String("redis", "redis://localhost:6379", "Redis connection URL")
+	faktory := faktoryFlags(fs)
+	outputFile := fs.String("output", "jobs.ndjson", "Output NDJSON file")
+	includeFailed := fs.Bool("include-failed", false, "Also export dead-letter jobs (the Sidekiq dead set, BullMQ failed jobs)")
+	noProgress := fs.Bool("no-progress", false, "Don't show a progress bar")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs migrate export <source> [flags]", "Export pending jobs from a source system as OJS NDJSON for \"ojs migrate import\".\nSupported sources: sidekiq, bullmq, celery, faktory, river")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("missing source\n\nSupported sources: sidekiq, bullmq, celery, faktory, river")
 	}
 
 	sourceName := args[0]
-	fs := flag.NewFlagSet("migrate export", flag.ContinueOnError)
-	redisURL := fs.String("redis", "redis://localhost:6379", "Redis connection URL")
-	outputFile := fs.String("output", "jobs.ndjson", "Output NDJSON file")
 	if err := fs.Parse(args[1:]); err != nil {
 		return fmt.Errorf("parse flags: %w", err)
 	}
 
-	src, err := newSource(sourceName, *redisURL)
+	src, err := openSource(sourceName, *redisURL, *faktory)
 	if err != nil {
 		return err
 	}
@@ -137,6 +223,17 @@ func migrateExport(args []string) error {
 	if err != nil {
 		return fmt.Errorf("export failed: %w", err)
 	}
+	if *includeFailed {
+		fe, ok := src.(migrate.FailedJobExporter)
+		if !ok {
+			return fmt.Errorf("--include-failed is not supported for source %s", sourceName)
+		}
+		failed, err := fe.ExportFailed()
+		if err != nil {
+			return fmt.Errorf("export failed jobs: %w", err)
+		}
+		jobs = append(jobs, failed...)
+	}
 
 	f, err := os.Create(*outputFile)
 	if err != nil {
@@ -144,12 +241,15 @@ func migrateExport(args []string) error {
 	}
 	defer f.Close()
 
+	progress := newMigrateProgress("Exporting", len(jobs), *noProgress)
 	enc := json.NewEncoder(f)
-	for _, job := range jobs {
+	for i, job := range jobs {
 		if err := enc.Encode(job); err != nil {
 			return fmt.Errorf("write job: %w", err)
 		}
+		progress.Update(i + 1)
 	}
+	progress.Done()
 
 	output.Success("Exported %d jobs to %s", len(jobs), *outputFile)
 	return nil
@@ -159,6 +259,15 @@ func migrateImport(c *client.Client, args []string) error {
 	fs := flag.NewFlagSet("migrate import", flag.ContinueOnError)
 	file := fs.String("file", "", "NDJSON file to import (required)")
 	dryRun := fs.Bool("dry-run", false, "Validate and count jobs without actually importing")
+	var mapQueue, mapType stringList
+	fs.Var(&mapQueue, "map-queue", "Rename a queue as old=new; a trailing * matches a prefix (repeatable)")
+	fs.Var(&mapType, "map-type", "Rename a job type as old=new; a trailing * matches a prefix (repeatable)")
+	noProgress := fs.Bool("no-progress", false, "Don't show a progress bar")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs migrate import --file <file> [flags]", "Import jobs exported from another system into OJS.")
+		return nil
+	}
 	if err := fs.Parse(args); err != nil {
 		return fmt.Errorf("parse flags: %w", err)
 	}
@@ -167,6 +276,22 @@ func migrateImport(c *client.Client, args []string) error {
 		return fmt.Errorf("--file is required\n\nUsage: ojs migrate import --file <file> [--dry-run]")
 	}
 
+	var rewrites migrate.Rewrites
+	for _, spec := range mapQueue {
+		rule, err := migrate.ParseRewriteRule(spec)
+		if err != nil {
+			return fmt.Errorf("--map-queue: %w", err)
+		}
+		rewrites.Queue = append(rewrites.Queue, rule)
+	}
+	for _, spec := range mapType {
+		rule, err := migrate.ParseRewriteRule(spec)
+		if err != nil {
+			return fmt.Errorf("--map-type: %w", err)
+		}
+		rewrites.Type = append(rewrites.Type, rule)
+	}
+
 	if *dryRun {
 		vr, err := migrate.ValidateFile(*file)
 		if err != nil {
@@ -192,15 +317,19 @@ func migrateImport(c *client.Client, args []string) error {
 		return nil
 	}
 
-	result, err := migrate.ImportFile(c, *file, func(imported, total int) {
-		fmt.Fprintf(os.Stderr, "\r  Imported %d/%d jobs...", imported, total)
+	total, err := migrate.CountJobs(*file)
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+	progress := newMigrateProgress("Importing", total, *noProgress)
+	result, err := migrate.ImportFile(c, *file, rewrites, func(imported, processed int) {
+		progress.Update(processed)
 	})
+	progress.Done()
 	if err != nil {
 		return fmt.Errorf("import failed: %w", err)
 	}
 
-	fmt.Fprintln(os.Stderr)
-
 	if output.Format == "json" {
 		return output.JSON(result)
 	}
@@ -210,7 +339,47 @@ func migrateImport(c *client.Client, args []string) error {
 	return nil
 }
 
-func newSource(name, redisURL string) (migrate.Source, error) {
+// stdoutIsTerminal reports whether stdout is attached to an interactive terminal.
+var stdoutIsTerminal = func() bool {
+	fi, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// newMigrateProgress reports export/import progress on stderr: a live bar
+// when stdout is a terminal, periodic plain lines otherwise. It returns nil,
+// which discards updates, with --no-progress or --quiet.
+func newMigrateProgress(label string, total int, disabled bool) *output.Progress {
+	if disabled || output.Quiet {
+		return nil
+	}
+	return output.NewProgress(os.Stderr, label, total, !stdoutIsTerminal())
+}
+
+// faktoryOptions holds the connection settings only the Faktory source uses.
+type faktoryOptions struct {
+	url      string
+	password string
+	useTLS   bool
+}
+
+// faktoryFlags registers --faktory-url, --faktory-password and --faktory-tls
+// on fs. The password falls back to $FAKTORY_PASSWORD, as Faktory's own
+// clients do.
+func faktoryFlags(fs *flag.FlagSet) *faktoryOptions {
+	opts := &faktoryOptions{}
+	fs.StringVar(&opts.url, "faktory-url", "http://localhost:7420", "Faktory web UI URL")
+	fs.StringVar(&opts.password, "faktory-password", "", "Faktory server password (default: $FAKTORY_PASSWORD)")
+	fs.BoolVar(&opts.useTLS, "faktory-tls", false, "Connect to Faktory over TLS")
+	return opts
+}
+
+// openSource opens a migration source; tests replace it with a fake.
+var openSource = newSource
+
+func newSource(name, redisURL string, faktory faktoryOptions) (migrate.Source, error) {
 	switch name {
 	case "sidekiq":
 		return migrate.NewSidekiqSource(redisURL)
@@ -219,7 +388,11 @@ func newSource(name, redisURL string) (migrate.Source, error) {
 	case "celery":
 		return migrate.NewCelerySource(redisURL)
 	case "faktory":
-		return migrate.NewFaktorySource(redisURL, "")
+		password := faktory.password
+		if password == "" {
+			password = os.Getenv("FAKTORY_PASSWORD")
+		}
+		return migrate.NewFaktorySource(faktory.url, password, faktory.useTLS)
 	case "river":
 		return migrate.NewRiverSource(redisURL)
 	default:
@@ -230,6 +403,12 @@ func newSource(name, redisURL string) (migrate.Source, error) {
 func migrateValidate(args []string) error {
 	fs := flag.NewFlagSet("migrate validate", flag.ContinueOnError)
 	file := fs.String("file", "", "NDJSON file to validate (required)")
+	schema := fs.String("schema", "", "Codegen manifest (.yaml or .json) to check job types and args against")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs migrate validate --file <file> [--schema <manifest>]", "Check an export file before importing it. With --schema, each job's type\nand args are also checked against the manifest used by \"ojs codegen\".")
+		return nil
+	}
 	if err := fs.Parse(args); err != nil {
 		return fmt.Errorf("parse flags: %w", err)
 	}
@@ -238,7 +417,17 @@ func migrateValidate(args []string) error {
 		return fmt.Errorf("--file is required\n\nUsage: ojs migrate validate --file <file>")
 	}
 
-	result, err := migrate.ValidateFile(*file)
+	var result *migrate.ValidationResult
+	var err error
+	if *schema != "" {
+		manifest, merr := codegen.LoadManifest(*schema)
+		if merr != nil {
+			return merr
+		}
+		result, err = migrate.ValidateFileWithManifest(*file, manifest)
+	} else {
+		result, err = migrate.ValidateFile(*file)
+	}
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/migrate/livemigrate"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// backendProgressInterval is how often the live view polls migration stats.
+var backendProgressInterval = 100 * time.Millisecond
+
+// migrateBackend copies jobs from one OJS server to another with
+// livemigrate: export from the source, import into the target, verify.
+func migrateBackend(args []string) error {
+	fs := flag.NewFlagSet("migrate backend", flag.ContinueOnError)
+	sourceURL := fs.String("source-url", "", "OJS server to copy jobs from (required)")
+	targetURL := fs.String("target-url", "", "OJS server to copy jobs to (required)")
+	sourceKey := fs.String("source-key", "", "API key for the source server")
+	targetKey := fs.String("target-key", "", "API key for the target server")
+	batchSize := fs.Int("batch-size", 100, "Jobs per import batch")
+	verifySample := fs.Int("verify-sample", 100, "Imported jobs to fetch back from the target and compare")
+	checkpointFile := fs.String("checkpoint", "", "Save import progress to this file and resume from it when rerun")
+	dryRun := fs.Bool("dry-run", false, "Export and count jobs without importing them")
+	noProgress := fs.Bool("no-progress", false, "Don't show live progress")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs migrate backend --source-url <url> --target-url <url> [flags]", "Copy every job from one OJS server to another, then fetch a random sample of\nthe imported jobs back from the target to confirm their type and args.\nExits non-zero if the migration fails or any job could not be imported or verified.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *sourceURL == "" || *targetURL == "" {
+		return fmt.Errorf("--source-url and --target-url are required\n\nUsage: ojs migrate backend --source-url <url> --target-url <url> [--batch-size <n>] [--checkpoint <file>] [--dry-run]")
+	}
+	if *batchSize <= 0 || *verifySample <= 0 {
+		return fmt.Errorf("--batch-size and --verify-sample must be positive")
+	}
+
+	m := livemigrate.New(livemigrate.Config{
+		SourceURL:      *sourceURL,
+		TargetURL:      *targetURL,
+		SourceKey:      *sourceKey,
+		TargetKey:      *targetKey,
+		BatchSize:      *batchSize,
+		DryRun:         *dryRun,
+		VerifySample:   *verifySample,
+		CheckpointFile: *checkpointFile,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx) }()
+	runErr := followBackendMigration(m, done, *noProgress)
+
+	stats := m.GetStats()
+	if output.Format == "json" {
+		if err := output.JSON(map[string]any{"dry_run": *dryRun, "stats": stats}); err != nil {
+			return err
+		}
+	} else {
+		output.Table([]string{"PHASE", "EXPORTED", "IMPORTED", "VERIFIED", "ERRORS"}, [][]string{{
+			string(stats.Phase),
+			fmt.Sprintf("%d", stats.Exported),
+			fmt.Sprintf("%d", stats.Imported),
+			fmt.Sprintf("%d", stats.Verified),
+			fmt.Sprintf("%d", stats.Errors),
+		}})
+	}
+
+	if runErr != nil {
+		if *checkpointFile != "" && stats.Imported > 0 {
+			return fmt.Errorf("migration failed: %w\n\nProgress is saved in %s; rerun the same command to resume", runErr, *checkpointFile)
+		}
+		return fmt.Errorf("migration failed: %w", runErr)
+	}
+	if stats.Errors > 0 {
+		return fmt.Errorf("%d of %d job(s) could not be migrated or verified", stats.Errors, stats.Exported)
+	}
+	if output.Format != "json" {
+		if *dryRun {
+			output.Success("Dry run: %d job(s) would be migrated", stats.Imported)
+		} else {
+			output.Success("Migrated %d job(s) from %s to %s", stats.Imported, *sourceURL, *targetURL)
+		}
+	}
+	return nil
+}
+
+// followBackendMigration shows the migration's phase on stderr and an
+// import progress bar once the export size is known, until Run returns.
+func followBackendMigration(m *livemigrate.Migration, done <-chan error, disabled bool) error {
+	ticker := time.NewTicker(backendProgressInterval)
+	defer ticker.Stop()
+
+	quiet := disabled || output.Quiet
+	phase := livemigrate.PhaseIdle
+	var bar *output.Progress
+	for {
+		var err error
+		finished := false
+		select {
+		case err = <-done:
+			finished = true
+		case <-ticker.C:
+		}
+
+		stats := m.GetStats()
+		bar.Update(int(stats.Imported + stats.Errors))
+		if stats.Phase != phase {
+			bar.Done()
+			bar = nil
+			phase = stats.Phase
+			switch {
+			case quiet || finished:
+			case phase == livemigrate.PhaseExporting:
+				fmt.Fprintln(os.Stderr, "Exporting jobs from source...")
+			case phase == livemigrate.PhaseImporting:
+				bar = newMigrateProgress("Importing", int(stats.Exported), false)
+			case phase == livemigrate.PhaseVerifying:
+				fmt.Fprintln(os.Stderr, "Verifying target...")
+			}
+		}
+		if finished {
+			bar.Done()
+			return err
+		}
+	}
+}
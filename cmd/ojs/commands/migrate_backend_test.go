@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func backendTestServers(t *testing.T, failType string) (source, target *httptest.Server, imported *atomic.Int32) {
+	t.Helper()
+	orig := backendProgressInterval
+	backendProgressInterval = time.Millisecond
+	t.Cleanup(func() { backendProgressInterval = orig })
+
+	source = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/admin/jobs" {
+			t.Errorf("unexpected source path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": []map[string]any{
+			{"id": "j1", "type": "email.send", "queue": "default", "args": []any{"a@example.com"}},
+			{"id": "j2", "type": "image.resize", "queue": "media", "args": []any{100}},
+			{"id": "j3", "type": "report.build", "queue": "default", "args": []any{}},
+		}})
+	}))
+	t.Cleanup(source.Close)
+
+	imported = &atomic.Int32{}
+	var mu sync.Mutex
+	created := map[string]json.RawMessage{}
+	target = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/ojs/v1/health":
+			json.NewEncoder(w).Encode(map[string]any{"status": "ok"})
+		case r.Method == http.MethodGet:
+			mu.Lock()
+			job, ok := created[strings.TrimPrefix(r.URL.Path, "/ojs/v1/jobs/")]
+			mu.Unlock()
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(job)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			var job map[string]any
+			json.Unmarshal(body, &job)
+			if job["type"] == failType {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				return
+			}
+			id := fmt.Sprintf("new-%d", imported.Add(1))
+			mu.Lock()
+			created[id] = body
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]any{"id": id})
+		}
+	}))
+	t.Cleanup(target.Close)
+	return source, target, imported
+}
+
+func TestMigrateBackend(t *testing.T) {
+	source, target, imported := backendTestServers(t, "")
+	out := captureStdout(t, func() {
+		if err := Migrate(nil, []string{"backend", "--source-url", source.URL, "--target-url", target.URL, "--no-progress"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	var resp struct {
+		Stats map[string]any `json:"stats"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("expected JSON, got %q", out)
+	}
+	if imported.Load() != 3 {
+		t.Errorf("target received %d jobs, want 3", imported.Load())
+	}
+	s := resp.Stats
+	if s["phase"] != "complete" || s["exported"] != float64(3) || s["imported"] != float64(3) || s["verified"] != float64(3) || s["errors"] != float64(0) {
+		t.Errorf("stats = %v", s)
+	}
+}
+
+func TestMigrateBackend_DryRun(t *testing.T) {
+	source, target, imported := backendTestServers(t, "")
+	captureStdout(t, func() {
+		if err := Migrate(nil, []string{"backend", "--source-url", source.URL, "--target-url", target.URL, "--dry-run"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if imported.Load() != 0 {
+		t.Errorf("dry run imported %d jobs", imported.Load())
+	}
+}
+
+func TestMigrateBackend_ImportErrors(t *testing.T) {
+	source, target, _ := backendTestServers(t, "image.resize")
+	var err error
+	captureStdout(t, func() {
+		err = Migrate(nil, []string{"backend", "--source-url", source.URL, "--target-url", target.URL, "--no-progress"})
+	})
+	if err == nil || err.Error() != "1 of 3 job(s) could not be migrated or verified" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestMigrateBackend_MissingURLs(t *testing.T) {
+	if err := Migrate(nil, []string{"backend", "--source-url", "http://a"}); err == nil {
+		t.Error("expected error without --target-url")
+	}
+}
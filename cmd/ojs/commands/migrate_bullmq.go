@@ -25,6 +25,17 @@ type bullmqJobOpts struct {
 	Attempts int             `json:"attempts"`
 	Delay    int64           `json:"delay"`
 	Backoff  *bullmqBackoff  `json:"backoff,omitempty"`
+	Repeat   *bullmqRepeat   `json:"repeat,omitempty"`
+}
+
+// bullmqRepeat holds a repeatable job's schedule. Pattern is the current
+// name for the cron expression; Cron is the pre-v2 spelling.
+type bullmqRepeat struct {
+	Pattern string `json:"pattern"`
+	Cron    string `json:"cron"`
+	Every   int64  `json:"every"`
+	TZ      string `json:"tz"`
+	Limit   int    `json:"limit"`
 }
 
 type bullmqBackoff struct {
@@ -33,6 +44,11 @@ type bullmqBackoff struct {
 }
 
 func migrateBullMQ(args []string, dryRun bool, outputFile string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs migrate bullmq <config-file> [--output <file>] [--dry-run]", "Convert a BullMQ configuration to OJS job and queue definitions.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("missing config file\n\nUsage: ojs migrate bullmq <config-file> [--output <file>] [--dry-run]")
 	}
@@ -106,6 +122,25 @@ func convertBullMQConfig(data []byte) (*ojsMigrateOutput, error) {
 					fmt.Sprintf("%s: static delay (%dms) converted to scheduled_at at enqueue time", j.Name, j.Opts.Delay))
 			}
 
+			if r := j.Opts.Repeat; r != nil {
+				job.Cron = r.Pattern
+				if job.Cron == "" {
+					job.Cron = r.Cron
+				}
+				if job.Cron == "" && r.Every > 0 {
+					result.Warnings = append(result.Warnings,
+						fmt.Sprintf("%s: repeat.every (%dms) is an interval; OJS cron needs a cron expression, convert it by hand", j.Name, r.Every))
+				}
+				if r.TZ != "" && job.Cron != "" {
+					result.Warnings = append(result.Warnings,
+						fmt.Sprintf("%s: repeat.tz %q is not carried over; set the timezone on the OJS cron schedule", j.Name, r.TZ))
+				}
+				if r.Limit > 0 {
+					result.Warnings = append(result.Warnings,
+						fmt.Sprintf("%s: repeat.limit (%d runs) has no OJS cron equivalent", j.Name, r.Limit))
+				}
+			}
+
 			result.Jobs = append(result.Jobs, job)
 		}
 	}
@@ -33,6 +33,11 @@ type celeryBeatSchedule struct {
 }
 
 func migrateCelery(args []string, dryRun bool, outputFile string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs migrate celery <config-file> [--output <file>] [--dry-run]", "Convert a Celery configuration to OJS job and queue definitions.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("missing config file\n\nUsage: ojs migrate celery <config-file> [--output <file>] [--dry-run]")
 	}
@@ -123,6 +128,11 @@ func convertCeleryConfig(data []byte) (*ojsMigrateOutput, error) {
 
 // migrateDetect auto-detects the framework in a directory and shows a migration plan.
 func migrateDetect(args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs migrate detect <directory>", "Detect which job system a project uses.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("missing directory\n\nUsage: ojs migrate detect <directory>")
 	}
@@ -251,6 +261,11 @@ func detectFrameworks(dir string) []frameworkDetect {
 
 // migrateValidateConfig validates an OJS config file generated by migrate commands.
 func migrateValidateConfig(args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs migrate validate-config <ojs-config.json>", "Check a generated OJS configuration file.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("missing OJS config file\n\nUsage: ojs migrate validate-config <ojs-config.json>")
 	}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -46,6 +48,56 @@ func TestConvertSidekiqConfig(t *testing.T) {
 	}
 }
 
+func TestConvertSidekiqConfig_Schedule(t *testing.T) {
+	data := []byte(`:queues:
+  - default
+workers:
+  - class: ReportGenerator
+    queue: reports
+    retry: 3
+:schedule:
+  nightly_report:
+    cron: "0 0 2 * * *"
+    class: ReportGenerator
+  cleanup:
+    cron: "*/10 * * * *"
+    class: Maintenance::Cleanup
+    queue: low
+    args: ["tmp"]
+  heartbeat:
+    every: 30s
+    class: Heartbeat
+  paused:
+    cron: "0 * * * *"
+    class: Paused
+    enabled: false
+`)
+
+	result, err := convertSidekiqConfig(data)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+
+	want := []ojsJobDefinition{
+		{Type: "report.generator", Queue: "reports", Options: ojsJobOptions{Queue: "reports", MaxAttempts: 3,
+			Retry: &ojsRetryPolicy{MaxAttempts: 3, Backoff: "exponential"}}, Cron: "0 2 * * *"},
+		{Type: "maintenance.cleanup", Queue: "low", Options: ojsJobOptions{Queue: "low"}, Cron: "*/10 * * * *"},
+	}
+	if !reflect.DeepEqual(result.Jobs, want) {
+		t.Errorf("jobs =\n%+v\nwant\n%+v", result.Jobs, want)
+	}
+
+	wantWarnings := []string{
+		"schedule cleanup: args are not carried over; pass them when registering the OJS cron job",
+		`schedule heartbeat: "every" schedules are not supported, OJS cron needs a cron expression; skipped`,
+		`schedule nightly_report: dropped the seconds field from cron "0 0 2 * * *"`,
+		"schedule paused: disabled; skipped",
+	}
+	if !reflect.DeepEqual(result.Warnings, wantWarnings) {
+		t.Errorf("warnings =\n%q\nwant\n%q", result.Warnings, wantWarnings)
+	}
+}
+
 func TestConvertSidekiqConfig_RetryTrue(t *testing.T) {
 	data, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "sidekiq_config.yml"))
 	if err != nil {
@@ -149,6 +201,29 @@ func TestConvertBullMQConfig_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestConvertBullMQConfig_Repeat(t *testing.T) {
+	data := []byte(`{"queues":[{"name":"reports","jobs":[
+		{"name":"report.daily","opts":{"repeat":{"pattern":"0 6 * * *"}}},
+		{"name":"report.legacy","opts":{"repeat":{"cron":"*/15 * * * *"}}},
+		{"name":"metrics.flush","opts":{"repeat":{"every":30000}}}
+	]}]}`)
+
+	result, err := convertBullMQConfig(data)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+
+	wantCron := map[string]string{"report.daily": "0 6 * * *", "report.legacy": "*/15 * * * *", "metrics.flush": ""}
+	for _, job := range result.Jobs {
+		if job.Cron != wantCron[job.Type] {
+			t.Errorf("%s cron = %q, want %q", job.Type, job.Cron, wantCron[job.Type])
+		}
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "metrics.flush: repeat.every (30000ms)") {
+		t.Errorf("warnings = %q, want one repeat.every warning for metrics.flush", result.Warnings)
+	}
+}
+
 func TestConvertCeleryConfig(t *testing.T) {
 	data, err := os.ReadFile(filepath.Join("..", "..", "..", "testdata", "celery_config.json"))
 	if err != nil {
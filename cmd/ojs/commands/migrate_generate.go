@@ -1,20 +1,25 @@
 package commands
 
 import (
+	"bufio"
 	"encoding/json"
-	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // MigrateGenerate generates OJS-compatible job definitions from source system analysis
 func MigrateGenerate(args []string) error {
-	fs := flag.NewFlagSet("migrate generate", flag.ExitOnError)
+	fs := newFlagSet("migrate generate")
 	source := fs.String("source", "", "Source system (sidekiq, bullmq, celery, faktory, river)")
 	outputDir := fs.String("output", "./ojs-migration", "Output directory for generated files")
 	format := fs.String("format", "json", "Output format (json, yaml)")
+	interactive := fs.Bool("interactive", false, "Detect the source system in the current directory and confirm it")
 	fs.Usage = func() {
 		fmt.Print(`Usage: ojs migrate generate [flags]
 
@@ -30,20 +35,48 @@ Flags:
   --source <system>  Source system: sidekiq, bullmq, celery, faktory, river
   --output <dir>     Output directory (default: ./ojs-migration)
   --format <fmt>     Output format: json, yaml (default: json)
+  --interactive      Without --source, detect the framework in the current
+                     directory and prompt to confirm it (requires a terminal)
 
 Examples:
   ojs migrate generate --source sidekiq
+  ojs migrate generate --interactive
   ojs migrate generate --source bullmq --output ./migration-plan
 `)
 	}
+	if helpRequested(args) {
+		fs.Usage()
+		return nil
+	}
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
 
+	if *source == "" && *interactive {
+		if !stdinIsTerminal() {
+			return fmt.Errorf("--interactive requires a terminal; pass --source instead")
+		}
+		detections := detectFrameworks(".")
+		if len(detections) == 0 {
+			return fmt.Errorf("no supported job framework detected in the current directory; pass --source")
+		}
+		fmt.Fprint(os.Stderr, sourcePrompt(detections))
+		answer, err := bufio.NewReader(confirmInput).ReadString('\n')
+		if err != nil && answer == "" {
+			return fmt.Errorf("aborted: no answer received")
+		}
+		if *source, err = chooseDetectedSource(detections, answer); err != nil {
+			return err
+		}
+	}
+
 	if *source == "" {
 		fs.Usage()
 		return fmt.Errorf("--source is required")
 	}
+	if *format != "json" && *format != "yaml" {
+		return fmt.Errorf("unsupported format: %s (supported: json, yaml)", *format)
+	}
 
 	templates := getMigrationTemplates(*source)
 	if templates == nil {
@@ -55,30 +88,30 @@ Examples:
 	}
 
 	// Write migration plan
-	plan := generateMigrationPlan(*source, templates)
+	plan := generateMigrationPlan(*source, templates, *format)
 	planPath := filepath.Join(*outputDir, "migration-plan."+*format)
-	if err := writeJSON(planPath, plan); err != nil {
+	if err := writePlanFile(planPath, *format, plan); err != nil {
 		return fmt.Errorf("writing migration plan: %w", err)
 	}
 	fmt.Printf("✅ Migration plan: %s\n", planPath)
 
 	// Write job type mappings
 	mappingPath := filepath.Join(*outputDir, "job-mappings."+*format)
-	if err := writeJSON(mappingPath, templates.JobMappings); err != nil {
+	if err := writePlanFile(mappingPath, *format, templates.JobMappings); err != nil {
 		return fmt.Errorf("writing job mappings: %w", err)
 	}
 	fmt.Printf("✅ Job mappings:   %s\n", mappingPath)
 
 	// Write queue config
 	queuePath := filepath.Join(*outputDir, "queue-config."+*format)
-	if err := writeJSON(queuePath, templates.QueueConfig); err != nil {
+	if err := writePlanFile(queuePath, *format, templates.QueueConfig); err != nil {
 		return fmt.Errorf("writing queue config: %w", err)
 	}
 	fmt.Printf("✅ Queue config:   %s\n", queuePath)
 
 	// Write retry policy mapping
 	retryPath := filepath.Join(*outputDir, "retry-policies."+*format)
-	if err := writeJSON(retryPath, templates.RetryPolicies); err != nil {
+	if err := writePlanFile(retryPath, *format, templates.RetryPolicies); err != nil {
 		return fmt.Errorf("writing retry policies: %w", err)
 	}
 	fmt.Printf("✅ Retry policies: %s\n", retryPath)
@@ -90,50 +123,100 @@ Examples:
 	return nil
 }
 
+// sourcePrompt lists the detected frameworks and asks the user to confirm
+// one: a y/N question for a single detection, a numbered choice otherwise.
+func sourcePrompt(detections []frameworkDetect) string {
+	var b strings.Builder
+	b.WriteString("Detected job frameworks:\n")
+	for i, d := range detections {
+		fmt.Fprintf(&b, "  %d) %s (%s confidence", i+1, d.Name, d.Confidence)
+		if d.ConfigFile != "" {
+			fmt.Fprintf(&b, ", %s", d.ConfigFile)
+		}
+		b.WriteString(")\n")
+	}
+	if len(detections) == 1 {
+		fmt.Fprintf(&b, "Generate a migration plan for %s? [Y/n]: ", detections[0].Name)
+	} else {
+		fmt.Fprintf(&b, "Generate a migration plan for which framework? [1-%d]: ", len(detections))
+	}
+	return b.String()
+}
+
+// chooseDetectedSource maps the user's answer to sourcePrompt onto a source
+// name. A single detection accepts y/n (empty means yes); any prompt also
+// accepts a list number or a detected framework name.
+func chooseDetectedSource(detections []frameworkDetect, answer string) (string, error) {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if len(detections) == 1 {
+		switch answer {
+		case "", "y", "yes":
+			return detections[0].Name, nil
+		case "n", "no":
+			return "", fmt.Errorf("aborted")
+		}
+	}
+	if n, err := strconv.Atoi(answer); err == nil {
+		if n < 1 || n > len(detections) {
+			return "", fmt.Errorf("invalid choice %d: pick 1-%d", n, len(detections))
+		}
+		return detections[n-1].Name, nil
+	}
+	for _, d := range detections {
+		if d.Name == answer {
+			return d.Name, nil
+		}
+	}
+	if answer == "" {
+		return "", fmt.Errorf("aborted: no framework chosen")
+	}
+	return "", fmt.Errorf("invalid choice %q", answer)
+}
+
 type migrationTemplates struct {
-	Source        string                `json:"source"`
-	JobMappings   []jobMapping          `json:"job_mappings"`
-	QueueConfig   []queueConfig         `json:"queue_config"`
-	RetryPolicies []retryPolicyMapping  `json:"retry_policies"`
+	Source        string                `yaml:"source" json:"source"`
+	JobMappings   []jobMapping          `yaml:"job_mappings" json:"job_mappings"`
+	QueueConfig   []queueConfig         `yaml:"queue_config" json:"queue_config"`
+	RetryPolicies []retryPolicyMapping  `yaml:"retry_policies" json:"retry_policies"`
 }
 
 type jobMapping struct {
-	SourceType  string            `json:"source_type"`
-	OJSType     string            `json:"ojs_type"`
-	SourceQueue string            `json:"source_queue"`
-	OJSQueue    string            `json:"ojs_queue"`
-	Notes       string            `json:"notes,omitempty"`
-	ArgsMapping map[string]string `json:"args_mapping,omitempty"`
+	SourceType  string            `yaml:"source_type" json:"source_type"`
+	OJSType     string            `yaml:"ojs_type" json:"ojs_type"`
+	SourceQueue string            `yaml:"source_queue" json:"source_queue"`
+	OJSQueue    string            `yaml:"ojs_queue" json:"ojs_queue"`
+	Notes       string            `yaml:"notes,omitempty" json:"notes,omitempty"`
+	ArgsMapping map[string]string `yaml:"args_mapping,omitempty" json:"args_mapping,omitempty"`
 }
 
 type queueConfig struct {
-	Name        string `json:"name"`
-	Priority    int    `json:"priority"`
-	Concurrency int    `json:"concurrency"`
-	Notes       string `json:"notes,omitempty"`
+	Name        string `yaml:"name" json:"name"`
+	Priority    int    `yaml:"priority" json:"priority"`
+	Concurrency int    `yaml:"concurrency" json:"concurrency"`
+	Notes       string `yaml:"notes,omitempty" json:"notes,omitempty"`
 }
 
 type retryPolicyMapping struct {
-	SourcePolicy string `json:"source_policy"`
-	OJSPolicy    string `json:"ojs_policy"`
-	MaxRetries   int    `json:"max_retries"`
-	Backoff      string `json:"backoff"`
-	Notes        string `json:"notes,omitempty"`
+	SourcePolicy string `yaml:"source_policy" json:"source_policy"`
+	OJSPolicy    string `yaml:"ojs_policy" json:"ojs_policy"`
+	MaxRetries   int    `yaml:"max_retries" json:"max_retries"`
+	Backoff      string `yaml:"backoff" json:"backoff"`
+	Notes        string `yaml:"notes,omitempty" json:"notes,omitempty"`
 }
 
 type migrationPlan struct {
-	Source      string          `json:"source"`
-	Target      string          `json:"target"`
-	GeneratedAt string          `json:"generated_at"`
-	Steps       []migrationStep `json:"steps"`
+	Source      string          `yaml:"source" json:"source"`
+	Target      string          `yaml:"target" json:"target"`
+	GeneratedAt string          `yaml:"generated_at" json:"generated_at"`
+	Steps       []migrationStep `yaml:"steps" json:"steps"`
 }
 
 type migrationStep struct {
-	Order       int    `json:"order"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Command     string `json:"command,omitempty"`
-	Duration    string `json:"estimated_duration"`
+	Order       int    `yaml:"order" json:"order"`
+	Title       string `yaml:"title" json:"title"`
+	Description string `yaml:"description" json:"description"`
+	Command     string `yaml:"command,omitempty" json:"command,omitempty"`
+	Duration    string `yaml:"estimated_duration" json:"estimated_duration"`
 }
 
 func getMigrationTemplates(source string) *migrationTemplates {
@@ -218,7 +301,7 @@ func getMigrationTemplates(source string) *migrationTemplates {
 	return nil
 }
 
-func generateMigrationPlan(source string, templates *migrationTemplates) migrationPlan {
+func generateMigrationPlan(source string, templates *migrationTemplates, format string) migrationPlan {
 	return migrationPlan{
 		Source:      source,
 		Target:      "ojs",
@@ -226,8 +309,8 @@ func generateMigrationPlan(source string, templates *migrationTemplates) migrati
 		Steps: []migrationStep{
 			{Order: 1, Title: "Set up OJS backend", Description: "Deploy an OJS backend (Redis or PostgreSQL recommended for migration from " + source + ")", Command: "docker compose -f docker-compose.quickstart.yml up -d", Duration: "30 minutes"},
 			{Order: 2, Title: "Install OJS SDK", Description: "Add the OJS SDK to your application", Duration: "15 minutes"},
-			{Order: 3, Title: "Map job types", Description: "Review job-mappings.json and adjust OJS type names to match your domain", Duration: "1-2 hours"},
-			{Order: 4, Title: "Configure queues", Description: "Create OJS queues based on queue-config.json", Duration: "30 minutes"},
+			{Order: 3, Title: "Map job types", Description: "Review job-mappings." + format + " and adjust OJS type names to match your domain", Duration: "1-2 hours"},
+			{Order: 4, Title: "Configure queues", Description: "Create OJS queues based on queue-config." + format, Duration: "30 minutes"},
 			{Order: 5, Title: "Implement workers", Description: "Create OJS worker handlers for each job type, reusing existing business logic", Duration: "1-3 days"},
 			{Order: 6, Title: "Dual-write phase", Description: "Enqueue jobs to both " + source + " and OJS simultaneously. Process only from " + source + ".", Command: "# Enable dual-write in your application code", Duration: "1 day"},
 			{Order: 7, Title: "Shadow processing", Description: "Start OJS workers alongside " + source + " workers. Compare results without affecting production.", Duration: "1-2 weeks"},
@@ -238,12 +321,21 @@ func generateMigrationPlan(source string, templates *migrationTemplates) migrati
 	}
 }
 
-func writeJSON(path string, v interface{}) error {
+// writePlanFile writes v to path as indented JSON or, for format "yaml", YAML.
+func writePlanFile(path, format string, v interface{}) error {
 	f, err := os.Create(path)
 	if err != nil {
 		return err
 	}
 	defer f.Close()
+	if format == "yaml" {
+		enc := yaml.NewEncoder(f)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
 	enc := json.NewEncoder(f)
 	enc.SetIndent("", "  ")
 	return enc.Encode(v)
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestMigrateGenerate_YAML(t *testing.T) {
+	dir := t.TempDir()
+	captureStdout(t, func() {
+		if err := MigrateGenerate([]string{"--source", "sidekiq", "--output", dir, "--format", "yaml"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	tests := []struct {
+		file string
+		keys []string
+	}{
+		{"migration-plan.yaml", []string{"source", "target", "generated_at", "steps"}},
+		{"job-mappings.yaml", []string{"source_type", "ojs_type", "source_queue", "ojs_queue"}},
+		{"queue-config.yaml", []string{"name", "priority", "concurrency"}},
+		{"retry-policies.yaml", []string{"source_policy", "ojs_policy", "max_retries", "backoff"}},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join(dir, tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(data)), "{") || strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+			t.Errorf("%s looks like JSON:\n%s", tt.file, data)
+		}
+
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			t.Fatalf("%s is not valid YAML: %v", tt.file, err)
+		}
+		m, ok := doc.(map[string]any)
+		if list, isList := doc.([]any); isList && len(list) > 0 {
+			m, ok = list[0].(map[string]any)
+		}
+		if !ok {
+			t.Fatalf("%s: unexpected document %T", tt.file, doc)
+		}
+		for _, key := range tt.keys {
+			if _, found := m[key]; !found {
+				t.Errorf("%s: missing key %q", tt.file, key)
+			}
+		}
+	}
+
+	plan, _ := os.ReadFile(filepath.Join(dir, "migration-plan.yaml"))
+	if !strings.Contains(string(plan), "job-mappings.yaml") {
+		t.Error("plan steps should refer to the .yaml files")
+	}
+}
+
+func TestMigrateGenerate_UnsupportedFormat(t *testing.T) {
+	err := MigrateGenerate([]string{"--source", "sidekiq", "--output", t.TempDir(), "--format", "toml"})
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Errorf("err = %v, want unsupported format", err)
+	}
+}
+
+func TestChooseDetectedSource(t *testing.T) {
+	one := []frameworkDetect{{Name: "sidekiq", Confidence: "high", ConfigFile: "./sidekiq.yml"}}
+	two := []frameworkDetect{{Name: "sidekiq", Confidence: "medium"}, {Name: "bullmq", Confidence: "high"}}
+
+	tests := []struct {
+		detections []frameworkDetect
+		answer     string
+		want       string
+		wantErr    bool
+	}{
+		{one, "\n", "sidekiq", false},
+		{one, "Y\n", "sidekiq", false},
+		{one, "n\n", "", true},
+		{two, "2\n", "bullmq", false},
+		{two, "sidekiq", "sidekiq", false},
+		{two, "3", "", true},
+		{two, "", "", true},
+		{two, "celery", "", true},
+	}
+	for _, tt := range tests {
+		got, err := chooseDetectedSource(tt.detections, tt.answer)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("%d detections, answer %q: got %q, err %v", len(tt.detections), tt.answer, got, err)
+		}
+	}
+}
+
+func TestSourcePrompt(t *testing.T) {
+	prompt := sourcePrompt([]frameworkDetect{{Name: "sidekiq", Confidence: "high", ConfigFile: "./sidekiq.yml"}})
+	for _, want := range []string{"1) sidekiq (high confidence, ./sidekiq.yml)", "[Y/n]"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt missing %q:\n%s", want, prompt)
+		}
+	}
+	prompt = sourcePrompt([]frameworkDetect{{Name: "sidekiq"}, {Name: "bullmq"}})
+	if !strings.Contains(prompt, "[1-2]") {
+		t.Errorf("prompt should offer a numbered choice:\n%s", prompt)
+	}
+}
+
+func TestMigrateGenerate_Interactive(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"dependencies":{"bullmq":"^5"}}`), 0644)
+	t.Chdir(dir)
+	out := filepath.Join(t.TempDir(), "plan")
+
+	withConfirm(t, false, "y\n")
+	err := MigrateGenerate([]string{"--interactive", "--output", out})
+	if err == nil || !strings.Contains(err.Error(), "--source") {
+		t.Fatalf("non-TTY: err = %v, want a hint to pass --source", err)
+	}
+
+	withConfirm(t, true, "y\n")
+	captureStdout(t, func() {
+		if err := MigrateGenerate([]string{"--interactive", "--output", out}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	data, err := os.ReadFile(filepath.Join(out, "migration-plan.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"source": "bullmq"`) {
+		t.Errorf("plan not generated for the detected source:\n%s", data)
+	}
+}
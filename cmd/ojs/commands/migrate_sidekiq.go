@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -14,6 +16,7 @@ type sidekiqConfig struct {
 	Concurrency int              `yaml:":concurrency"`
 	Queues      []string         `yaml:":queues"`
 	Workers     []sidekiqWorker  `yaml:"workers"`
+	Schedule    map[string]sidekiqSchedule `yaml:":schedule"`
 }
 
 type sidekiqWorker struct {
@@ -23,6 +26,20 @@ type sidekiqWorker struct {
 	Cron  string `yaml:"cron"`
 }
 
+// sidekiqSchedule is a periodic job entry in the :schedule: section used by
+// sidekiq-scheduler and sidekiq-cron, keyed by schedule name.
+type sidekiqSchedule struct {
+	Class    string `yaml:"class"`
+	Queue    string `yaml:"queue"`
+	Cron     string `yaml:"cron"`
+	Every    any    `yaml:"every"`
+	At       any    `yaml:"at"`
+	In       any    `yaml:"in"`
+	Interval any    `yaml:"interval"`
+	Args     any    `yaml:"args"`
+	Enabled  *bool  `yaml:"enabled"`
+}
+
 // ojsJobDefinition is the output format for converted job definitions.
 type ojsJobDefinition struct {
 	Type    string             `json:"type"`
@@ -51,6 +68,11 @@ type ojsMigrateOutput struct {
 }
 
 func migrateSidekiq(args []string, dryRun bool, outputFile string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs migrate sidekiq <config-file> [--output <file>] [--dry-run]", "Convert a Sidekiq configuration to OJS job and queue definitions.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("missing config file\n\nUsage: ojs migrate sidekiq <config-file> [--output <file>] [--dry-run]")
 	}
@@ -126,9 +148,82 @@ func convertSidekiqConfig(data []byte) (*ojsMigrateOutput, error) {
 		result.Jobs = append(result.Jobs, job)
 	}
 
+	convertSidekiqSchedule(cfg.Schedule, result)
+
 	return result, nil
 }
 
+// convertSidekiqSchedule maps :schedule: entries onto OJS cron definitions.
+// An entry for a class already listed under workers sets that job's cron;
+// otherwise a new job definition is added. Schedules that are not cron
+// expressions are skipped with a warning.
+func convertSidekiqSchedule(schedule map[string]sidekiqSchedule, result *ojsMigrateOutput) {
+	names := make([]string, 0, len(schedule))
+	for name := range schedule {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entry := schedule[name]
+		warn := func(format string, args ...any) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("schedule %s: ", name)+fmt.Sprintf(format, args...))
+		}
+
+		if entry.Class == "" {
+			warn("missing class; skipped")
+			continue
+		}
+		if entry.Enabled != nil && !*entry.Enabled {
+			warn("disabled; skipped")
+			continue
+		}
+		if entry.Cron == "" {
+			switch {
+			case entry.Every != nil:
+				warn(`"every" schedules are not supported, OJS cron needs a cron expression; skipped`)
+			case entry.Interval != nil:
+				warn(`"interval" schedules are not supported, OJS cron needs a cron expression; skipped`)
+			case entry.At != nil, entry.In != nil:
+				warn(`one-off "at"/"in" schedules are not supported; skipped`)
+			default:
+				warn("missing cron; skipped")
+			}
+			continue
+		}
+
+		cron := entry.Cron
+		if fields := strings.Fields(cron); len(fields) == 6 {
+			if fields[0] != "0" {
+				warn("cron %q fires at second %s; OJS cron has minute resolution, skipped", cron, fields[0])
+				continue
+			}
+			cron = strings.Join(fields[1:], " ")
+			warn("dropped the seconds field from cron %q", entry.Cron)
+		}
+		if entry.Args != nil {
+			warn("args are not carried over; pass them when registering the OJS cron job")
+		}
+
+		jobType := sidekiqClassToOJSType(entry.Class)
+		if i := slices.IndexFunc(result.Jobs, func(j ojsJobDefinition) bool { return j.Type == jobType && j.Cron == "" }); i >= 0 {
+			result.Jobs[i].Cron = cron
+			continue
+		}
+
+		queue := entry.Queue
+		if queue == "" {
+			queue = "default"
+		}
+		result.Jobs = append(result.Jobs, ojsJobDefinition{
+			Type:    jobType,
+			Queue:   queue,
+			Options: ojsJobOptions{Queue: queue},
+			Cron:    cron,
+		})
+	}
+}
+
 // sidekiqClassToOJSType converts a Ruby class name to an OJS type.
 func sidekiqClassToOJSType(class string) string {
 	s := strings.ReplaceAll(class, "::", ".")
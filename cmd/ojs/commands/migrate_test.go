@@ -3,6 +3,12 @@ package commands
 import (
 	"encoding/base64"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/openjobspec/ojs-cli/internal/migrate"
@@ -354,3 +360,176 @@ func TestSidekiqToOJSConversion(t *testing.T) {
 	}
 }
 
+// fakeSource is a migrate.Source returning a fixed analysis.
+type fakeSource struct{ result *migrate.AnalysisResult }
+
+func (f fakeSource) Analyze() (*migrate.AnalysisResult, error) { return f.result, nil }
+func (f fakeSource) Export() ([]migrate.ExportedJob, error)    { return nil, nil }
+func (f fakeSource) Close() error                              { return nil }
+
+func withFakeSource(t *testing.T, result *migrate.AnalysisResult) {
+	t.Helper()
+	orig := openSource
+	openSource = func(string, string, faktoryOptions) (migrate.Source, error) { return fakeSource{result}, nil }
+	t.Cleanup(func() { openSource = orig })
+}
+
+func TestMigrate_AnalyzeOutputAndCompare(t *testing.T) {
+	before := &migrate.AnalysisResult{Source: "sidekiq", TotalJobs: 4,
+		Queues: []migrate.QueueAnalysis{{Name: "default", PendingJobs: 4, JobTypes: map[string]int{"HardWorker": 4}}}}
+	report := filepath.Join(t.TempDir(), "before.json")
+
+	withFakeSource(t, before)
+	captureStdout(t, func() {
+		if err := Migrate(nil, []string{"analyze", "sidekiq", "--output", report}); err != nil {
+			t.Fatalf("analyze --output: %v", err)
+		}
+	})
+	saved, err := migrate.LoadAnalysis(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.TotalJobs != 4 || len(saved.Queues) != 1 {
+		t.Errorf("saved report = %+v", saved)
+	}
+
+	withFakeSource(t, &migrate.AnalysisResult{Source: "sidekiq", TotalJobs: 1,
+		Queues: []migrate.QueueAnalysis{{Name: "default", PendingJobs: 1, JobTypes: map[string]int{"HardWorker": 1}}}})
+	var diff migrate.AnalysisDiff
+	out := captureStdout(t, func() {
+		if err := Migrate(nil, []string{"analyze", "sidekiq", "--compare", report}); err != nil {
+			t.Fatalf("analyze --compare: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &diff); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if diff.TotalDelta != -3 || len(diff.Queues) != 1 || diff.Queues[0].Status != migrate.QueueChanged {
+		t.Errorf("diff = %+v", diff)
+	}
+
+	if err := Migrate(nil, []string{"analyze", "sidekiq", "--compare", filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Error("expected error for a missing --compare report")
+	}
+}
+
+func TestNewSource_FaktoryPasswordFromEnv(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, got, _ = r.BasicAuth()
+		w.Write([]byte(`{"faktory":{"queues":{}}}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("FAKTORY_PASSWORD", "from-env")
+	for _, tt := range []struct{ flag, want string }{{"", "from-env"}, {"from-flag", "from-flag"}} {
+		src, err := newSource("faktory", "redis://localhost:6379", faktoryOptions{url: srv.URL, password: tt.flag})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := src.Analyze(); err != nil {
+			t.Fatalf("analyze: %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("--faktory-password %q: server saw %q, want %q", tt.flag, got, tt.want)
+		}
+	}
+}
+
+func TestMigrate_ImportRewritesQueueAndType(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jobs.ndjson")
+	os.WriteFile(file, []byte(`{"type":"EmailWorker","queue":"mailers","args":[]}
+{"type":"legacy.report","queue":"reports","args":[]}
+`), 0644)
+
+	var got [][2]string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Type    string `json:"type"`
+			Options struct {
+				Queue string `json:"queue"`
+			} `json:"options"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		got = append(got, [2]string{body.Type, body.Options.Queue})
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"job-1"}`))
+	})
+
+	captureStdout(t, func() {
+		err := Migrate(c, []string{"import", "--file", file,
+			"--map-queue", "mailers=email", "--map-type", "EmailWorker=email.send", "--map-type", "legacy.*=app.*"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := [][2]string{{"email.send", "email"}, {"app.report", "reports"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("imported (type, queue) = %v, want %v", got, want)
+	}
+
+	if err := Migrate(c, []string{"import", "--file", file, "--map-queue", "mailers"}); err == nil {
+		t.Error("expected error for a malformed --map-queue rule")
+	}
+}
+
+func TestParseSidekiqFailedJob(t *testing.T) {
+	raw := `{"class":"EmailWorker","args":[42],"queue":"mailers","jid":"abc","retry":true,` +
+		`"error_class":"Net::ReadTimeout","error_message":"timed out","retry_count":3,"failed_at":1700000000.5}`
+	job, err := migrate.ParseSidekiqFailedJob(raw, "retry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Type != "email.worker" || job.Queue != "mailers" || string(job.Args) != "[42]" {
+		t.Errorf("job = %+v", job)
+	}
+	want := map[string]any{
+		"sidekiq_jid":         "abc",
+		"sidekiq_class":       "EmailWorker",
+		"source_state":        "retry",
+		"sidekiq_error":       "Net::ReadTimeout: timed out",
+		"sidekiq_retry_count": 3,
+		"sidekiq_failed_at":   "2023-11-14T22:13:20Z",
+	}
+	if !reflect.DeepEqual(job.Meta, want) {
+		t.Errorf("meta = %v, want %v", job.Meta, want)
+	}
+}
+
+func TestParseBullMQFailedJob(t *testing.T) {
+	fields := map[string]string{
+		"name":         "send-email",
+		"data":         `{"to":"a@b.c"}`,
+		"opts":         `{"attempts":3}`,
+		"failedReason": "SMTP 550",
+		"attemptsMade": "3",
+		"finishedOn":   "1700000000000",
+	}
+	job, err := migrate.ParseBullMQFailedJob("notifications", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Type != "send-email" || job.Queue != "notifications" || string(job.Args) != `[{"to":"a@b.c"}]` {
+		t.Errorf("job = %+v", job)
+	}
+	want := map[string]any{
+		"bullmq_source":        true,
+		"source_state":         "failed",
+		"bullmq_failed_reason": "SMTP 550",
+		"bullmq_attempts_made": 3,
+		"bullmq_failed_at":     "2023-11-14T22:13:20Z",
+	}
+	if !reflect.DeepEqual(job.Meta, want) {
+		t.Errorf("meta = %v, want %v", job.Meta, want)
+	}
+}
+
+func TestMigrate_ExportIncludeFailedUnsupported(t *testing.T) {
+	withFakeSource(t, &migrate.AnalysisResult{})
+	out := filepath.Join(t.TempDir(), "jobs.ndjson")
+	err := Migrate(nil, []string{"export", "river", "--output", out, "--include-failed"})
+	if err == nil || !strings.Contains(err.Error(), "--include-failed is not supported for source river") {
+		t.Errorf("err = %v", err)
+	}
+}
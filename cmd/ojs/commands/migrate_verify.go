@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/migrate"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// verifyQueue compares one queue's pending jobs in the source system with
+// the jobs waiting in the OJS queue of the same name.
+type verifyQueue struct {
+	Queue  string `json:"queue"`
+	Source int    `json:"source_pending"`
+	OJS    int    `json:"ojs_pending"`
+	Delta  int    `json:"delta"`
+	Status string `json:"status"` // ok, mismatch, missing
+}
+
+type verifyResult struct {
+	Source     string        `json:"source"`
+	Queues     []verifyQueue `json:"queues"`
+	Mismatches int           `json:"mismatches"`
+}
+
+func migrateVerify(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("migrate verify", flag.ContinueOnError)
+	source := fs.String("source", "", "Source system (sidekiq, bullmq, celery, faktory, river)")
+	redisURL := fs.String("redis", "redis://localhost:6379", "Redis connection URL")
+	faktory := faktoryFlags(fs)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs migrate verify --source <system> --redis <url>", "Compare per-queue pending job counts in the source system with OJS queue\nstats after a migration. OJS pending counts are available plus scheduled jobs.\nExits non-zero if any queue differs.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if *source == "" {
+		return fmt.Errorf("--source is required\n\nUsage: ojs migrate verify --source <system> --redis <url>")
+	}
+
+	src, err := openSource(*source, *redisURL, *faktory)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	analysis, err := src.Analyze()
+	if err != nil {
+		return fmt.Errorf("analyze failed: %w", err)
+	}
+
+	ojsPending := make(map[string]int, len(analysis.Queues))
+	for _, q := range analysis.Queues {
+		counts, err := fetchQueueCounts(c, q.Name)
+		if client.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("get stats for queue %s: %w", q.Name, err)
+		}
+		ojsPending[q.Name] = counts.Available + counts.Scheduled
+	}
+
+	result := compareVerifyCounts(analysis, ojsPending)
+
+	if output.Format == "json" {
+		if err := output.JSON(result); err != nil {
+			return err
+		}
+	} else {
+		headers := []string{"QUEUE", "SOURCE", "OJS", "DELTA", "STATUS"}
+		var rows [][]string
+		for _, q := range result.Queues {
+			rows = append(rows, []string{q.Queue, fmt.Sprintf("%d", q.Source), fmt.Sprintf("%d", q.OJS), fmt.Sprintf("%+d", q.Delta), q.Status})
+		}
+		output.Table(headers, rows)
+		if result.Mismatches == 0 {
+			output.Success("All %d queues match %s", len(result.Queues), result.Source)
+		}
+	}
+
+	if result.Mismatches > 0 {
+		return fmt.Errorf("%d queue(s) differ between %s and OJS", result.Mismatches, result.Source)
+	}
+	return nil
+}
+
+// compareVerifyCounts compares the source analysis with OJS pending counts
+// keyed by queue name. Queues absent from ojsPending are reported missing.
+func compareVerifyCounts(analysis *migrate.AnalysisResult, ojsPending map[string]int) *verifyResult {
+	result := &verifyResult{Source: analysis.Source}
+	for _, q := range analysis.Queues {
+		vq := verifyQueue{Queue: q.Name, Source: q.PendingJobs, Status: "ok"}
+		n, ok := ojsPending[q.Name]
+		vq.OJS = n
+		vq.Delta = n - q.PendingJobs
+		switch {
+		case !ok:
+			vq.Status = "missing"
+		case vq.Delta != 0:
+			vq.Status = "mismatch"
+		}
+		if vq.Status != "ok" {
+			result.Mismatches++
+		}
+		result.Queues = append(result.Queues, vq)
+	}
+	sort.Slice(result.Queues, func(i, j int) bool { return result.Queues[i].Queue < result.Queues[j].Queue })
+	return result
+}
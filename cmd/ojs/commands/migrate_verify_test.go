@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openjobspec/ojs-cli/internal/migrate"
+)
+
+func TestMigrateVerify_ReportsMismatch(t *testing.T) {
+	withFakeSource(t, &migrate.AnalysisResult{Source: "sidekiq", TotalJobs: 17, Queues: []migrate.QueueAnalysis{
+		{Name: "default", PendingJobs: 10},
+		{Name: "mailers", PendingJobs: 5},
+		{Name: "legacy", PendingJobs: 2},
+	}})
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ojs/v1/queues/default/stats":
+			w.Write([]byte(`{"queue":"default","stats":{"available":8,"scheduled":2,"completed":40}}`))
+		case "/ojs/v1/queues/mailers/stats":
+			w.Write([]byte(`{"queue":"mailers","stats":{"available":3}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"queue not found"}}`))
+		}
+	})
+
+	var err error
+	out := captureStdout(t, func() {
+		err = Migrate(c, []string{"verify", "--source", "sidekiq"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "2 queue(s) differ") {
+		t.Errorf("err = %v, want 2 queues to differ", err)
+	}
+
+	var result verifyResult
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	want := []verifyQueue{
+		{Queue: "default", Source: 10, OJS: 10, Delta: 0, Status: "ok"},
+		{Queue: "legacy", Source: 2, OJS: 0, Delta: -2, Status: "missing"},
+		{Queue: "mailers", Source: 5, OJS: 3, Delta: -2, Status: "mismatch"},
+	}
+	if !reflect.DeepEqual(result.Queues, want) || result.Mismatches != 2 {
+		t.Errorf("result = %+v, want queues %+v", result, want)
+	}
+}
+
+func TestMigrateVerify_MissingSource(t *testing.T) {
+	err := Migrate(newTestClient(noRequestClient(t)), []string{"verify"})
+	if err == nil || !strings.Contains(err.Error(), "--source is required") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,11 +11,22 @@ import (
 	"github.com/openjobspec/ojs-cli/internal/client"
 )
 
+// pollInterval is how often wait/watch loops (drains, --watch) re-poll the
+// server. Tests shorten it.
+var pollInterval = 2 * time.Second
+
 // Monitor provides a live monitoring dashboard.
 func Monitor(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("monitor", flag.ExitOnError)
+	fs := newFlagSet("monitor")
 	interval := fs.Duration("interval", 2*time.Second, "Refresh interval")
-	fs.Parse(args)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs monitor [flags]", "Show a live dashboard of queues, workers and throughput.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt)
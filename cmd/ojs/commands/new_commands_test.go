@@ -2,11 +2,17 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
 )
 
 // --- Result command tests ---
@@ -53,6 +59,103 @@ func TestResult_Wait(t *testing.T) {
 	}
 }
 
+func TestResult_WaitStreamsPartials(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" || !strings.Contains(r.URL.RawQuery, "wait=true") {
+			t.Errorf("unexpected request %s?%s (Accept %q)", r.URL.Path, r.URL.RawQuery, r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{`{"chunk":"row 1"}`, `{"chunk":{"rows":2}}`} {
+			fmt.Fprintf(w, "event: partial\ndata: %s\n\n", chunk)
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "event: result\ndata: {\"state\":\"completed\",\"result\":{\"rows\":2}}\n\n")
+	})
+
+	out := captureStdout(t, func() {
+		if err := Result(c, []string{"--wait", "job-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	lines := strings.SplitN(out, "\n", 3)
+	if len(lines) < 3 || lines[0] != `{"partial":"row 1"}` || lines[1] != `{"partial":{"rows":2}}` {
+		t.Fatalf("expected two partial lines first, got:\n%s", out)
+	}
+	var final map[string]any
+	if err := json.Unmarshal([]byte(lines[2]), &final); err != nil || final["state"] != "completed" {
+		t.Errorf("expected the final result last, got:\n%s", lines[2])
+	}
+}
+
+func TestResult_WaitFallsBackWithoutStreaming(t *testing.T) {
+	withTableOutput(t)
+	requests := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"state": "completed", "result": "ok"})
+	})
+	out := captureStdout(t, func() {
+		if err := Result(c, []string{"--wait", "job-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if requests != 2 || !strings.Contains(out, "completed") {
+		t.Errorf("expected a plain retry after 406 (requests=%d):\n%s", requests, out)
+	}
+}
+
+func TestResult_WaitStreamEndsEarly(t *testing.T) {
+	var paths []string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		if r.URL.RawQuery != "" {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: partial\ndata: half\n\n")
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"state": "active"})
+	})
+	out := captureStdout(t, func() {
+		if err := Result(c, []string{"--wait", "job-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if len(paths) != 2 || paths[1] != "/ojs/v1/jobs/job-1/result?" {
+		t.Errorf("requests = %v, want the stream then a plain result fetch", paths)
+	}
+	if !strings.HasPrefix(out, `{"partial":"half"}`) || !strings.Contains(out, `"active"`) {
+		t.Errorf("output = %s", out)
+	}
+}
+
+func TestResult_WaitStreamTimesOut(t *testing.T) {
+	orig := resultWaitGrace
+	resultWaitGrace = 0
+	t.Cleanup(func() { resultWaitGrace = orig })
+
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		// Open the stream, send one partial and then go quiet.
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: partial\ndata: half\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	start := time.Now()
+	var err error
+	captureStdout(t, func() { err = Result(c, []string{"--wait", "--timeout", "1", "job-1"}) })
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("--timeout 1 took %s", elapsed)
+	}
+}
+
 // --- Jobs command tests ---
 
 func TestJobs_List(t *testing.T) {
@@ -98,6 +201,186 @@ func TestJobs_ListWithFilters(t *testing.T) {
 	}
 }
 
+func TestJobs_ListWithTags(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		tags := r.URL.Query()["tags"]
+		if strings.Join(tags, ",") != "env:prod,team:billing" {
+			t.Errorf("tags = %v, want [env:prod team:billing]", tags)
+		}
+		if r.URL.Query().Get("state") != "active" {
+			t.Errorf("state = %s, want active", r.URL.Query().Get("state"))
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": []any{}, "total": 0})
+	})
+	err := Jobs(c, []string{"--tag", "team=billing", "--state", "active", "--tag", "env=prod"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestJobs_SearchServerParam(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("search") != "ada@example.com" || q.Get("search_field") != "args" || q.Get("queue") != "email" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"jobs":  []map[string]any{{"id": "j-1", "args": []any{"ada@example.com"}}},
+			"total": 1,
+		})
+	})
+	out := captureStdout(t, func() {
+		if err := Jobs(c, []string{"--search", "ada@example.com", "--search-field", "args", "--queue", "email"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, `"j-1"`) {
+		t.Errorf("output missing j-1:\n%s", out)
+	}
+}
+
+func TestJobs_SearchClientFallback(t *testing.T) {
+	var offsets []string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("search") != "" {
+			w.WriteHeader(http.StatusNotImplemented)
+			return
+		}
+		if q.Get("state") != "discarded" {
+			t.Errorf("fallback lost the state filter: %s", r.URL.RawQuery)
+		}
+		offsets = append(offsets, q.Get("offset"))
+		var jobs []map[string]any
+		if q.Get("offset") == "0" {
+			for i := 0; i < searchPageSize; i++ {
+				jobs = append(jobs, map[string]any{"id": fmt.Sprintf("p1-%d", i), "args": []any{"bob@example.com"}})
+			}
+			jobs[7] = map[string]any{"id": "in-args", "args": []any{map[string]any{"to": "Ada@Example.com"}}}
+		} else {
+			jobs = []map[string]any{
+				{"id": "in-meta", "args": []any{}, "meta": map[string]any{"user": "ada@example.com"}},
+				{"id": "other", "args": []any{"carol"}},
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": jobs, "total": len(jobs)})
+	})
+
+	search := func(args ...string) []string {
+		t.Helper()
+		var resp struct {
+			Jobs []map[string]any `json:"jobs"`
+		}
+		out := captureStdout(t, func() {
+			if err := Jobs(c, append([]string{"--state", "discarded", "--search", "ada@example.com"}, args...)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		json.Unmarshal([]byte(out), &resp)
+		var ids []string
+		for _, j := range resp.Jobs {
+			ids = append(ids, str(j["id"]))
+		}
+		return ids
+	}
+
+	if got := search(); strings.Join(got, ",") != "in-args,in-meta" {
+		t.Errorf("matches = %v, want [in-args in-meta]", got)
+	}
+	if strings.Join(offsets, ",") != "0,100" {
+		t.Errorf("offsets = %v, want two pages", offsets)
+	}
+	if got := search("--search-field", "meta"); strings.Join(got, ",") != "in-meta" {
+		t.Errorf("meta-only matches = %v, want [in-meta]", got)
+	}
+	if got := search("--limit", "1"); strings.Join(got, ",") != "in-args" {
+		t.Errorf("limited matches = %v, want [in-args]", got)
+	}
+}
+
+func TestJobs_SearchIgnoredByServer(t *testing.T) {
+	var requests []string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		// The server reports it applied no search and returns an
+		// unfiltered page.
+		json.NewEncoder(w).Encode(map[string]any{
+			"jobs": []map[string]any{
+				{"id": "match", "args": []any{"ada@example.com"}},
+				{"id": "other", "args": []any{"bob@example.com"}},
+			},
+			"total":  2,
+			"search": nil,
+		})
+	})
+	var resp struct {
+		Jobs []map[string]any `json:"jobs"`
+	}
+	out := captureStdout(t, func() {
+		if err := Jobs(c, []string{"--search", "ada@example.com"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	json.Unmarshal([]byte(out), &resp)
+	if len(resp.Jobs) != 1 || resp.Jobs[0]["id"] != "match" {
+		t.Errorf("jobs = %v, want only the match", resp.Jobs)
+	}
+	if len(requests) != 2 || strings.Contains(requests[1], "search=") {
+		t.Errorf("requests = %v, want the search then a local scan", requests)
+	}
+}
+
+func TestJobs_SearchKeepsServerMatches(t *testing.T) {
+	requests := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// A fuzzy match that doesn't contain the search text literally.
+		json.NewEncoder(w).Encode(map[string]any{
+			"jobs":  []map[string]any{{"id": "fuzzy", "args": []any{"Ada Lovelace"}}},
+			"total": 1,
+		})
+	})
+	out := captureStdout(t, func() {
+		if err := Jobs(c, []string{"--search", "ada lovelace"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if requests != 1 || !strings.Contains(out, `"fuzzy"`) {
+		t.Errorf("expected the server's results as is, got %d request(s):\n%s", requests, out)
+	}
+}
+
+func TestJobs_SearchFieldInvalid(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	if err := Jobs(c, []string{"--search", "x", "--search-field", "type"}); err == nil {
+		t.Error("expected error for --search-field type")
+	}
+	if err := Jobs(c, []string{"--search-field", "args"}); err == nil {
+		t.Error("expected error for --search-field without --search")
+	}
+}
+
+func TestJobs_InvalidStateSuggestion(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	err := Jobs(c, []string{"--state", "complete"})
+	if err == nil || !strings.Contains(err.Error(), `invalid --state "complete" (did you mean completed?)`) {
+		t.Fatalf("err = %v, want a suggestion for completed", err)
+	}
+}
+
+func TestStateValidation_BulkAndPurge(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	if err := Bulk(c, []string{"retry", "--state", "retyrable"}); err == nil || !strings.Contains(err.Error(), "did you mean retryable?") {
+		t.Errorf("bulk retry err = %v, want a suggestion for retryable", err)
+	}
+	if err := Bulk(c, []string{"cancel", "--state", "bogus"}); err == nil || !strings.Contains(err.Error(), "Valid states:") {
+		t.Errorf("bulk cancel err = %v, want the valid states listed", err)
+	}
+	if err := Queues(c, []string{"--purge", "default", "--states", "completed,canceled", "--yes"}); err == nil || !strings.Contains(err.Error(), "did you mean cancelled?") {
+		t.Errorf("queues --purge err = %v, want a suggestion for cancelled", err)
+	}
+}
+
 // --- Bulk command tests ---
 
 func TestBulk_NoSubcommand(t *testing.T) {
@@ -318,6 +601,54 @@ func TestRateLimits_Override(t *testing.T) {
 	}
 }
 
+func TestRateLimits_OverrideTTL(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]any{"key": "email", "concurrency": 20})
+	})
+	before := time.Now()
+	err := RateLimits(c, []string{"--override", "email", "--concurrency", "20", "--ttl", "1h"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["ttl"] != "1h" {
+		t.Errorf("ttl = %v, want 1h", body["ttl"])
+	}
+	exp, err := time.Parse(time.RFC3339, str(body["expires_at"]))
+	if err != nil {
+		t.Fatalf("expires_at = %v: %v", body["expires_at"], err)
+	}
+	if d := exp.Sub(before); d < 59*time.Minute || d > 61*time.Minute {
+		t.Errorf("expires_at %v is not ~1h from now", exp)
+	}
+
+	if err := RateLimits(c, []string{"--override", "email", "--concurrency", "20", "--ttl", "soon"}); err == nil {
+		t.Error("expected error for invalid --ttl")
+	}
+}
+
+func TestRateLimits_InspectShowsOverrideExpiry(t *testing.T) {
+	withTableOutput(t)
+	expires := time.Now().Add(45*time.Minute + 30*time.Second).UTC().Format(time.RFC3339)
+	for _, payload := range []map[string]any{
+		{"key": "email", "concurrency": 10, "override": 20, "override_expires_at": expires},
+		{"key": "email", "concurrency": 10, "override": map[string]any{"concurrency": 20, "expires_at": expires}},
+	} {
+		c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(payload)
+		})
+		out := captureStdout(t, func() {
+			if err := RateLimits(c, []string{"--inspect", "email"}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		if !strings.Contains(out, "Override Expires") || !strings.Contains(out, expires+" (in 45m0s)") {
+			t.Errorf("expected remaining TTL in inspect view, got:\n%s", out)
+		}
+	}
+}
+
 func TestRateLimits_Override_MissingConcurrency(t *testing.T) {
 	c := newTestClient(nil)
 	err := RateLimits(c, []string{"--override", "email"})
@@ -326,6 +657,69 @@ func TestRateLimits_Override_MissingConcurrency(t *testing.T) {
 	}
 }
 
+func TestRateLimits_Apply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.yaml")
+	os.WriteFile(path, []byte("rate_limits:\n  sms: 5\n  email: 20\n"), 0644)
+
+	var puts []string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		puts = append(puts, fmt.Sprintf("%s %s %v", r.Method, r.URL.Path, body["concurrency"]))
+		w.Write([]byte(`{}`))
+	})
+	if err := RateLimits(c, []string{"apply", "--file", path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"PUT /ojs/v1/rate-limits/email/override 20",
+		"PUT /ojs/v1/rate-limits/sms/override 5",
+	}
+	if !reflect.DeepEqual(puts, want) {
+		t.Errorf("requests = %v, want %v", puts, want)
+	}
+}
+
+func TestRateLimits_ApplyDryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.yaml")
+	os.WriteFile(path, []byte("rate_limits:\n  email: 20\n  sms: 5\n"), 0644)
+
+	c := newTestClient(noRequestClient(t))
+	out := captureStdout(t, func() {
+		if err := RateLimits(c, []string{"apply", "--file", path, "--dry-run"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, `"planned"`) {
+		t.Errorf("output = %s, want planned results", out)
+	}
+}
+
+func TestRateLimits_ApplyReportsFailures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.yaml")
+	os.WriteFile(path, []byte("rate_limits:\n  email: 20\n  sms: 5\n"), 0644)
+
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/sms/") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"unknown rate limit"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	err := RateLimits(c, []string{"apply", "--file", path})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Fatalf("err = %v, want 1 of 2 failed", err)
+	}
+}
+
+func TestRateLimits_ApplyRequiresFile(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	if err := RateLimits(c, []string{"apply"}); err == nil || !strings.Contains(err.Error(), "--file is required") {
+		t.Fatalf("err = %v, want --file is required", err)
+	}
+}
+
 // --- System command tests ---
 
 func TestSystem_NoSubcommand(t *testing.T) {
@@ -387,6 +781,50 @@ func TestSystem_Maintenance_Status(t *testing.T) {
 	}
 }
 
+func TestSystem_Maintenance_AllowTypes(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		allowed, _ := body["allowed_types"].([]any)
+		if len(allowed) != 2 || allowed[0] != "critical.alert" || allowed[1] != "security.revoke" {
+			t.Errorf("allowed_types = %v, want [critical.alert security.revoke]", body["allowed_types"])
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{"enabled": true})
+	})
+	err := System(c, []string{"maintenance", "--enable", "--allow-types", "critical.alert,security.revoke"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSystem_Maintenance_AllowTypesRequiresEnable(t *testing.T) {
+	err := System(newTestClient(noRequestClient(t)), []string{"maintenance", "--disable", "--allow-types", "critical.alert"})
+	if err == nil || !strings.Contains(err.Error(), "requires --enable") {
+		t.Errorf("err = %v, want --allow-types requires --enable", err)
+	}
+}
+
+func TestSystem_Maintenance_StatusShowsAllowTypes(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{
+			"enabled":       true,
+			"reason":        "upgrade",
+			"allowed_types": []string{"critical.alert", "security.revoke"},
+		})
+	})
+	var err error
+	out := captureStdout(t, func() { err = System(c, []string{"maintenance"}) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Allowed types: critical.alert, security.revoke") {
+		t.Errorf("expected allow list in status output, got:\n%s", out)
+	}
+}
+
 func TestSystem_Config(t *testing.T) {
 	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -432,7 +870,7 @@ func TestDeadLetter_Purge(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]any{"deleted": 10})
 	})
-	err := DeadLetter(c, []string{"--purge"})
+	err := DeadLetter(c, []string{"--purge", "--yes"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -446,12 +884,89 @@ func TestDeadLetter_Purge_OlderThan(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]any{"deleted": 3})
 	})
-	err := DeadLetter(c, []string{"--purge", "--older-than", "7d"})
+	err := DeadLetter(c, []string{"--purge", "--older-than", "7d", "--yes"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
+func TestDeadLetter_Purge_Scoped(t *testing.T) {
+	purged := false
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("queue") != "emails" || q.Get("type") != "email.send" || q.Get("older_than") != "7d" {
+			t.Errorf("%s query = %v, want queue, type and older_than", r.URL.Path, q)
+		}
+		if r.Method == http.MethodGet {
+			json.NewEncoder(w).Encode(map[string]any{
+				"jobs":  []map[string]any{{"id": "dl-1", "queue": "emails", "type": "email.send"}},
+				"total": 2,
+			})
+			return
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		want := map[string]any{"queue": "emails", "type": "email.send", "older_than": "7d"}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("body = %v, want %v", body, want)
+		}
+		purged = true
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]any{"deleted": 2})
+	})
+	err := DeadLetter(c, []string{"--purge", "--queue", "emails", "--type", "email.send", "--older-than", "7d", "--yes"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !purged {
+		t.Error("expected the purge request")
+	}
+}
+
+func TestDeadLetter_Purge_ScopeNotApplied(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected %s %s: purge must not run", r.Method, r.URL.Path)
+		}
+		// A server without filtering returns the whole dead letter queue.
+		json.NewEncoder(w).Encode(map[string]any{
+			"jobs": []map[string]any{
+				{"id": "dl-1", "queue": "emails", "type": "email.send"},
+				{"id": "dl-2", "queue": "billing", "type": "invoice.create"},
+			},
+			"total": 2,
+		})
+	})
+	err := DeadLetter(c, []string{"--purge", "--queue", "emails", "--yes"})
+	if err == nil || !strings.Contains(err.Error(), "refusing to purge") {
+		t.Errorf("err = %v, want refusal", err)
+	}
+}
+
+func TestDeadLetter_Purge_ScopedNoMatches(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("unexpected %s %s: nothing to purge", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": []any{}, "total": 0})
+	})
+	if err := DeadLetter(c, []string{"--purge", "--type", "email.send", "--yes"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeadLetter_Purge_ScopedNeedsConfirmation(t *testing.T) {
+	withConfirm(t, false, "")
+	c := newTestClient(noRequestClient(t))
+	err := DeadLetter(c, []string{"--purge", "--type", "email.send"})
+	if err == nil || !strings.Contains(err.Error(), `of type "email.send"`) {
+		t.Errorf("err = %v, want confirmation error naming the type", err)
+	}
+	if err := DeadLetter(c, []string{"--queue", "emails"}); err == nil {
+		t.Error("expected error for --queue without --purge")
+	}
+}
+
 // --- Cron extended tests (trigger, history, pause, resume) ---
 
 func TestCron_Trigger(t *testing.T) {
@@ -471,6 +986,34 @@ func TestCron_Trigger(t *testing.T) {
 	}
 }
 
+func TestCron_TriggerWithArgs(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]any{"job_id": "triggered-job-2"})
+	})
+	if err := Cron(c, []string{"--trigger", "daily-report", "--args", `["2026-01-01", {"dry_run": true}]`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []any{"2026-01-01", map[string]any{"dry_run": true}}
+	if !reflect.DeepEqual(body["args"], want) {
+		t.Errorf("trigger body = %v, want args %v", body, want)
+	}
+}
+
+func TestCron_TriggerInvalidArgs(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	for _, args := range []string{`[1, 2`, `{"not": "an array"}`} {
+		err := Cron(c, []string{"--trigger", "daily-report", "--args", args})
+		if err == nil || !strings.Contains(err.Error(), "invalid --args JSON") {
+			t.Errorf("--args %s: err = %v, want invalid JSON", args, err)
+		}
+	}
+	if err := Cron(c, []string{"--args", "[]"}); err == nil {
+		t.Error("expected --args without --trigger to be rejected")
+	}
+}
+
 func TestCron_History(t *testing.T) {
 	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
 		if !strings.Contains(r.URL.Path, "/history") {
@@ -544,6 +1087,37 @@ func TestQueues_Create(t *testing.T) {
 	}
 }
 
+func TestQueues_CreateIfNotExists(t *testing.T) {
+	conflict := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error":{"code":"conflict","message":"queue billing already exists"}}`))
+	})
+
+	// Off by default: an existing queue is still an error.
+	if err := Queues(conflict, []string{"--create", "billing"}); !client.IsStatus(err, http.StatusConflict) {
+		t.Errorf("err = %v, want 409 conflict without --if-not-exists", err)
+	}
+
+	var err error
+	out := captureStdout(t, func() { err = Queues(conflict, []string{"--create", "billing", "--if-not-exists"}) })
+	if err != nil {
+		t.Fatalf("unexpected error with --if-not-exists: %v", err)
+	}
+	var result map[string]any
+	json.Unmarshal([]byte(out), &result)
+	if result["created"] != false || result["exists"] != true {
+		t.Errorf("result = %v, want created=false exists=true", result)
+	}
+
+	// Other failures are not masked.
+	failing := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	if err := Queues(failing, []string{"--create", "billing", "--if-not-exists"}); err == nil {
+		t.Error("expected error for 500 with --if-not-exists")
+	}
+}
+
 func TestQueues_Delete(t *testing.T) {
 	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodDelete {
@@ -552,7 +1126,7 @@ func TestQueues_Delete(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]any{"deleted": true})
 	})
-	err := Queues(c, []string{"--delete", "old-queue"})
+	err := Queues(c, []string{"--delete", "old-queue", "--yes"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -569,12 +1143,59 @@ func TestQueues_Purge(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]any{"deleted": 42})
 	})
-	err := Queues(c, []string{"--purge", "default", "--states", "completed,discarded"})
+	err := Queues(c, []string{"--purge", "default", "--states", "completed,discarded", "--yes"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
 
+func TestQueues_PurgeDryRun(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/ojs/v1/queues/default/stats" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"stats": map[string]any{"completed": 40, "dead": 2, "active": 3},
+		})
+	})
+	out := captureStdout(t, func() {
+		if err := Queues(c, []string{"--purge", "default", "--states", "completed,discarded,cancelled", "--dry-run"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var resp struct {
+		DryRun bool           `json:"dry_run"`
+		States map[string]int `json:"states"`
+		Total  int            `json:"total"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, out)
+	}
+	want := map[string]int{"completed": 40, "discarded": 2, "cancelled": 0}
+	if !resp.DryRun || resp.Total != 42 || !reflect.DeepEqual(resp.States, want) {
+		t.Errorf("dry run = %+v, want states %v and total 42", resp, want)
+	}
+}
+
+func TestQueues_PurgeRejectsInvalidStates(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	err := Queues(c, []string{"--purge", "default", "--states", "complete", "--dry-run"})
+	if err == nil || !strings.Contains(err.Error(), `invalid --states "complete" (did you mean completed?)`) {
+		t.Errorf("err = %v, want a suggestion for completed", err)
+	}
+	if err := Queues(c, []string{"--purge", "default", "--states", ",", "--yes"}); err == nil {
+		t.Error("expected an error for empty --states")
+	}
+}
+
+func TestQueues_PurgeRequiresConfirmation(t *testing.T) {
+	withConfirm(t, true, "n\n")
+	c := newTestClient(noRequestClient(t))
+	if err := Queues(c, []string{"--purge", "default"}); err == nil {
+		t.Fatal("expected the purge to be aborted without confirmation")
+	}
+}
+
 // --- Enqueue extended tests (unique, batch) ---
 
 func TestEnqueue_UniqueKey(t *testing.T) {
@@ -661,3 +1282,109 @@ func TestStatus_WithProgress(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestProgressBar(t *testing.T) {
+	cases := map[float64]string{
+		0.75: "[███████████████░░░░░]  75%",
+		0:    "[░░░░░░░░░░░░░░░░░░░░]   0%",
+		1.5:  "[████████████████████] 100%",
+	}
+	for p, want := range cases {
+		if got := progressBar(p); got != want {
+			t.Errorf("progressBar(%v) = %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestStatus_RendersProgress(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"id": "job-1", "type": "test", "state": "active", "queue": "default",
+			"progress":      0.75,
+			"progress_data": map[string]any{"step": "processing", "rows": 750},
+		})
+	})
+	out := captureStdout(t, func() {
+		if err := Status(c, []string{"job-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"███████████████░░░░░", "75%", "step", "processing", "rows", "750"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStatus_WatchUntilTerminal(t *testing.T) {
+	withTableOutput(t)
+	origPoll, origTTY := pollInterval, stdoutIsTerminal
+	pollInterval = time.Millisecond
+	stdoutIsTerminal = func() bool { return false }
+	t.Cleanup(func() { pollInterval, stdoutIsTerminal = origPoll, origTTY })
+
+	polls := []map[string]any{
+		{"id": "job-1", "state": "active", "progress": 0.25},
+		{"id": "job-1", "state": "active", "progress": 0.25},
+		{"id": "job-1", "state": "active", "progress": 0.75},
+		{"id": "job-1", "state": "completed", "progress": 1.0},
+	}
+	calls := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(polls[min(calls, len(polls)-1)])
+		calls++
+	})
+	out := captureStdout(t, func() {
+		if err := Status(c, []string{"--watch", "job-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if calls != len(polls) {
+		t.Errorf("polled %d times, want %d", calls, len(polls))
+	}
+	if lines := strings.Count(out, "\n"); lines != 3 {
+		t.Errorf("expected one line per change (3), got %d:\n%s", lines, out)
+	}
+	if !strings.Contains(out, "✓ completed [████████████████████] 100%") {
+		t.Errorf("missing final line:\n%s", out)
+	}
+}
+
+func TestJobCommands_NotFound(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"not_found","message":"job not found","request_id":"req-1"}}`))
+	})
+	for name, run := range map[string]func() error{
+		"status":        func() error { return Status(c, []string{"job-404"}) },
+		"status detail": func() error { return Status(c, []string{"--detail", "job-404"}) },
+		"result":        func() error { return Result(c, []string{"job-404"}) },
+		"cancel":        func() error { return Cancel(c, []string{"job-404"}) },
+	} {
+		err := run()
+		if err == nil || err.Error() != "job job-404 not found" {
+			t.Errorf("%s: expected friendly not-found error, got %v", name, err)
+			continue
+		}
+		var apiErr *client.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: expected the APIError to stay reachable, got %#v", name, err)
+		}
+		if code := ExitCode(err); code != ExitNotFound {
+			t.Errorf("%s: exit code = %d, want %d", name, code, ExitNotFound)
+		}
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if ExitCode(nil) != 0 {
+		t.Error("nil error should exit 0")
+	}
+	if ExitCode(errors.New("boom")) != 1 {
+		t.Error("generic errors should exit 1")
+	}
+	if ExitCode(&client.APIError{StatusCode: http.StatusInternalServerError}) != 1 {
+		t.Error("server errors should exit 1")
+	}
+}
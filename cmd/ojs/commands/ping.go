@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// pingSummary aggregates the round trips of one ping run. Latencies are in
+// milliseconds and only cover successful requests.
+type pingSummary struct {
+	Sent        int     `json:"sent"`
+	Received    int     `json:"received"`
+	SuccessRate float64 `json:"success_rate"`
+	MinMs       float64 `json:"min_ms"`
+	AvgMs       float64 `json:"avg_ms"`
+	P50Ms       float64 `json:"p50_ms"`
+	P95Ms       float64 `json:"p95_ms"`
+	MaxMs       float64 `json:"max_ms"`
+}
+
+// Ping repeatedly hits the health endpoint and reports latency statistics.
+func Ping(c *client.Client, args []string) error {
+	fs := newFlagSet("ping")
+	count := fs.Int("count", 10, "Number of requests to send")
+	interval := fs.Float64("interval", 1, "Seconds to wait between requests")
+	strict := fs.Bool("strict", false, "Exit non-zero if any request fails")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs ping [flags]", "Repeatedly hit /health and report latency statistics.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *count < 1 {
+		return fmt.Errorf("--count must be at least 1\n\nUsage: ojs ping [--count 10] [--interval 1] [--strict]")
+	}
+	if *interval < 0 {
+		return fmt.Errorf("--interval must not be negative\n\nUsage: ojs ping [--count 10] [--interval 1] [--strict]")
+	}
+	wait := time.Duration(*interval * float64(time.Second))
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	if output.Format != "json" {
+		fmt.Printf("PING %s/health\n", c.BaseURL())
+	}
+
+	var latencies []time.Duration
+	sent := 0
+loop:
+	for seq := 1; seq <= *count; seq++ {
+		start := time.Now()
+		_, status, err := c.Get("/health")
+		elapsed := time.Since(start)
+		sent++
+
+		if err != nil {
+			if output.Format != "json" {
+				fmt.Printf("seq=%d error: %v\n", seq, err)
+			}
+		} else {
+			latencies = append(latencies, elapsed)
+			if output.Format != "json" {
+				fmt.Printf("seq=%d status=%d time=%.1fms\n", seq, status, durationMs(elapsed))
+			}
+		}
+
+		if seq == *count {
+			break
+		}
+		select {
+		case <-time.After(wait):
+		case <-sigCh:
+			break loop
+		}
+	}
+
+	summary := summarizePings(latencies, sent)
+	if output.Format == "json" {
+		if err := output.JSON(summary); err != nil {
+			return err
+		}
+	} else {
+		fmt.Println()
+		fmt.Printf("%d requests, %d ok, %.1f%% success\n", summary.Sent, summary.Received, summary.SuccessRate)
+		if summary.Received > 0 {
+			fmt.Printf("min/avg/p50/p95/max = %.1f/%.1f/%.1f/%.1f/%.1f ms\n",
+				summary.MinMs, summary.AvgMs, summary.P50Ms, summary.P95Ms, summary.MaxMs)
+		}
+	}
+
+	if *strict && summary.Received < summary.Sent {
+		return fmt.Errorf("%d of %d requests failed", summary.Sent-summary.Received, summary.Sent)
+	}
+	return nil
+}
+
+// summarizePings computes the summary of sent requests from the latencies of
+// those that succeeded.
+func summarizePings(latencies []time.Duration, sent int) pingSummary {
+	s := pingSummary{Sent: sent, Received: len(latencies)}
+	if sent > 0 {
+		s.SuccessRate = float64(s.Received) / float64(sent) * 100
+	}
+	if len(latencies) == 0 {
+		return s
+	}
+
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	s.MinMs = durationMs(sorted[0])
+	s.MaxMs = durationMs(sorted[len(sorted)-1])
+	s.AvgMs = durationMs(total / time.Duration(len(sorted)))
+	s.P50Ms = durationMs(percentile(sorted, 50))
+	s.P95Ms = durationMs(percentile(sorted, 95))
+	return s
+}
+
+// percentile returns the nearest-rank p-th percentile of sorted, which must be
+// in ascending order and non-empty.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizePings(t *testing.T) {
+	var latencies []time.Duration
+	// 20 samples of 1..20ms, out of order.
+	for i := 20; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	s := summarizePings(latencies, 25)
+	if s.Sent != 25 || s.Received != 20 || s.SuccessRate != 80 {
+		t.Errorf("unexpected counts: %+v", s)
+	}
+	if s.MinMs != 1 || s.MaxMs != 20 || s.AvgMs != 10.5 {
+		t.Errorf("unexpected min/avg/max: %+v", s)
+	}
+	if s.P50Ms != 10 || s.P95Ms != 19 {
+		t.Errorf("expected p50=10 p95=19, got p50=%v p95=%v", s.P50Ms, s.P95Ms)
+	}
+	if latencies[0] != 20*time.Millisecond {
+		t.Error("summarizePings should not reorder its input")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{5, 10, 15, 20}
+	for _, tt := range []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, 5}, {25, 5}, {50, 10}, {51, 15}, {95, 20}, {100, 20},
+	} {
+		if got := percentile(sorted, tt.p); got != tt.want {
+			t.Errorf("p%v: got %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizePings_AllFailed(t *testing.T) {
+	s := summarizePings(nil, 3)
+	if s.Received != 0 || s.SuccessRate != 0 || s.MaxMs != 0 {
+		t.Errorf("unexpected summary: %+v", s)
+	}
+}
+
+func TestPing_Strict(t *testing.T) {
+	calls := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/health" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		calls++
+		if calls == 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	out := captureStdout(t, func() {
+		if err := Ping(c, []string{"--count", "3", "--interval", "0"}); err != nil {
+			t.Fatalf("failures should not error without --strict: %v", err)
+		}
+	})
+	var s pingSummary
+	if err := json.Unmarshal([]byte(out), &s); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if s.Sent != 3 || s.Received != 2 {
+		t.Errorf("unexpected summary: %+v", s)
+	}
+
+	calls = 0
+	var err error
+	captureStdout(t, func() {
+		err = Ping(c, []string{"--count", "3", "--interval", "0", "--strict"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 3") {
+		t.Errorf("expected strict failure, got %v", err)
+	}
+}
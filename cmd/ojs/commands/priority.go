@@ -1,9 +1,8 @@
 package commands
 
 import (
-	"encoding/json"
-	"flag"
 	"fmt"
+	"net/http"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -11,9 +10,16 @@ import (
 
 // Priority updates job priority.
 func Priority(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("priority", flag.ExitOnError)
+	fs := newFlagSet("priority")
 	set := fs.Int("set", -1, "New priority value (0-255)")
-	fs.Parse(args)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs priority <job-id> --set <priority>", "Change the priority of a pending job.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	remaining := fs.Args()
 	if len(remaining) == 0 {
@@ -30,15 +36,13 @@ func Priority(c *client.Client, args []string) error {
 		"priority": *set,
 	}
 
-	data, _, err := c.Patch("/jobs/"+jobID, body)
-	if err != nil {
+	var job map[string]any
+	if err := c.DoJSON(http.MethodPatch, "/jobs/"+jobID, body, &job); err != nil {
 		return err
 	}
 
 	if output.Format == "json" {
-		var result any
-		json.Unmarshal(data, &result)
-		return output.JSON(result)
+		return output.JSON(job)
 	}
 
 	output.Success("Job %s priority updated to %d", jobID, *set)
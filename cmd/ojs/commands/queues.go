@@ -2,42 +2,105 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/jobstate"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
 // Queues lists queues and their stats.
 func Queues(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("queues", flag.ExitOnError)
+	if len(args) > 0 {
+		switch args[0] {
+		case "export":
+			return queuesExport(c, args[1:])
+		case "apply":
+			return queuesApply(c, args[1:])
+		}
+	}
+
+	fs := newFlagSet("queues")
 	statsName := fs.String("stats", "", "Show detailed stats for a specific queue")
+	history := fs.Bool("history", false, "With --stats, show the queue's depth and throughput over time")
+	period := fs.String("period", "5m", "Aggregation period for --history (e.g. 5m, 1h)")
 	pause := fs.String("pause", "", "Pause a queue")
 	resume := fs.String("resume", "", "Resume a queue")
 	create := fs.String("create", "", "Create a new queue")
+	ifNotExists := fs.Bool("if-not-exists", false, "With --create, succeed without changes if the queue already exists")
 	deleteQueue := fs.String("delete", "", "Delete a queue")
 	purge := fs.String("purge", "", "Purge completed jobs from a queue")
 	concurrency := fs.Int("concurrency", 0, "Concurrency limit (for create/config)")
 	maxSize := fs.Int("max-size", 0, "Max queue size (for create/config)")
 	purgeStates := fs.String("states", "completed", "States to purge (comma-separated)")
+	dryRun := fs.Bool("dry-run", false, "With --purge, report how many jobs would be purged without deleting them")
 	configQueue := fs.String("config", "", "Update configuration for a queue")
 	retention := fs.String("retention", "", "Retention duration (for config, e.g. 24h, 7d)")
-	fs.Parse(args)
+	var sets stringList
+	fs.Var(&sets, "set", "Set any queue config key as key=value (for config, repeatable)")
+	yes := fs.Bool("yes", false, "Skip confirmation prompts for --delete, --purge and --rename")
+	drain := fs.String("drain", "", "Pause a queue and wait until it has no active or available jobs")
+	timeout := fs.Int("timeout", 300, "Drain wait timeout in seconds (with --drain)")
+	rename := fs.String("rename", "", "Rename a queue (requires --to)")
+	renameTo := fs.String("to", "", "New queue name (with --rename)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs queues [flags]", "List, inspect, create, delete, purge, configure, pause and resume queues.\nUse \"ojs queues export\" and \"ojs queues apply\" to manage queues as a file.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *rename != "" {
+		if *renameTo == "" {
+			return fmt.Errorf("--to is required\n\nUsage: ojs queues --rename <old> --to <new> [--yes]")
+		}
+		if err := confirmDestructive(fmt.Sprintf("move every job from queue %q to %q and delete %q", *rename, *renameTo, *rename), *rename, *yes); err != nil {
+			return err
+		}
+		return renameQueue(c, *rename, *renameTo)
+	}
+
+	if *drain != "" {
+		return drainQueue(c, *drain, time.Duration(*timeout)*time.Second)
+	}
 
 	if *configQueue != "" {
-		return updateQueueConfig(c, *configQueue, *concurrency, *maxSize, *retention)
+		return updateQueueConfig(c, *configQueue, *concurrency, *maxSize, *retention, sets)
 	}
 
 	if *create != "" {
-		return createQueue(c, *create, *concurrency, *maxSize)
+		return createQueue(c, *create, *concurrency, *maxSize, *ifNotExists)
 	}
 
 	if *deleteQueue != "" {
+		if err := confirmDestructive(fmt.Sprintf("delete queue %q", *deleteQueue), *deleteQueue, *yes); err != nil {
+			return err
+		}
 		return deleteQueueCmd(c, *deleteQueue)
 	}
 
 	if *purge != "" {
+		states := splitCommaStr(*purgeStates)
+		if len(states) == 0 {
+			return fmt.Errorf("--states must name at least one job state\n\nUsage: ojs queues --purge <queue> [--states <s1,s2,...>] [--dry-run] [--yes]")
+		}
+		if err := validateStates("--states", states...); err != nil {
+			return err
+		}
+		if *dryRun {
+			return previewPurge(c, *purge, states)
+		}
+		if err := confirmDestructive(fmt.Sprintf("purge %s jobs from queue %q", *purgeStates, *purge), *purge, *yes); err != nil {
+			return err
+		}
 		return purgeQueue(c, *purge, *purgeStates)
 	}
 
@@ -60,8 +123,14 @@ func Queues(c *client.Client, args []string) error {
 	}
 
 	if *statsName != "" {
+		if *history {
+			return queueStatsHistory(c, *statsName, *period)
+		}
 		return queueStats(c, *statsName)
 	}
+	if *history {
+		return fmt.Errorf("--history requires --stats\n\nUsage: ojs queues --stats <name> --history [--period 5m]")
+	}
 
 	return listQueues(c)
 }
@@ -141,7 +210,223 @@ func queueStats(c *client.Client, name string) error {
 	return nil
 }
 
-func createQueue(c *client.Client, name string, concurrency, maxSize int) error {
+// queueHistoryPoint is one bucket of /queues/<name>/stats/history.
+type queueHistoryPoint struct {
+	Timestamp string `json:"timestamp"`
+	Depth     int    `json:"depth"`
+	Enqueued  int    `json:"enqueued"`
+	Completed int    `json:"completed"`
+	Failed    int    `json:"failed"`
+}
+
+// queueStatsHistory shows a queue's depth and throughput per period. Servers
+// without the history endpoint get the point-in-time stats instead.
+func queueStatsHistory(c *client.Client, name, period string) error {
+	data, _, err := c.Get("/queues/" + name + "/stats/history?period=" + url.QueryEscape(period))
+	if client.IsStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented) {
+		output.Warn("Server does not provide queue history; showing current stats")
+		return queueStats(c, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output.Format == "json" {
+		var result any
+		json.Unmarshal(data, &result)
+		return output.JSON(result)
+	}
+
+	var resp struct {
+		Period     string              `json:"period"`
+		DataPoints []queueHistoryPoint `json:"data_points"`
+	}
+	json.Unmarshal(data, &resp)
+	if resp.Period == "" {
+		resp.Period = period
+	}
+
+	fmt.Printf("Queue %q history (period=%s)\n\n", name, resp.Period)
+	if len(resp.DataPoints) == 0 {
+		fmt.Println("No data points available.")
+		return nil
+	}
+
+	headers := []string{"TIMESTAMP", "DEPTH", "ENQUEUED", "COMPLETED", "FAILED"}
+	rows := make([][]string, 0, len(resp.DataPoints))
+	depth := make([]int, 0, len(resp.DataPoints))
+	completed := make([]int, 0, len(resp.DataPoints))
+	for _, dp := range resp.DataPoints {
+		rows = append(rows, []string{
+			dp.Timestamp,
+			fmt.Sprintf("%d", dp.Depth),
+			fmt.Sprintf("%d", dp.Enqueued),
+			fmt.Sprintf("%d", dp.Completed),
+			fmt.Sprintf("%d", dp.Failed),
+		})
+		depth = append(depth, dp.Depth)
+		completed = append(completed, dp.Completed)
+	}
+	output.Table(headers, rows)
+
+	fmt.Println()
+	fmt.Printf("Depth      %s  (min %d, max %d)\n", sparkline(depth), slices.Min(depth), slices.Max(depth))
+	fmt.Printf("Completed  %s  (min %d, max %d)\n", sparkline(completed), slices.Min(completed), slices.Max(completed))
+	return nil
+}
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders a non-empty series as block characters scaled between
+// its minimum and maximum. A flat series renders as the lowest bar.
+func sparkline(values []int) string {
+	lo, hi := slices.Min(values), slices.Max(values)
+	var b strings.Builder
+	for _, v := range values {
+		i := 0
+		if hi > lo {
+			i = (v - lo) * (len(sparkBars) - 1) / (hi - lo)
+		}
+		b.WriteRune(sparkBars[i])
+	}
+	return b.String()
+}
+
+type queueCounts struct {
+	Available int `json:"available"`
+	Active    int `json:"active"`
+	Completed int `json:"completed"`
+	Scheduled int `json:"scheduled"`
+	Retryable int `json:"retryable"`
+	Dead      int `json:"dead"`
+}
+
+// total is the number of jobs in the queue across all states.
+func (q queueCounts) total() int {
+	return q.Available + q.Active + q.Completed + q.Scheduled + q.Retryable + q.Dead
+}
+
+func fetchQueueCounts(c *client.Client, name string) (queueCounts, error) {
+	data, _, err := c.Get("/queues/" + name + "/stats")
+	if err != nil {
+		return queueCounts{}, err
+	}
+	var resp struct {
+		Stats queueCounts `json:"stats"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return queueCounts{}, fmt.Errorf("parse queue stats: %w", err)
+	}
+	return resp.Stats, nil
+}
+
+// drainQueue pauses a queue and waits for its in-flight and ready jobs to
+// reach zero. On timeout the queue is left paused.
+func drainQueue(c *client.Client, name string, timeout time.Duration) error {
+	if _, _, err := c.Post("/queues/"+name+"/pause", nil); err != nil {
+		return err
+	}
+	if output.Format != "json" {
+		output.Success("Queue %q paused; waiting for it to drain", name)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		counts, err := fetchQueueCounts(c, name)
+		if err != nil {
+			return err
+		}
+		remaining := counts.Active + counts.Available
+		if remaining == 0 {
+			if output.Format == "json" {
+				return output.JSON(map[string]any{"queue": name, "drained": true, "status": "paused"})
+			}
+			output.Success("Queue %q drained", name)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for queue %q to drain (%d active, %d available); queue left paused",
+				timeout, name, counts.Active, counts.Available)
+		}
+		if output.Format != "json" {
+			fmt.Printf("  %d active, %d available\n", counts.Active, counts.Available)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// renameQueue creates newName with oldName's config, moves every job across,
+// and deletes oldName. If the move fails, newName is deleted again only when
+// no jobs reached it.
+func renameQueue(c *client.Client, oldName, newName string) error {
+	step := func(format string, a ...any) {
+		if output.Format != "json" {
+			output.Success(format, a...)
+		}
+	}
+
+	data, _, err := c.Get("/admin/queues/" + oldName + "/config")
+	if err != nil {
+		return fmt.Errorf("read config for queue %q: %w", oldName, err)
+	}
+	var cfg map[string]any
+	json.Unmarshal(data, &cfg)
+
+	body := map[string]any{"name": newName}
+	for _, key := range []string{"concurrency", "max_size", "retention"} {
+		if v, ok := cfg[key]; ok && v != nil {
+			body[key] = v
+		}
+	}
+	if _, _, err := c.Post("/queues", body); err != nil {
+		return fmt.Errorf("create queue %q: %w", newName, err)
+	}
+	step("Created queue %q with config from %q", newName, oldName)
+
+	data, _, err = c.Post("/admin/queues/"+oldName+"/move", map[string]any{"target": newName})
+	if err != nil {
+		moveErr := fmt.Errorf("move jobs from %q to %q: %w", oldName, newName, err)
+		// A move that failed partway may have left jobs in the new queue;
+		// only an empty one is safe to delete.
+		counts, statErr := fetchQueueCounts(c, newName)
+		if statErr != nil {
+			return fmt.Errorf("%w (could not check queue %q for moved jobs, so both queues were kept: %v)", moveErr, newName, statErr)
+		}
+		if n := counts.total(); n > 0 {
+			left := "an unknown number of"
+			if oldCounts, err := fetchQueueCounts(c, oldName); err == nil {
+				left = fmt.Sprint(oldCounts.total())
+			}
+			return fmt.Errorf("%w (%d jobs were already moved to %q and %s remain in %q; both queues were kept)", moveErr, n, newName, left, oldName)
+		}
+		if _, _, delErr := c.Delete("/queues/" + newName); delErr != nil {
+			return fmt.Errorf("%w (rollback failed, queue %q must be removed manually: %v)", moveErr, newName, delErr)
+		}
+		output.Warn("Rolled back: deleted queue %q", newName)
+		return moveErr
+	}
+	var moved struct {
+		Moved int `json:"moved"`
+	}
+	json.Unmarshal(data, &moved)
+	step("Moved %d jobs from %q to %q", moved.Moved, oldName, newName)
+
+	if _, _, err := c.Delete("/queues/" + oldName); err != nil {
+		// Jobs already live in the new queue, so keep it and report.
+		return fmt.Errorf("delete queue %q: %w (jobs were moved to %q; remove %q manually)", oldName, err, newName, oldName)
+	}
+	step("Deleted queue %q", oldName)
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"from": oldName, "to": newName, "moved": moved.Moved})
+	}
+	output.Success("Queue %q renamed to %q", oldName, newName)
+	return nil
+}
+
+// createQueue creates a queue. With ifNotExists, a conflict because the
+// queue already exists is reported as success and the queue is left as is.
+func createQueue(c *client.Client, name string, concurrency, maxSize int, ifNotExists bool) error {
 	body := map[string]any{
 		"name": name,
 	}
@@ -153,6 +438,13 @@ func createQueue(c *client.Client, name string, concurrency, maxSize int) error
 	}
 
 	data, _, err := c.Post("/queues", body)
+	if ifNotExists && client.IsStatus(err, http.StatusConflict) {
+		if output.Format == "json" {
+			return output.JSON(map[string]any{"name": name, "created": false, "exists": true})
+		}
+		output.Success("Queue %q already exists", name)
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -200,6 +492,43 @@ func purgeQueue(c *client.Client, name, states string) error {
 	return nil
 }
 
+// previewPurge reports how many jobs in each state a purge would delete,
+// using the queue's stats. Older servers report discarded jobs as "dead".
+func previewPurge(c *client.Client, name string, states []string) error {
+	data, _, err := c.Get("/queues/" + name + "/stats")
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Stats map[string]any `json:"stats"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("parse queue stats: %w", err)
+	}
+
+	counts := make(map[string]int, len(states))
+	total := 0
+	for _, state := range states {
+		v, ok := resp.Stats[state]
+		if !ok && state == jobstate.Discarded {
+			v = resp.Stats["dead"]
+		}
+		counts[state] = int(toFloat(v))
+		total += counts[state]
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"queue": name, "dry_run": true, "states": counts, "total": total})
+	}
+	rows := make([][]string, 0, len(states))
+	for _, state := range states {
+		rows = append(rows, []string{state, strconv.Itoa(counts[state])})
+	}
+	output.Table([]string{"STATE", "JOBS"}, rows)
+	fmt.Printf("\nWould purge %d jobs from queue %q (dry run, nothing deleted)\n", total, name)
+	return nil
+}
+
 func splitCommaStr(s string) []string {
 	result := []string{}
 	current := ""
@@ -219,7 +548,10 @@ func splitCommaStr(s string) []string {
 	return result
 }
 
-func updateQueueConfig(c *client.Client, name string, concurrency, maxSize int, retention string) error {
+// updateQueueConfig PUTs a queue's config. --set key=value entries let new
+// server options through without a CLI release; numbers and booleans are
+// sent as typed JSON values, and --set wins over the dedicated flags.
+func updateQueueConfig(c *client.Client, name string, concurrency, maxSize int, retention string, sets []string) error {
 	body := map[string]any{}
 	if concurrency > 0 {
 		body["concurrency"] = concurrency
@@ -230,10 +562,19 @@ func updateQueueConfig(c *client.Client, name string, concurrency, maxSize int,
 	if retention != "" {
 		body["retention"] = retention
 	}
+	for _, kv := range sets {
+		key, value, ok := strings.Cut(kv, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --set %q: expected key=value\n\n"+
+				"Usage: ojs queues --config <name> --set <key>=<value> [--set ...]", kv)
+		}
+		body[key] = coerceValue(value)
+	}
 
 	if len(body) == 0 {
 		return fmt.Errorf("at least one config option is required\n\n" +
-			"Usage: ojs queues --config <name> [--concurrency <n>] [--max-size <n>] [--retention <duration>]")
+			"Usage: ojs queues --config <name> [--concurrency <n>] [--max-size <n>] [--retention <duration>] [--set <key>=<value>]")
 	}
 
 	data, _, err := c.Put("/admin/queues/"+name+"/config", body)
@@ -0,0 +1,260 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+// queueSpec is the declarative form of a queue used by export and apply.
+type queueSpec struct {
+	Name        string `yaml:"name" json:"name"`
+	Concurrency int    `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+	MaxSize     int    `yaml:"max_size,omitempty" json:"max_size,omitempty"`
+	Retention   string `yaml:"retention,omitempty" json:"retention,omitempty"`
+}
+
+type queueSpecFile struct {
+	Queues []queueSpec `yaml:"queues" json:"queues"`
+}
+
+// queueChange is one step of an apply plan.
+type queueChange struct {
+	Action  string    `json:"action"` // "create", "update", "delete"
+	Spec    queueSpec `json:"spec"`
+	Changes []string  `json:"changes,omitempty"`
+}
+
+func queuesExport(c *client.Client, args []string) error {
+	fs := newFlagSet("queues export")
+	out := fs.String("out", "", "Output file (default: stdout)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs queues export [flags]", "Write all queue configurations as a YAML or JSON file suitable for \"ojs queues apply\".")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	specs, err := fetchQueueSpecs(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(queueSpecFile{Queues: specs})
+	if err != nil {
+		return fmt.Errorf("encode queues: %w", err)
+	}
+	if *out == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", *out, err)
+	}
+	output.Success("Exported %d queues to %s", len(specs), *out)
+	return nil
+}
+
+func queuesApply(c *client.Client, args []string) error {
+	fs := newFlagSet("queues apply")
+	file := fs.String("file", "", "Queue definitions file (required)")
+	dryRun := fs.Bool("dry-run", false, "Show the planned changes without applying them")
+	prune := fs.Bool("prune", false, "Delete queues that are not in the file")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt for --prune deletions")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs queues apply --file <queues.yaml> [flags]", "Create or update queues so the server matches a file.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("--file is required\n\nUsage: ojs queues apply --file <queues.yaml> [--dry-run] [--prune]")
+	}
+
+	raw, err := os.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", *file, err)
+	}
+	var desired queueSpecFile
+	if err := yaml.Unmarshal(raw, &desired); err != nil {
+		return fmt.Errorf("parse %s: %w", *file, err)
+	}
+	for i, q := range desired.Queues {
+		if q.Name == "" {
+			return fmt.Errorf("%s: queue %d has no name", *file, i+1)
+		}
+	}
+
+	current, err := fetchQueueSpecs(c)
+	if err != nil {
+		return err
+	}
+
+	plan := planQueueApply(desired.Queues, current, *prune)
+
+	if *dryRun || len(plan) == 0 {
+		return printQueuePlan(plan, *dryRun)
+	}
+
+	var deletes []string
+	for _, ch := range plan {
+		if ch.Action == "delete" {
+			deletes = append(deletes, ch.Spec.Name)
+		}
+	}
+	if len(deletes) > 0 {
+		if err := confirmDestructive("delete queues "+strings.Join(deletes, ", "), "", *yes); err != nil {
+			return err
+		}
+	}
+
+	for _, ch := range plan {
+		var err error
+		switch ch.Action {
+		case "create":
+			_, _, err = c.Post("/queues", queueSpecBody(ch.Spec, true))
+		case "update":
+			_, _, err = c.Put("/admin/queues/"+ch.Spec.Name+"/config", queueSpecBody(ch.Spec, false))
+		case "delete":
+			_, _, err = c.Delete("/queues/" + ch.Spec.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("%s queue %q: %w", ch.Action, ch.Spec.Name, err)
+		}
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"applied": plan})
+	}
+	output.Success("Applied %d queue change(s)", len(plan))
+	return nil
+}
+
+// fetchQueueSpecs reads every queue and its config from the server.
+func fetchQueueSpecs(c *client.Client) ([]queueSpec, error) {
+	data, _, err := c.Get("/queues")
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Queues []struct {
+			Name string `json:"name"`
+		} `json:"queues"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parse queues response: %w", err)
+	}
+
+	specs := make([]queueSpec, 0, len(resp.Queues))
+	for _, q := range resp.Queues {
+		data, _, err := c.Get("/admin/queues/" + q.Name + "/config")
+		if err != nil {
+			return nil, fmt.Errorf("read config for queue %q: %w", q.Name, err)
+		}
+		spec := queueSpec{}
+		json.Unmarshal(data, &spec)
+		spec.Name = q.Name
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+	return specs, nil
+}
+
+// planQueueApply computes the changes needed to move current toward desired.
+// Fields omitted in desired are left as they are on the server. Queues absent
+// from desired are only deleted when prune is set.
+func planQueueApply(desired, current []queueSpec, prune bool) []queueChange {
+	existing := make(map[string]queueSpec, len(current))
+	for _, q := range current {
+		existing[q.Name] = q
+	}
+
+	var plan []queueChange
+	wanted := make(map[string]bool, len(desired))
+	for _, want := range desired {
+		wanted[want.Name] = true
+		have, ok := existing[want.Name]
+		if !ok {
+			plan = append(plan, queueChange{Action: "create", Spec: want})
+			continue
+		}
+		var changes []string
+		if want.Concurrency != 0 && want.Concurrency != have.Concurrency {
+			changes = append(changes, fmt.Sprintf("concurrency: %d -> %d", have.Concurrency, want.Concurrency))
+		}
+		if want.MaxSize != 0 && want.MaxSize != have.MaxSize {
+			changes = append(changes, fmt.Sprintf("max_size: %d -> %d", have.MaxSize, want.MaxSize))
+		}
+		if want.Retention != "" && want.Retention != have.Retention {
+			changes = append(changes, fmt.Sprintf("retention: %s -> %s", orDash(have.Retention), want.Retention))
+		}
+		if len(changes) > 0 {
+			plan = append(plan, queueChange{Action: "update", Spec: want, Changes: changes})
+		}
+	}
+
+	if prune {
+		for _, have := range current {
+			if !wanted[have.Name] {
+				plan = append(plan, queueChange{Action: "delete", Spec: have})
+			}
+		}
+	}
+	return plan
+}
+
+func queueSpecBody(spec queueSpec, includeName bool) map[string]any {
+	body := map[string]any{}
+	if includeName {
+		body["name"] = spec.Name
+	}
+	if spec.Concurrency > 0 {
+		body["concurrency"] = spec.Concurrency
+	}
+	if spec.MaxSize > 0 {
+		body["max_size"] = spec.MaxSize
+	}
+	if spec.Retention != "" {
+		body["retention"] = spec.Retention
+	}
+	return body
+}
+
+func printQueuePlan(plan []queueChange, dryRun bool) error {
+	if output.Format == "json" {
+		if plan == nil {
+			plan = []queueChange{}
+		}
+		return output.JSON(map[string]any{"dry_run": dryRun, "changes": plan})
+	}
+	if len(plan) == 0 {
+		fmt.Println("Queues are up to date.")
+		return nil
+	}
+
+	headers := []string{"ACTION", "QUEUE", "CHANGES"}
+	rows := make([][]string, 0, len(plan))
+	for _, ch := range plan {
+		rows = append(rows, []string{ch.Action, ch.Spec.Name, strings.Join(ch.Changes, "; ")})
+	}
+	output.Table(headers, rows)
+	return nil
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
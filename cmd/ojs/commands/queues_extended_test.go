@@ -3,7 +3,13 @@ package commands
 import (
 	"encoding/json"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestQueues_Pause(t *testing.T) {
@@ -125,3 +131,316 @@ func TestQueues_List_JSONOutput(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestQueues_Drain_ReachesZero(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	paused := false
+	polls := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ojs/v1/queues/emails/pause":
+			paused = true
+			w.Write([]byte(`{}`))
+		case "/ojs/v1/queues/emails/stats":
+			if !paused {
+				t.Error("stats polled before queue was paused")
+			}
+			remaining := max(3-polls, 0)
+			polls++
+			json.NewEncoder(w).Encode(map[string]any{
+				"queue": "emails", "status": "paused",
+				"stats": map[string]any{"active": remaining, "available": remaining},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	if err := Queues(c, []string{"--drain", "emails", "--timeout", "5"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 4 {
+		t.Errorf("polls = %d, want 4", polls)
+	}
+}
+
+func TestQueues_Drain_Timeout(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	resumed := false
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ojs/v1/queues/emails/resume":
+			resumed = true
+		case "/ojs/v1/queues/emails/stats":
+			json.NewEncoder(w).Encode(map[string]any{
+				"stats": map[string]any{"active": 1, "available": 10},
+			})
+		default:
+			w.Write([]byte(`{}`))
+		}
+	})
+
+	err := Queues(c, []string{"--drain", "emails", "--timeout", "0"})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if resumed {
+		t.Error("queue should be left paused after a drain timeout")
+	}
+}
+
+// renameTestServer serves a queue rename of old to new. With failMove the
+// move fails after moving partial jobs into new.
+func renameTestServer(t *testing.T, failMove bool, partial int, steps *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*steps = append(*steps, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/admin/queues/old/config":
+			json.NewEncoder(w).Encode(map[string]any{"concurrency": 5, "max_size": 100, "retention": "7d"})
+		case r.Method == http.MethodPost && r.URL.Path == "/ojs/v1/queues":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["name"] != "new" || body["concurrency"] != float64(5) || body["retention"] != "7d" {
+				t.Errorf("create body = %v, want new queue with copied config", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/queues/new/stats":
+			json.NewEncoder(w).Encode(map[string]any{"stats": map[string]any{"available": partial}})
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/queues/old/stats":
+			json.NewEncoder(w).Encode(map[string]any{"stats": map[string]any{"available": 12 - partial}})
+		case r.Method == http.MethodPost && r.URL.Path == "/ojs/v1/admin/queues/old/move":
+			if failMove {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error":{"code":"internal_error","message":"move failed"}}`))
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"moved": 12})
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestQueues_Rename_Steps(t *testing.T) {
+	var steps []string
+	c := newTestClient(renameTestServer(t, false, 0, &steps))
+	if err := Queues(c, []string{"--rename", "old", "--to", "new", "--yes"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{
+		"GET /ojs/v1/admin/queues/old/config",
+		"POST /ojs/v1/queues",
+		"POST /ojs/v1/admin/queues/old/move",
+		"DELETE /ojs/v1/queues/old",
+	}
+	if strings.Join(steps, "\n") != strings.Join(want, "\n") {
+		t.Errorf("steps = %v, want %v", steps, want)
+	}
+}
+
+func TestQueues_Rename_MoveFailureRollsBack(t *testing.T) {
+	var steps []string
+	c := newTestClient(renameTestServer(t, true, 0, &steps))
+	err := Queues(c, []string{"--rename", "old", "--to", "new", "--yes"})
+	if err == nil || !strings.Contains(err.Error(), "move jobs") {
+		t.Fatalf("expected move error, got %v", err)
+	}
+	last := steps[len(steps)-1]
+	if last != "DELETE /ojs/v1/queues/new" {
+		t.Errorf("last step = %s, want rollback delete of new queue", last)
+	}
+	for _, s := range steps {
+		if s == "DELETE /ojs/v1/queues/old" {
+			t.Error("old queue must not be deleted when the move fails")
+		}
+	}
+}
+
+func TestQueues_Rename_PartialMoveKeepsBothQueues(t *testing.T) {
+	var steps []string
+	c := newTestClient(renameTestServer(t, true, 4, &steps))
+	err := Queues(c, []string{"--rename", "old", "--to", "new", "--yes"})
+	if err == nil || !strings.Contains(err.Error(), `4 jobs were already moved to "new" and 8 remain in "old"`) {
+		t.Fatalf("expected partial move error, got %v", err)
+	}
+	for _, s := range steps {
+		if strings.HasPrefix(s, "DELETE") {
+			t.Errorf("no queue may be deleted after a partial move, got %s", s)
+		}
+	}
+}
+
+func TestQueues_Rename_NeedsConfirmation(t *testing.T) {
+	withConfirm(t, true, "new\n")
+	c := newTestClient(noRequestClient(t))
+	err := Queues(c, []string{"--rename", "old", "--to", "new"})
+	if err == nil || !strings.Contains(err.Error(), "aborted") {
+		t.Fatalf("expected aborted rename, got %v", err)
+	}
+
+	withConfirm(t, false, "")
+	if err := Queues(c, []string{"--rename", "old", "--to", "new"}); err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("expected non-interactive rename to require --yes, got %v", err)
+	}
+}
+
+func TestQueues_Rename_RequiresTo(t *testing.T) {
+	c := newTestClient(nil)
+	if err := Queues(c, []string{"--rename", "old"}); err == nil {
+		t.Fatal("expected error without --to")
+	}
+}
+
+func TestPlanQueueApply(t *testing.T) {
+	current := []queueSpec{
+		{Name: "default", Concurrency: 10, Retention: "7d"},
+		{Name: "emails", Concurrency: 5},
+		{Name: "legacy", Concurrency: 1},
+	}
+	desired := []queueSpec{
+		{Name: "default", Concurrency: 10},            // unchanged; retention omitted
+		{Name: "emails", Concurrency: 8, MaxSize: 50}, // update
+		{Name: "reports", Concurrency: 2},             // create
+	}
+
+	plan := planQueueApply(desired, current, false)
+	var got []string
+	for _, ch := range plan {
+		got = append(got, ch.Action+":"+ch.Spec.Name)
+	}
+	if strings.Join(got, ",") != "update:emails,create:reports" {
+		t.Errorf("plan = %v, want update:emails,create:reports", got)
+	}
+	if len(plan[0].Changes) != 2 {
+		t.Errorf("emails changes = %v, want concurrency and max_size", plan[0].Changes)
+	}
+
+	plan = planQueueApply(desired, current, true)
+	last := plan[len(plan)-1]
+	if last.Action != "delete" || last.Spec.Name != "legacy" {
+		t.Errorf("prune plan last = %s:%s, want delete:legacy", last.Action, last.Spec.Name)
+	}
+}
+
+func queueStateServer(t *testing.T, mutations *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/queues":
+			json.NewEncoder(w).Encode(map[string]any{
+				"queues": []map[string]any{{"name": "default"}, {"name": "legacy"}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/admin/queues/default/config":
+			json.NewEncoder(w).Encode(map[string]any{"concurrency": 10, "retention": "7d"})
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/admin/queues/legacy/config":
+			json.NewEncoder(w).Encode(map[string]any{"concurrency": 1})
+		default:
+			*mutations = append(*mutations, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{}`))
+		}
+	}
+}
+
+func TestQueues_Export(t *testing.T) {
+	var mutations []string
+	c := newTestClient(queueStateServer(t, &mutations))
+	path := filepath.Join(t.TempDir(), "queues.yaml")
+	if err := Queues(c, []string{"export", "--out", path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	var file queueSpecFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		t.Fatalf("parse export: %v", err)
+	}
+	if len(file.Queues) != 2 || file.Queues[0].Name != "default" || file.Queues[0].Retention != "7d" {
+		t.Errorf("exported = %+v", file.Queues)
+	}
+}
+
+func TestQueues_Apply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queues.yaml")
+	os.WriteFile(path, []byte("queues:\n  - name: default\n    concurrency: 20\n  - name: reports\n    concurrency: 2\n"), 0644)
+
+	var mutations []string
+	c := newTestClient(queueStateServer(t, &mutations))
+	if err := Queues(c, []string{"apply", "--file", path, "--dry-run", "--prune"}); err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(mutations) != 0 {
+		t.Fatalf("dry run made changes: %v", mutations)
+	}
+
+	if err := Queues(c, []string{"apply", "--file", path, "--prune", "--yes"}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	want := "PUT /ojs/v1/admin/queues/default/config,POST /ojs/v1/queues,DELETE /ojs/v1/queues/legacy"
+	if got := strings.Join(mutations, ","); got != want {
+		t.Errorf("mutations = %s, want %s", got, want)
+	}
+}
+
+func TestQueues_StatsHistory(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/queues/emails/stats/history" || r.URL.Query().Get("period") != "1h" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"period": "1h",
+			"data_points": []map[string]any{
+				{"timestamp": "10:00", "depth": 0, "enqueued": 10, "completed": 5, "failed": 0},
+				{"timestamp": "11:00", "depth": 70, "enqueued": 90, "completed": 20, "failed": 1},
+				{"timestamp": "12:00", "depth": 35, "enqueued": 30, "completed": 65, "failed": 2},
+			},
+		})
+	})
+
+	out := captureStdout(t, func() {
+		if err := Queues(c, []string{"--stats", "emails", "--history", "--period", "1h"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{`Queue "emails" history (period=1h)`, "11:00", "Depth      ▁█▄  (min 0, max 70)", "Completed  ▁▂█  (min 5, max 65)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestQueues_StatsHistoryFallback(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/history") {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"queue": "emails", "status": "active", "stats": map[string]any{"available": 4}})
+	})
+
+	out := captureStdout(t, func() {
+		if err := Queues(c, []string{"--stats", "emails", "--history"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Available") || !strings.Contains(out, "4") {
+		t.Errorf("expected the point-in-time stats as a fallback, got:\n%s", out)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{1, 2, 3, 4, 5, 6, 7, 8}); got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("sparkline = %q", got)
+	}
+	if got := sparkline([]int{3, 3, 3}); got != "▁▁▁" {
+		t.Errorf("flat sparkline = %q", got)
+	}
+}
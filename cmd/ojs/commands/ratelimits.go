@@ -2,8 +2,8 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -11,12 +11,24 @@ import (
 
 // RateLimits manages rate limit inspection and overrides.
 func RateLimits(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("rate-limits", flag.ExitOnError)
+	if len(args) > 0 && args[0] == "apply" {
+		return rateLimitsApply(c, args[1:])
+	}
+
+	fs := newFlagSet("rate-limits")
 	inspect := fs.String("inspect", "", "Inspect rate limit by key")
 	override := fs.String("override", "", "Override rate limit by key")
 	concurrency := fs.Int("concurrency", 0, "Concurrency limit (for override)")
 	clear := fs.Bool("clear", false, "Clear rate limit override")
-	fs.Parse(args)
+	ttl := fs.String("ttl", "", "Expire the override after this duration (e.g. 30m, 1h, 2d)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs rate-limits [flags]", "List and inspect rate limits and manage their overrides.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	if *override != "" {
 		if *clear {
@@ -35,6 +47,16 @@ func RateLimits(c *client.Client, args []string) error {
 		body := map[string]any{
 			"concurrency": *concurrency,
 		}
+		var expiresAt time.Time
+		if *ttl != "" {
+			d, err := parseDuration(*ttl)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --ttl %q: expected a positive duration such as 30m, 1h or 2d", *ttl)
+			}
+			expiresAt = time.Now().Add(d).UTC()
+			body["ttl"] = *ttl
+			body["expires_at"] = expiresAt.Format(time.RFC3339)
+		}
 		data, _, err := c.Put("/rate-limits/"+*override+"/override", body)
 		if err != nil {
 			return err
@@ -44,6 +66,10 @@ func RateLimits(c *client.Client, args []string) error {
 			json.Unmarshal(data, &result)
 			return output.JSON(result)
 		}
+		if *ttl != "" {
+			output.Success("Rate limit override set for %q (concurrency=%d, expires in %s at %s)", *override, *concurrency, *ttl, expiresAt.Format(time.RFC3339))
+			return nil
+		}
 		output.Success("Rate limit override set for %q (concurrency=%d)", *override, *concurrency)
 		return nil
 	}
@@ -70,6 +96,9 @@ func RateLimits(c *client.Client, args []string) error {
 		if rl["override"] != nil {
 			rows = append(rows, []string{"Override", str(rl["override"])})
 		}
+		if exp, ok := overrideExpiry(rl); ok {
+			rows = append(rows, []string{"Override Expires", describeExpiry(exp, time.Now())})
+		}
 		output.Table(headers, rows)
 		return nil
 	}
@@ -77,6 +106,17 @@ func RateLimits(c *client.Client, args []string) error {
 	return listRateLimits(c)
 }
 
+// overrideExpiry returns when a rate limit's override expires, read from
+// override_expires_at or an override object's expires_at.
+func overrideExpiry(rl map[string]any) (time.Time, bool) {
+	s, _ := rl["override_expires_at"].(string)
+	if o, ok := rl["override"].(map[string]any); ok && s == "" {
+		s, _ = o["expires_at"].(string)
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
+
 func listRateLimits(c *client.Client) error {
 	data, _, err := c.Get("/rate-limits")
 	if err != nil {
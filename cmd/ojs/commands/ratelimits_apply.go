@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+// rateLimitFile maps rate limit keys to the concurrency to override them with.
+type rateLimitFile struct {
+	RateLimits map[string]int `yaml:"rate_limits" json:"rate_limits"`
+}
+
+// rateLimitResult is the outcome of applying one override.
+type rateLimitResult struct {
+	Key         string `json:"key"`
+	Concurrency int    `json:"concurrency"`
+	Status      string `json:"status"` // "planned", "applied", "failed"
+	Error       string `json:"error,omitempty"`
+}
+
+func rateLimitsApply(c *client.Client, args []string) error {
+	fs := newFlagSet("rate-limits apply")
+	file := fs.String("file", "", "Rate limit overrides file (required)")
+	dryRun := fs.Bool("dry-run", false, "Show the overrides without applying them")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs rate-limits apply --file <limits.yaml> [flags]", "Set a concurrency override for every key listed in a file.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return fmt.Errorf("--file is required\n\nUsage: ojs rate-limits apply --file <limits.yaml> [--dry-run]")
+	}
+
+	raw, err := os.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", *file, err)
+	}
+	var desired rateLimitFile
+	if err := yaml.Unmarshal(raw, &desired); err != nil {
+		return fmt.Errorf("parse %s: %w", *file, err)
+	}
+	if len(desired.RateLimits) == 0 {
+		return fmt.Errorf("%s: no rate_limits defined", *file)
+	}
+
+	keys := make([]string, 0, len(desired.RateLimits))
+	for key, n := range desired.RateLimits {
+		if n <= 0 {
+			return fmt.Errorf("%s: rate limit %q needs a positive concurrency, got %d", *file, key, n)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	results := make([]rateLimitResult, 0, len(keys))
+	failed := 0
+	for _, key := range keys {
+		res := rateLimitResult{Key: key, Concurrency: desired.RateLimits[key], Status: "planned"}
+		if !*dryRun {
+			_, _, err := c.Put("/rate-limits/"+key+"/override", map[string]any{"concurrency": res.Concurrency})
+			if err != nil {
+				res.Status = "failed"
+				res.Error = err.Error()
+				failed++
+			} else {
+				res.Status = "applied"
+			}
+		}
+		results = append(results, res)
+	}
+
+	if output.Format == "json" {
+		if err := output.JSON(map[string]any{"dry_run": *dryRun, "results": results}); err != nil {
+			return err
+		}
+	} else {
+		headers := []string{"KEY", "CONCURRENCY", "STATUS", "ERROR"}
+		rows := make([][]string, 0, len(results))
+		for _, res := range results {
+			rows = append(rows, []string{res.Key, strconv.Itoa(res.Concurrency), res.Status, orDash(res.Error)})
+		}
+		output.Table(headers, rows)
+		if !*dryRun && failed == 0 {
+			output.Success("Applied %d rate limit override(s)", len(results))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rate limit override(s) failed", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/migrate"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// ReplayFile re-enqueues the jobs of an OJS-native NDJSON export, such as
+// one written by "ojs bulk archive".
+func ReplayFile(c *client.Client, args []string) error {
+	fs := newFlagSet("replay-file")
+	queue := fs.String("queue", "", "Enqueue every job on this queue instead of its original one")
+	priority := fs.Int("priority", -1, "Set this priority (0-10) on every job")
+	dryRun := fs.Bool("dry-run", false, "Show the jobs that would be enqueued without enqueuing them")
+	noDedup := fs.Bool("no-dedup", false, "Don't add unique keys; replaying the file twice enqueues duplicates")
+	noProgress := fs.Bool("no-progress", false, "Don't show a progress bar")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs replay-file <jobs.ndjson> [flags]", "Re-enqueue jobs from an OJS NDJSON export. Each job is deduplicated by its\nunique key, or by its original ID, unless --no-dedup is given.")
+		return nil
+	}
+
+	var file string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		file = args[0]
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+	} else {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		file = fs.Arg(0)
+	}
+	if file == "" {
+		return fmt.Errorf("file required\n\nUsage: ojs replay-file <jobs.ndjson> [--queue <queue>] [--priority <n>] [--dry-run]")
+	}
+	if *priority > 10 {
+		return fmt.Errorf("--priority must be between 0 and 10")
+	}
+
+	opts := migrate.ReplayOptions{Queue: *queue, NoDedup: *noDedup}
+	if *priority >= 0 {
+		opts.Priority = priority
+	}
+
+	if *dryRun {
+		return previewReplay(file, opts)
+	}
+
+	total, err := migrate.CountJobs(file)
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+	progress := newMigrateProgress("Replaying", total, *noProgress)
+	result, err := migrate.ReplayFile(c, file, opts, func(imported, processed int) {
+		progress.Update(processed)
+	})
+	progress.Done()
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+
+	if output.Format == "json" {
+		if err := output.JSON(result); err != nil {
+			return err
+		}
+	} else {
+		output.Success("Replay complete: %d enqueued, %d failed", result.Success, result.Failed)
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d job(s) could not be replayed", result.Failed, result.Total)
+	}
+	return nil
+}
+
+// replayPlanEntry is one line of a replay dry run.
+type replayPlanEntry struct {
+	Line      int    `json:"line"`
+	Type      string `json:"type,omitempty"`
+	Queue     string `json:"queue,omitempty"`
+	Priority  *int   `json:"priority,omitempty"`
+	UniqueKey string `json:"unique_key,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+func previewReplay(file string, opts migrate.ReplayOptions) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	var plan []replayPlanEntry
+	invalid := 0
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		job, err := migrate.ParseReplayJob(scanner.Bytes(), opts)
+		if err != nil {
+			plan = append(plan, replayPlanEntry{Line: n, Error: err.Error()})
+			invalid++
+			continue
+		}
+		plan = append(plan, replayPlanEntry{Line: n, Type: job.Type, Queue: job.Queue, Priority: job.Priority, UniqueKey: job.UniqueKey})
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	if output.Format == "json" {
+		if plan == nil {
+			plan = []replayPlanEntry{}
+		}
+		return output.JSON(map[string]any{"dry_run": true, "jobs": plan, "invalid": invalid})
+	}
+
+	rows := make([][]string, 0, len(plan))
+	for _, e := range plan {
+		if e.Error != "" {
+			rows = append(rows, []string{strconv.Itoa(e.Line), "-", "-", "-", "invalid: " + e.Error})
+			continue
+		}
+		prio := "-"
+		if e.Priority != nil {
+			prio = strconv.Itoa(*e.Priority)
+		}
+		rows = append(rows, []string{strconv.Itoa(e.Line), e.Type, e.Queue, prio, orDash(e.UniqueKey)})
+	}
+	output.Table([]string{"LINE", "TYPE", "QUEUE", "PRIORITY", "UNIQUE KEY"}, rows)
+	fmt.Printf("\nDry run: %d job(s) would be enqueued, %d invalid\n", len(plan)-invalid, invalid)
+	return nil
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const replayExport = `{"id":"j1","type":"email.send","queue":"emails","args":["a@example.com"],"priority":2}
+{"id":"j2","type":"report.build","queue":"reports","args":[42],"meta":{"tenant":"acme"}}
+`
+
+func TestReplayFile_QueueOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.ndjson")
+	os.WriteFile(path, []byte(replayExport), 0644)
+
+	var bodies []map[string]any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/ojs/v1/jobs" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	})
+
+	if err := ReplayFile(c, []string{path, "--queue", "reprocess", "--no-progress"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("enqueued %d jobs, want 2", len(bodies))
+	}
+	for i, want := range []struct{ typ, unique string }{{"email.send", "replay:j1"}, {"report.build", "replay:j2"}} {
+		opts := bodies[i]["options"].(map[string]any)
+		unique, _ := opts["unique"].(map[string]any)
+		if bodies[i]["type"] != want.typ || opts["queue"] != "reprocess" || unique["key"] != want.unique {
+			t.Errorf("job %d = %v", i, bodies[i])
+		}
+	}
+	if opts := bodies[0]["options"].(map[string]any); opts["priority"] != float64(2) {
+		t.Errorf("original priority lost: %v", opts)
+	}
+	if meta, _ := bodies[1]["meta"].(map[string]any); meta["tenant"] != "acme" {
+		t.Errorf("meta lost: %v", bodies[1])
+	}
+}
+
+func TestReplayFile_DryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.ndjson")
+	os.WriteFile(path, []byte(replayExport+"not json\n"), 0644)
+
+	c := newTestClient(noRequestClient(t))
+	out := captureStdout(t, func() {
+		if err := ReplayFile(c, []string{"--dry-run", "--queue", "reprocess", path}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var resp struct {
+		Jobs    []replayPlanEntry `json:"jobs"`
+		Invalid int               `json:"invalid"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		t.Fatalf("parse output: %v\n%s", err, out)
+	}
+	if len(resp.Jobs) != 3 || resp.Invalid != 1 || resp.Jobs[1].Queue != "reprocess" || resp.Jobs[2].Line != 3 {
+		t.Errorf("plan = %+v", resp)
+	}
+}
+
+func TestReplayFile_RequiresFile(t *testing.T) {
+	c := newTestClient(noRequestClient(t))
+	if err := ReplayFile(c, []string{"--dry-run"}); err == nil || !strings.Contains(err.Error(), "file required") {
+		t.Fatalf("err = %v, want file required", err)
+	}
+}
@@ -0,0 +1,44 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// reportNow is the clock used to name --output-dir reports. Tests pin it.
+var reportNow = time.Now
+
+// reportFileName returns "<name>-<UTC timestamp>.json". The fixed-width
+// timestamp keeps a directory of captures in time order when sorted by name.
+func reportFileName(name string, t time.Time) string {
+	return fmt.Sprintf("%s-%s.json", name, t.UTC().Format("20060102T150405Z"))
+}
+
+// saveReport writes data as indented JSON to a timestamped file in dir,
+// creating dir if needed, and reports where it went. It is the shared
+// --output-dir convention for scheduled captures of doctor, stats and
+// metrics.
+func saveReport(dir, name string, data any) error {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s report: %w", name, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, reportFileName(name, reportNow()))
+	if err := os.WriteFile(path, append(encoded, '\n'), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"file": path})
+	}
+	output.Success("Saved %s report to %s", name, path)
+	return nil
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func pinReportClock(t *testing.T) {
+	t.Helper()
+	orig := reportNow
+	reportNow = func() time.Time { return time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { reportNow = orig })
+}
+
+// readReport returns the single file written to dir, decoded as JSON.
+func readReport(t *testing.T, dir string) (string, map[string]any) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one report in %s, got %v (%v)", dir, entries, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	var report map[string]any
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("report is not JSON: %v\n%s", err, data)
+	}
+	return entries[0].Name(), report
+}
+
+func TestReportFileName(t *testing.T) {
+	at := time.Date(2026, 1, 1, 11, 0, 0, 0, time.FixedZone("CET", 3600))
+	if got := reportFileName("doctor", at); got != "doctor-20260101T100000Z.json" {
+		t.Errorf("reportFileName = %q", got)
+	}
+}
+
+func TestOutputDir_Metrics(t *testing.T) {
+	pinReportClock(t)
+	dir := filepath.Join(t.TempDir(), "reports")
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"jobs_completed_total": 42})
+	})
+	if err := Metrics(c, []string{"--output-dir", dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name, report := readReport(t, dir)
+	if name != "metrics-20260101T100000Z.json" || report["jobs_completed_total"] != float64(42) {
+		t.Errorf("report %s = %v", name, report)
+	}
+}
+
+func TestOutputDir_Stats(t *testing.T) {
+	pinReportClock(t)
+	dir := t.TempDir()
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/admin/stats" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": map[string]any{"completed": 7}})
+	})
+	if err := Stats(c, []string{"--output-dir", dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name, report := readReport(t, dir)
+	jobs, _ := report["jobs"].(map[string]any)
+	if name != "stats-20260101T100000Z.json" || jobs["completed"] != float64(7) {
+		t.Errorf("report %s = %v", name, report)
+	}
+	if err := Stats(c, []string{"--output-dir", dir, "--watch"}); err == nil {
+		t.Error("expected --output-dir with --watch to be rejected")
+	}
+}
+
+func TestOutputDir_Doctor(t *testing.T) {
+	dir := t.TempDir()
+	healthy := true
+	c := newTestClient(auditHandler(&healthy))
+	Doctor(c, []string{"--output-dir", dir})
+
+	name, report := readReport(t, dir)
+	if !regexp.MustCompile(`^doctor-\d{8}T\d{6}Z\.json$`).MatchString(name) {
+		t.Errorf("unexpected report name %q", name)
+	}
+	if report["grade"] == nil || report["categories"] == nil {
+		t.Errorf("report is missing the audit: %v", report)
+	}
+}
@@ -1,9 +1,14 @@
 package commands
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
-	"flag"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -11,10 +16,17 @@ import (
 
 // Result retrieves the result of a completed job.
 func Result(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("result", flag.ExitOnError)
-	wait := fs.Bool("wait", false, "Wait for job to complete before returning result")
+	fs := newFlagSet("result")
+	wait := fs.Bool("wait", false, "Wait for job to complete before returning result, streaming partial results if the server sends them")
 	timeout := fs.Int("timeout", 30, "Timeout in seconds when using --wait")
-	fs.Parse(args)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs result <job-id> [flags]", "Print the result of a completed job, optionally waiting for it.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	remaining := fs.Args()
 	if len(remaining) == 0 {
@@ -25,13 +37,17 @@ func Result(c *client.Client, args []string) error {
 	path := fmt.Sprintf("/jobs/%s/result", jobID)
 	if *wait {
 		path += fmt.Sprintf("?wait=true&timeout=%d", *timeout)
+		return waitForResult(c, jobID, path, time.Duration(*timeout)*time.Second)
 	}
 
 	data, _, err := c.Get(path)
 	if err != nil {
-		return err
+		return jobError(jobID, err)
 	}
+	return printJobResult(jobID, data)
+}
 
+func printJobResult(jobID string, data []byte) error {
 	if output.Format == "json" {
 		var result any
 		json.Unmarshal(data, &result)
@@ -59,3 +75,115 @@ func Result(c *client.Client, args []string) error {
 	output.Table(headers, rows)
 	return nil
 }
+
+// resultWaitGrace is how long past --timeout waitForResult keeps a result
+// stream open, so the server's own timeout answer can still arrive.
+var resultWaitGrace = 5 * time.Second
+
+// waitForResult asks for the result as an event stream so that partial
+// results are printed as they arrive. Servers that do not stream answer with
+// the final result as usual, which is printed unchanged. The stream is
+// abandoned once timeout (plus resultWaitGrace) passes.
+func waitForResult(c *client.Client, jobID, path string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+resultWaitGrace)
+	defer cancel()
+
+	resp, err := c.StreamContext(ctx, path)
+	if ctx.Err() != nil {
+		return fmt.Errorf("timed out after %s waiting for the result of job %s", timeout, jobID)
+	}
+	if client.IsStatus(err, http.StatusNotAcceptable) {
+		data, _, err := c.Get(path)
+		if err != nil {
+			return jobError(jobID, err)
+		}
+		return printJobResult(jobID, data)
+	}
+	if err != nil {
+		return jobError(jobID, err)
+	}
+	defer resp.Body.Close()
+
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("read result: %w", err)
+		}
+		return printJobResult(jobID, data)
+	}
+
+	final, err := readResultStream(resp.Body, printPartialResult)
+	if ctx.Err() != nil {
+		return fmt.Errorf("timed out after %s waiting for the result of job %s", timeout, jobID)
+	}
+	if err != nil {
+		return fmt.Errorf("read result stream: %w", err)
+	}
+	if final == nil {
+		// The stream ended before the job did; show the result as it is now.
+		data, _, err := c.Get("/jobs/" + jobID + "/result")
+		if err != nil {
+			return jobError(jobID, err)
+		}
+		final = data
+	}
+	return printJobResult(jobID, final)
+}
+
+// readResultStream reads a result event stream, passing each "partial"
+// event's chunk to partial, and returns the payload of the final "result"
+// event. Unnamed events count as the final result when they carry a state.
+// It returns nil if the stream ends without a final result.
+func readResultStream(r io.Reader, partial func(chunk any)) ([]byte, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	event, data := "", []string{}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			continue
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+			continue
+		case line != "" || len(data) == 0:
+			continue
+		}
+
+		payload := []byte(strings.Join(data, "\n"))
+		var frame map[string]any
+		json.Unmarshal(payload, &frame)
+		switch {
+		case event == "result", event == "" && frame["state"] != nil:
+			return payload, nil
+		case event == "partial", event == "":
+			chunk, ok := frame["chunk"]
+			if !ok {
+				chunk = string(payload)
+				if frame != nil {
+					chunk = frame
+				}
+			}
+			partial(chunk)
+		}
+		event, data = "", data[:0]
+	}
+	return nil, scanner.Err()
+}
+
+// printPartialResult prints one partial result chunk: text as-is, anything
+// else as compact JSON. In JSON mode each chunk is one {"partial": ...} line.
+func printPartialResult(chunk any) {
+	if output.Format == "json" {
+		line, _ := json.Marshal(map[string]any{"partial": chunk})
+		fmt.Println(string(line))
+		return
+	}
+	if text, ok := chunk.(string); ok {
+		fmt.Println(text)
+		return
+	}
+	line, _ := json.Marshal(chunk)
+	fmt.Println(string(line))
+}
@@ -10,6 +10,11 @@ import (
 
 // Retries shows the retry history for a job.
 func Retries(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs retries <job-id>", "Show the retry history of a job: each attempt with its error and timing.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("job ID required\n\nUsage: ojs retries <job-id>")
 	}
@@ -10,6 +10,11 @@ import (
 
 // Retry retries an individual job by ID.
 func Retry(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs retry <job-id>", "Retry a failed or discarded job.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("job ID required\n\nUsage: ojs retry <job-id>")
 	}
@@ -3,6 +3,7 @@ package commands
 import (
 	"encoding/json"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -27,6 +28,9 @@ func TestWebhooks_Create_MissingFields(t *testing.T) {
 
 func TestWebhooks_Create_Success(t *testing.T) {
 	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if serveEventTypes(w, r) {
+			return
+		}
 		if r.Method != http.MethodPost {
 			t.Errorf("method = %s, want POST", r.Method)
 		}
@@ -267,7 +271,7 @@ func TestBulk_Delete_ByIDs(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]any{"deleted": 2, "failed": 0})
 	})
-	err := Bulk(c, []string{"delete", "--ids", "job-1,job-2"})
+	err := Bulk(c, []string{"delete", "--ids", "job-1,job-2", "--yes"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -287,7 +291,7 @@ func TestBulk_Delete_ByState(t *testing.T) {
 		w.WriteHeader(http.StatusOK)
 		json.NewEncoder(w).Encode(map[string]any{"deleted": 50, "failed": 0})
 	})
-	err := Bulk(c, []string{"delete", "--state", "completed", "--older-than", "7d"})
+	err := Bulk(c, []string{"delete", "--state", "completed", "--older-than", "7d", "--yes"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -314,6 +318,63 @@ func TestWorkers_Detail(t *testing.T) {
 	}
 }
 
+func TestWorkers_DetailCounters(t *testing.T) {
+	withTableOutput(t)
+	for name, payload := range map[string]map[string]any{
+		"top level": {
+			"id": "wk-1", "state": "running",
+			"processed": 1520, "failed": 12, "retried": 30, "avg_duration_ms": 84.5,
+		},
+		"stats object": {
+			"id": "wk-1", "state": "running",
+			"stats": map[string]any{"processed": 1520, "failed": 12, "retried": 30, "avg_duration_ms": 84.5},
+		},
+	} {
+		c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(payload)
+		})
+		var err error
+		out := captureStdout(t, func() { err = Workers(c, []string{"--detail", "wk-1"}) })
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		for _, want := range []string{"Processed", "1520", "Failed", "12", "Retried", "30", "Avg Duration", "84.50ms"} {
+			if !strings.Contains(out, want) {
+				t.Errorf("%s: output missing %q:\n%s", name, want, out)
+			}
+		}
+	}
+}
+
+func TestWorkers_DetailCountersOmittedWhenAbsent(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"id": "wk-1", "state": "running"})
+	})
+	out := captureStdout(t, func() { Workers(c, []string{"--detail", "wk-1"}) })
+	if strings.Contains(out, "Processed") || strings.Contains(out, "Avg Duration") {
+		t.Errorf("counters shown without server data:\n%s", out)
+	}
+}
+
+func TestWorkers_DetailJSONPassesThrough(t *testing.T) {
+	payload := map[string]any{
+		"id": "wk-1", "state": "running", "labels": map[string]any{"zone": "eu-1"},
+		"stats": map[string]any{"processed": float64(1520), "history": []any{map[string]any{"hour": "10:00", "processed": float64(60)}}},
+	}
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(payload)
+	})
+	out := captureStdout(t, func() { Workers(c, []string{"--detail", "wk-1"}) })
+	var got map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if !reflect.DeepEqual(got, payload) {
+		t.Errorf("JSON output = %v, want full response %v", got, payload)
+	}
+}
+
 func TestWorkers_QuietWorker(t *testing.T) {
 	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -432,6 +493,39 @@ func TestQueues_Config_NoOptions(t *testing.T) {
 	}
 }
 
+func TestQueues_ConfigSet(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/admin/queues/default/config" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(body)
+	})
+	captureStdout(t, func() {
+		err := Queues(c, []string{"--config", "default", "--concurrency", "10",
+			"--set", "visibility_timeout=30s", "--set", "dedup_window_ms=60000",
+			"--set", "fifo=true", "--set", "concurrency=20", "--set", "backoff_factor=1.5"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	want := map[string]any{
+		"concurrency":        float64(20),
+		"visibility_timeout": "30s",
+		"dedup_window_ms":    float64(60000),
+		"fifo":               true,
+		"backoff_factor":     1.5,
+	}
+	if !reflect.DeepEqual(body, want) {
+		t.Errorf("body = %v, want %v", body, want)
+	}
+
+	if err := Queues(c, []string{"--config", "default", "--set", "fifo"}); err == nil || !strings.Contains(err.Error(), "expected key=value") {
+		t.Errorf("err = %v, want key=value error", err)
+	}
+}
+
 // --- Status --detail tests ---
 
 func TestStatus_Detail(t *testing.T) {
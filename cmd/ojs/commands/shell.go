@@ -0,0 +1,380 @@
+package commands
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/config"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// Dispatcher runs one top-level command. args[0] is the command name, as in
+// os.Args[1:].
+type Dispatcher func(c *client.Client, args []string) error
+
+// shellBuiltins are handled by the shell itself rather than dispatched.
+var shellBuiltins = []string{"exit", "quit", "help", "history", "json", "url"}
+
+// shellSubcommands maps commands with subcommands to their completion tables.
+var shellSubcommands = map[string]map[string][]string{
+	"workflow": workflowSubcommands,
+	"bulk":     bulkSubcommands,
+	"system":   systemSubcommands,
+	"webhooks": webhooksSubcommands,
+	"template": templateSubcommands,
+}
+
+const shellHelp = `Enter commands without the "ojs" prefix, e.g. "status <job-id>".
+
+Built-ins:
+  json [on|off]  Show or toggle JSON output for the session
+  url [<url>]    Show or change the server URL
+  history        Show command history (!! repeats the last, !N repeats entry N)
+  help           Show this help
+  exit, quit     Leave the shell
+
+End a partial line with Tab then Enter to list completions.
+`
+
+// Shell starts an interactive session that runs commands against one shared
+// client. Output format and server URL persist across commands.
+func Shell(cfg *config.Config, c *client.Client, dispatch Dispatcher, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs shell", "Start an interactive shell that runs ojs commands over one connection.\nBuilt-ins: help, history, !!, !N, json [on|off], url [<url>], exit.")
+		return nil
+	}
+
+	sh := &shell{
+		cfg:      cfg,
+		client:   c,
+		dispatch: dispatch,
+		in:       bufio.NewScanner(os.Stdin),
+		out:      os.Stdout,
+		histPath: shellHistoryPath(),
+	}
+	sh.history = loadShellHistory(sh.histPath)
+
+	// Ctrl-C interrupts the running command (those that watch for it) but
+	// must not end the session.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	fmt.Fprintf(sh.out, "ojs shell connected to %s. Type \"help\" for built-ins, \"exit\" to leave.\n", cfg.ServerURL)
+	return sh.run()
+}
+
+type shell struct {
+	cfg      *config.Config
+	client   *client.Client
+	dispatch Dispatcher
+	in       *bufio.Scanner
+	out      io.Writer
+	history  []string
+	histPath string
+}
+
+func (sh *shell) run() error {
+	// A mistyped flag must end the command, not the session.
+	prev := flagErrorHandling
+	flagErrorHandling = flag.ContinueOnError
+	defer func() { flagErrorHandling = prev }()
+
+	for {
+		fmt.Fprint(sh.out, "ojs> ")
+		if !sh.in.Scan() {
+			fmt.Fprintln(sh.out)
+			return sh.in.Err()
+		}
+		line := sh.in.Text()
+
+		if prefix, ok := strings.CutSuffix(strings.TrimRight(line, " "), "\t"); ok {
+			if candidates := shellCompletions(prefix); len(candidates) > 0 {
+				fmt.Fprintln(sh.out, strings.Join(candidates, "  "))
+			}
+			continue
+		}
+
+		line, err := sh.expandHistory(strings.TrimSpace(line))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		sh.remember(line)
+
+		args, err := splitShellLine(line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		if args[0] == "exit" || args[0] == "quit" {
+			return nil
+		}
+		if err := sh.exec(args); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	}
+}
+
+// exec runs a built-in or dispatches a command. A --json or --wide on the
+// line applies to that command only.
+func (sh *shell) exec(args []string) error {
+	switch args[0] {
+	case "help":
+		fmt.Fprint(sh.out, shellHelp)
+		return nil
+	case "history":
+		for i, h := range sh.history {
+			fmt.Fprintf(sh.out, "%5d  %s\n", i+1, h)
+		}
+		return nil
+	case "json":
+		return sh.setJSON(args[1:])
+	case "url":
+		return sh.setURL(args[1:])
+	case "shell":
+		return fmt.Errorf("already in the shell")
+	}
+
+	rest := make([]string, 0, len(args))
+	asJSON, wide := false, false
+	for _, a := range args {
+		switch a {
+		case "--json":
+			asJSON = true
+		case "--wide":
+			wide = true
+		default:
+			rest = append(rest, a)
+		}
+	}
+	if asJSON {
+		prev := output.Format
+		output.Format = "json"
+		defer func() { output.Format = prev }()
+	}
+	if wide {
+		prev := output.Wide
+		output.Wide = true
+		defer func() { output.Wide = prev }()
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	return sh.dispatch(sh.client, rest)
+}
+
+func (sh *shell) setJSON(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintf(sh.out, "json output: %v\n", output.Format == "json")
+		return nil
+	}
+	switch args[0] {
+	case "on", "true":
+		output.Format = "json"
+	case "off", "false":
+		output.Format = "table"
+	default:
+		return fmt.Errorf("usage: json [on|off]")
+	}
+	return nil
+}
+
+func (sh *shell) setURL(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(sh.out, sh.cfg.ServerURL)
+		return nil
+	}
+	prev := sh.cfg.ServerURL
+	sh.cfg.ServerURL = strings.TrimRight(args[0], "/")
+	c := client.New(sh.cfg)
+	if err := c.Err(); err != nil {
+		sh.cfg.ServerURL = prev
+		return err
+	}
+	sh.client = c
+	fmt.Fprintf(sh.out, "Now connected to %s\n", sh.cfg.ServerURL)
+	return nil
+}
+
+// expandHistory replaces "!!" and "!N" with the matching history entry.
+func (sh *shell) expandHistory(line string) (string, error) {
+	if !strings.HasPrefix(line, "!") || len(line) < 2 {
+		return line, nil
+	}
+	if line == "!!" {
+		if len(sh.history) == 0 {
+			return "", fmt.Errorf("no history")
+		}
+		line = sh.history[len(sh.history)-1]
+	} else {
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n < 1 || n > len(sh.history) {
+			return "", fmt.Errorf("%s: event not found", line)
+		}
+		line = sh.history[n-1]
+	}
+	fmt.Fprintln(sh.out, line)
+	return line, nil
+}
+
+func (sh *shell) remember(line string) {
+	if n := len(sh.history); n > 0 && sh.history[n-1] == line {
+		return
+	}
+	sh.history = append(sh.history, line)
+	if sh.histPath == "" {
+		return
+	}
+	f, err := os.OpenFile(sh.histPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, line)
+}
+
+// maxShellHistory caps how many past lines are loaded into a new session.
+const maxShellHistory = 500
+
+func shellHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ojs_history")
+}
+
+func loadShellHistory(path string) []string {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var lines []string
+	for _, l := range strings.Split(string(data), "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	if len(lines) > maxShellHistory {
+		lines = lines[len(lines)-maxShellHistory:]
+	}
+	return lines
+}
+
+// splitShellLine splits a REPL input line into arguments the way a POSIX
+// shell would: whitespace separates words, single quotes are literal, double
+// quotes allow \" and \\ escapes, and a backslash outside quotes escapes the
+// next character. A leading "ojs" is dropped so pasted commands work as-is.
+func splitShellLine(line string) ([]string, error) {
+	var (
+		args    []string
+		cur     strings.Builder
+		inWord  bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				cur.WriteRune(r)
+			}
+		case r == '\\':
+			escaped, inWord = true, true
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				args = append(args, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, fmt.Errorf("trailing backslash")
+	}
+	if inWord {
+		args = append(args, cur.String())
+	}
+	if len(args) > 0 && args[0] == "ojs" {
+		args = args[1:]
+	}
+	return args, nil
+}
+
+// shellCompletions returns the candidates for the last word of a partial
+// line, using the same command, subcommand and flag tables as the shell
+// completion scripts.
+func shellCompletions(partial string) []string {
+	words := strings.Fields(partial)
+	current := ""
+	if len(words) > 0 && !strings.HasSuffix(partial, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	var pool []string
+	switch {
+	case len(words) == 0:
+		pool = append(commandNames(), shellBuiltins...)
+	case strings.HasPrefix(current, "-"):
+		if subs, ok := shellSubcommands[words[0]]; ok && len(words) > 1 {
+			pool = subs[words[1]]
+		} else {
+			pool = commands[words[0]]
+		}
+	case len(words) == 1:
+		for name := range shellSubcommands[words[0]] {
+			pool = append(pool, name)
+		}
+	}
+
+	var out []string
+	for _, p := range pool {
+		if strings.HasPrefix(p, current) {
+			out = append(out, p)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/config"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+func TestSplitShellLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"status job-1", []string{"status", "job-1"}},
+		{"  queues   --stats  ", []string{"queues", "--stats"}},
+		{`enqueue --type email.send --args '["a@b.c", "Hi there"]'`,
+			[]string{"enqueue", "--type", "email.send", "--args", `["a@b.c", "Hi there"]`}},
+		{`system maintenance --enable --reason "db \"upgrade\""`,
+			[]string{"system", "maintenance", "--enable", "--reason", `db "upgrade"`}},
+		{`cron --name nightly\ report`, []string{"cron", "--name", "nightly report"}},
+		{`enqueue --meta ''`, []string{"enqueue", "--meta", ""}},
+		{"ojs health", []string{"health"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		got, err := splitShellLine(tt.line)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestSplitShellLine_Errors(t *testing.T) {
+	for _, line := range []string{`status "job-1`, `--args '[1`, `status job\`} {
+		if _, err := splitShellLine(line); err == nil {
+			t.Errorf("%q: expected error", line)
+		}
+	}
+}
+
+func TestShellCompletions(t *testing.T) {
+	if got := shellCompletions("st"); !reflect.DeepEqual(got, []string{"stats", "status"}) {
+		t.Errorf("command completion: %v", got)
+	}
+	if got := shellCompletions("system d"); !reflect.DeepEqual(got, []string{"drain"}) {
+		t.Errorf("subcommand completion: %v", got)
+	}
+	if got := shellCompletions("logs job-1 --f"); !reflect.DeepEqual(got, []string{"--follow"}) {
+		t.Errorf("flag completion: %v", got)
+	}
+	if got := shellCompletions("system drain --no"); !reflect.DeepEqual(got, []string{"--no-maintenance"}) {
+		t.Errorf("subcommand flag completion: %v", got)
+	}
+	if got := shellCompletions("ex"); !reflect.DeepEqual(got, []string{"exit"}) {
+		t.Errorf("builtin completion: %v", got)
+	}
+}
+
+func TestShell_SessionState(t *testing.T) {
+	withTableOutput(t)
+	var calls [][]string
+	var formats []string
+	var clients []*client.Client
+	cfg := &config.Config{ServerURL: "http://one:8080"}
+	c := client.New(cfg)
+
+	var out bytes.Buffer
+	sh := &shell{
+		cfg:    cfg,
+		client: c,
+		dispatch: func(c *client.Client, args []string) error {
+			calls = append(calls, args)
+			formats = append(formats, output.Format)
+			clients = append(clients, c)
+			return nil
+		},
+		in:  bufio.NewScanner(strings.NewReader("health\n--json status job-1\nstatus job-2\njson on\nurl http://two:8080\n!!\n!1\nexit\nhealth\n")),
+		out: &out,
+	}
+	if err := sh.run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{{"health"}, {"status", "job-1"}, {"status", "job-2"}, {"health"}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Fatalf("dispatched %q, want %q", calls, want)
+	}
+	if !reflect.DeepEqual(formats, []string{"table", "json", "table", "json"}) {
+		t.Errorf("unexpected output formats %v", formats)
+	}
+	if clients[0] != c || clients[3] == c || cfg.ServerURL != "http://two:8080" {
+		t.Error("expected url to reconnect the shared client")
+	}
+	if !strings.Contains(out.String(), "Now connected to http://two:8080") {
+		t.Errorf("unexpected shell output:\n%s", out.String())
+	}
+}
+
+func TestShell_BadFlagKeepsSession(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+	var errs []error
+	sh := &shell{
+		cfg:    &config.Config{},
+		client: c,
+		dispatch: func(c *client.Client, args []string) error {
+			err := Health(c, args[1:])
+			errs = append(errs, err)
+			return err
+		},
+		in:  bufio.NewScanner(strings.NewReader("health --bogus\nhealth\nexit\n")),
+		out: &bytes.Buffer{},
+	}
+	captureStdout(t, func() {
+		if err := sh.run(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if len(errs) != 2 || errs[0] == nil || !strings.Contains(errs[0].Error(), "bogus") || errs[1] != nil {
+		t.Errorf("expected the bad flag to fail only its own command, got %v", errs)
+	}
+	if flagErrorHandling != flag.ExitOnError {
+		t.Error("expected the shell to restore exit-on-error flag handling")
+	}
+}
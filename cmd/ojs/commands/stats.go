@@ -2,8 +2,8 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -11,27 +11,107 @@ import (
 
 // Stats shows aggregate system statistics.
 func Stats(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	fs := newFlagSet("stats")
 	history := fs.Bool("history", false, "Show historical time-series statistics")
 	period := fs.String("period", "1h", "Aggregation period for history (5m, 1h, 1d)")
 	since := fs.String("since", "", "Start time for history (e.g. 2024-01-01T00:00:00Z or 24h)")
 	queue := fs.String("queue", "", "Filter stats by queue name")
-	fs.Parse(args)
+	top := fs.String("top", "", "Show the busiest queues or types (queues|types)")
+	by := fs.String("by", "throughput", "Rank --top entries by throughput or depth")
+	limit := fs.Int("limit", 10, "Number of --top entries to show")
+	watch := fs.Bool("watch", false, "Continuously refresh the overview")
+	interval := fs.Int("interval", 5, "Refresh interval in seconds for --watch")
+	alerts := fs.Bool("alerts", false, "Show current SLO violations and anomalies")
+	outputDir := fs.String("output-dir", "", "Save the overview as JSON to a timestamped file in this directory")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs stats [flags]", "Show aggregate system statistics, history, top queues and alerts.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *outputDir != "" {
+		if *alerts || *top != "" || *history || *watch {
+			return fmt.Errorf("--output-dir only applies to the stats overview, not --alerts, --top, --history or --watch")
+		}
+		_, data, err := fetchStatsOverview(c, *queue)
+		if err != nil {
+			return err
+		}
+		return saveReport(*outputDir, "stats", json.RawMessage(data))
+	}
+
+	if *alerts {
+		return statsAlerts(c)
+	}
+
+	if *top != "" {
+		return statsTop(c, *top, *by, *limit)
+	}
 
 	if *history {
 		return statsHistory(c, *period, *since, *queue)
 	}
 
+	if *watch {
+		if *interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		return statsWatch(c, *queue, time.Duration(*interval)*time.Second)
+	}
+
 	return statsOverview(c, *queue)
 }
 
-func statsOverview(c *client.Client, queue string) error {
+// statsSnapshot is the overview returned by /admin/stats.
+type statsSnapshot struct {
+	Queues struct {
+		Total  int `json:"total"`
+		Active int `json:"active"`
+		Paused int `json:"paused"`
+	} `json:"queues"`
+	Workers struct {
+		Total   int `json:"total"`
+		Running int `json:"running"`
+		Quiet   int `json:"quiet"`
+		Stale   int `json:"stale"`
+	} `json:"workers"`
+	Jobs struct {
+		Available int `json:"available"`
+		Active    int `json:"active"`
+		Completed int `json:"completed"`
+		Retryable int `json:"retryable"`
+		Scheduled int `json:"scheduled"`
+		Discarded int `json:"discarded"`
+		Cancelled int `json:"cancelled"`
+	} `json:"jobs"`
+	Throughput struct {
+		EnqueuedPerMin int     `json:"enqueued_per_min"`
+		CompletedPerMin int    `json:"completed_per_min"`
+		FailedPerMin   int     `json:"failed_per_min"`
+		AvgLatencyMs   float64 `json:"avg_latency_ms"`
+	} `json:"throughput"`
+}
+
+func fetchStatsOverview(c *client.Client, queue string) (*statsSnapshot, []byte, error) {
 	path := "/admin/stats"
 	if queue != "" {
 		path += "?queue=" + queue
 	}
 
 	data, _, err := c.Get(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var s statsSnapshot
+	json.Unmarshal(data, &s)
+	return &s, data, nil
+}
+
+func statsOverview(c *client.Client, queue string) error {
+	s, data, err := fetchStatsOverview(c, queue)
 	if err != nil {
 		return err
 	}
@@ -42,81 +122,55 @@ func statsOverview(c *client.Client, queue string) error {
 		return output.JSON(result)
 	}
 
-	var resp struct {
-		Queues struct {
-			Total  int `json:"total"`
-			Active int `json:"active"`
-			Paused int `json:"paused"`
-		} `json:"queues"`
-		Workers struct {
-			Total   int `json:"total"`
-			Running int `json:"running"`
-			Quiet   int `json:"quiet"`
-			Stale   int `json:"stale"`
-		} `json:"workers"`
-		Jobs struct {
-			Available int `json:"available"`
-			Active    int `json:"active"`
-			Completed int `json:"completed"`
-			Retryable int `json:"retryable"`
-			Scheduled int `json:"scheduled"`
-			Discarded int `json:"discarded"`
-			Cancelled int `json:"cancelled"`
-		} `json:"jobs"`
-		Throughput struct {
-			EnqueuedPerMin int     `json:"enqueued_per_min"`
-			CompletedPerMin int    `json:"completed_per_min"`
-			FailedPerMin   int     `json:"failed_per_min"`
-			AvgLatencyMs   float64 `json:"avg_latency_ms"`
-		} `json:"throughput"`
-	}
-	json.Unmarshal(data, &resp)
+	printStatsOverview(s)
+	return nil
+}
 
+func printStatsOverview(s *statsSnapshot) {
 	fmt.Println("System Statistics")
 	fmt.Println()
 
 	fmt.Println("Queues:")
 	headers := []string{"METRIC", "VALUE"}
 	rows := [][]string{
-		{"Total", fmt.Sprintf("%d", resp.Queues.Total)},
-		{"Active", fmt.Sprintf("%d", resp.Queues.Active)},
-		{"Paused", fmt.Sprintf("%d", resp.Queues.Paused)},
+		{"Total", fmt.Sprintf("%d", s.Queues.Total)},
+		{"Active", fmt.Sprintf("%d", s.Queues.Active)},
+		{"Paused", fmt.Sprintf("%d", s.Queues.Paused)},
 	}
 	output.Table(headers, rows)
 	fmt.Println()
 
 	fmt.Println("Workers:")
 	rows = [][]string{
-		{"Total", fmt.Sprintf("%d", resp.Workers.Total)},
-		{"Running", fmt.Sprintf("%d", resp.Workers.Running)},
-		{"Quiet", fmt.Sprintf("%d", resp.Workers.Quiet)},
-		{"Stale", fmt.Sprintf("%d", resp.Workers.Stale)},
+		{"Total", fmt.Sprintf("%d", s.Workers.Total)},
+		{"Running", fmt.Sprintf("%d", s.Workers.Running)},
+		{"Quiet", fmt.Sprintf("%d", s.Workers.Quiet)},
+		{"Stale", fmt.Sprintf("%d", s.Workers.Stale)},
 	}
 	output.Table(headers, rows)
 	fmt.Println()
 
 	fmt.Println("Jobs:")
 	rows = [][]string{
-		{"Available", fmt.Sprintf("%d", resp.Jobs.Available)},
-		{"Active", fmt.Sprintf("%d", resp.Jobs.Active)},
-		{"Completed", fmt.Sprintf("%d", resp.Jobs.Completed)},
-		{"Retryable", fmt.Sprintf("%d", resp.Jobs.Retryable)},
-		{"Scheduled", fmt.Sprintf("%d", resp.Jobs.Scheduled)},
-		{"Discarded", fmt.Sprintf("%d", resp.Jobs.Discarded)},
-		{"Cancelled", fmt.Sprintf("%d", resp.Jobs.Cancelled)},
+		{"Available", fmt.Sprintf("%d", s.Jobs.Available)},
+		{"Active", fmt.Sprintf("%d", s.Jobs.Active)},
+		{"Completed", fmt.Sprintf("%d", s.Jobs.Completed)},
+		{"Retryable", fmt.Sprintf("%d", s.Jobs.Retryable)},
+		{"Scheduled", fmt.Sprintf("%d", s.Jobs.Scheduled)},
+		{"Discarded", fmt.Sprintf("%d", s.Jobs.Discarded)},
+		{"Cancelled", fmt.Sprintf("%d", s.Jobs.Cancelled)},
 	}
 	output.Table(headers, rows)
 	fmt.Println()
 
 	fmt.Println("Throughput:")
 	rows = [][]string{
-		{"Enqueued/min", fmt.Sprintf("%d", resp.Throughput.EnqueuedPerMin)},
-		{"Completed/min", fmt.Sprintf("%d", resp.Throughput.CompletedPerMin)},
-		{"Failed/min", fmt.Sprintf("%d", resp.Throughput.FailedPerMin)},
-		{"Avg Latency", fmt.Sprintf("%.2fms", resp.Throughput.AvgLatencyMs)},
+		{"Enqueued/min", fmt.Sprintf("%d", s.Throughput.EnqueuedPerMin)},
+		{"Completed/min", fmt.Sprintf("%d", s.Throughput.CompletedPerMin)},
+		{"Failed/min", fmt.Sprintf("%d", s.Throughput.FailedPerMin)},
+		{"Avg Latency", fmt.Sprintf("%.2fms", s.Throughput.AvgLatencyMs)},
 	}
 	output.Table(headers, rows)
-	return nil
 }
 
 func statsHistory(c *client.Client, period, since, queue string) error {
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// observabilityHealth is the subset of /admin/observability/health used for
+// the alert summary.
+type observabilityHealth struct {
+	Score         float64 `json:"score"`
+	Status        string  `json:"status"`
+	SLOViolations []struct {
+		SLO      string  `json:"slo"`
+		Severity string  `json:"severity"`
+		Message  string  `json:"message"`
+		Current  float64 `json:"current"`
+		Target   float64 `json:"target"`
+	} `json:"violations"`
+	Anomalies []struct {
+		Metric     string `json:"metric"`
+		Severity   string `json:"severity"`
+		Message    string `json:"message"`
+		DetectedAt string `json:"detected_at"`
+	} `json:"anomalies"`
+}
+
+// statsAlerts lists current SLO violations and anomalies. It returns an
+// error when any anomaly is critical so scripts can alert on the exit code.
+func statsAlerts(c *client.Client) error {
+	data, _, err := c.Get("/admin/observability/health")
+	if err != nil {
+		return err
+	}
+	var health observabilityHealth
+	if err := json.Unmarshal(data, &health); err != nil {
+		return fmt.Errorf("parse health response: %w", err)
+	}
+
+	critical := 0
+	for _, a := range health.Anomalies {
+		if strings.EqualFold(a.Severity, "critical") {
+			critical++
+		}
+	}
+
+	if output.Format == "json" {
+		var result any
+		json.Unmarshal(data, &result)
+		if err := output.JSON(result); err != nil {
+			return err
+		}
+	} else {
+		printStatsAlerts(&health)
+	}
+
+	if critical > 0 {
+		return fmt.Errorf("%d critical anomaly(s) detected", critical)
+	}
+	return nil
+}
+
+func printStatsAlerts(h *observabilityHealth) {
+	if h.Status != "" {
+		fmt.Printf("System Health: %.0f/100 (%s)\n\n", h.Score, strings.ToUpper(h.Status))
+	}
+	if len(h.SLOViolations) == 0 && len(h.Anomalies) == 0 {
+		output.Success("No SLO violations or anomalies")
+		return
+	}
+
+	headers := []string{"SEVERITY", "KIND", "NAME", "DETAILS"}
+	var rows [][]string
+	for _, v := range h.SLOViolations {
+		details := v.Message
+		if details == "" {
+			details = fmt.Sprintf("current %g, target %g", v.Current, v.Target)
+		}
+		rows = append(rows, []string{orDash(v.Severity), "slo", v.SLO, details})
+	}
+	for _, a := range h.Anomalies {
+		details := a.Message
+		if a.DetectedAt != "" {
+			details += " (since " + a.DetectedAt + ")"
+		}
+		rows = append(rows, []string{orDash(a.Severity), "anomaly", a.Metric, strings.TrimSpace(details)})
+	}
+	output.Table(headers, rows)
+}
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTopHotspots(t *testing.T) {
+	items := []hotspot{
+		{Name: "email", Depth: 5, ThroughputPerMin: 120},
+		{Name: "billing", Depth: 900, ThroughputPerMin: 30},
+		{Name: "reports", Depth: 40, ThroughputPerMin: 120},
+		{Name: "default", Depth: 0, ThroughputPerMin: 0},
+	}
+
+	byThroughput := topHotspots(items, "throughput", 3)
+	want := []string{"reports", "email", "billing"}
+	if len(byThroughput) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(byThroughput))
+	}
+	for i, name := range want {
+		if byThroughput[i].Name != name {
+			t.Errorf("throughput[%d] = %s, want %s", i, byThroughput[i].Name, name)
+		}
+	}
+
+	byDepth := topHotspots(items, "depth", 2)
+	if byDepth[0].Name != "billing" || byDepth[1].Name != "reports" {
+		t.Errorf("unexpected depth ordering: %+v", byDepth)
+	}
+
+	if all := topHotspots(items, "throughput", 0); len(all) != 4 {
+		t.Errorf("limit 0 should return all entries, got %d", len(all))
+	}
+	if items[0].Name != "email" {
+		t.Error("topHotspots must not reorder its input")
+	}
+}
+
+func TestStats_Top(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/admin/stats" || r.URL.Query().Get("detail") != "true" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"types": []map[string]any{
+				{"name": "email.send", "depth": 3, "throughput_per_min": 10},
+				{"name": "report.build", "depth": 50, "throughput_per_min": 80},
+			},
+		})
+	})
+
+	out := captureStdout(t, func() {
+		if err := Stats(c, []string{"--top", "types", "--limit", "1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result struct {
+		Types []hotspot `json:"types"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.Types) != 1 || result.Types[0].Name != "report.build" {
+		t.Errorf("expected report.build on top, got %+v", result.Types)
+	}
+}
+
+func TestStats_TopInvalid(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {})
+	if err := Stats(c, []string{"--top", "workers"}); err == nil {
+		t.Error("expected error for unsupported --top value")
+	}
+	if err := Stats(c, []string{"--top", "queues", "--by", "latency"}); err == nil {
+		t.Error("expected error for unsupported --by value")
+	}
+}
+
+func TestStatsDelta(t *testing.T) {
+	prev := &statsSnapshot{}
+	prev.Jobs.Completed = 100
+	prev.Jobs.Discarded = 4
+	prev.Jobs.Cancelled = 1
+
+	cur := &statsSnapshot{}
+	cur.Jobs.Completed = 150
+	cur.Jobs.Discarded = 6
+	cur.Jobs.Cancelled = 1
+
+	d := statsDelta(prev, cur, 5*time.Second)
+	if d.Completed != 50 || d.Discarded != 2 || d.Cancelled != 0 {
+		t.Errorf("unexpected counts: %+v", d)
+	}
+	if d.CompletedPerSec != 10 || d.DiscardedPerSec != 0.4 {
+		t.Errorf("unexpected rates: %+v", d)
+	}
+}
+
+func TestStatsDelta_CounterReset(t *testing.T) {
+	prev := &statsSnapshot{}
+	prev.Jobs.Completed = 1000
+	cur := &statsSnapshot{}
+	cur.Jobs.Completed = 20
+
+	if d := statsDelta(prev, cur, 10*time.Second); d.Completed != 20 {
+		t.Errorf("expected reset counter to count from zero, got %d", d.Completed)
+	}
+	if d := statsDelta(prev, prev, 0); d.CompletedPerSec != 0 {
+		t.Errorf("expected zero rate for zero elapsed, got %v", d.CompletedPerSec)
+	}
+}
+
+const alertsHealth = `{
+	"score": 62, "status": "degraded",
+	"violations": [{"slo": "p99_latency", "severity": "warning", "current": 1200, "target": 500}],
+	"anomalies": [
+		{"metric": "failure_rate", "severity": "critical", "message": "failure rate 5x baseline"},
+		{"metric": "queue_depth", "severity": "warning", "message": "billing depth rising"}
+	]
+}`
+
+func TestStats_Alerts(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/admin/observability/health" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(alertsHealth))
+	})
+
+	var err error
+	out := captureStdout(t, func() {
+		err = Stats(c, []string{"--alerts"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 critical") {
+		t.Errorf("expected critical anomaly error, got %v", err)
+	}
+	for _, want := range []string{"DEGRADED", "p99_latency", "current 1200, target 500", "failure_rate", "critical", "queue_depth"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStats_AlertsNoCritical(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"score": 90, "status": "degraded", "violations": [{"slo": "availability", "severity": "critical"}], "anomalies": [{"metric": "latency", "severity": "warning"}]}`))
+	})
+
+	out := captureStdout(t, func() {
+		if err := Stats(c, []string{"--alerts"}); err != nil {
+			t.Errorf("only critical anomalies should fail, got %v", err)
+		}
+	})
+	var result observabilityHealth
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.SLOViolations) != 1 || len(result.Anomalies) != 1 {
+		t.Errorf("unexpected alerts: %+v", result)
+	}
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// hotspot is a queue or job type entry from the detailed stats response,
+// the same shape debug bottleneck reads.
+type hotspot struct {
+	Name             string  `json:"name"`
+	Depth            int     `json:"depth"`
+	ThroughputPerMin float64 `json:"throughput_per_min"`
+	AvgDurationMs    float64 `json:"avg_duration_ms"`
+	ErrorRate        float64 `json:"error_rate"`
+}
+
+// statsTop prints the busiest queues or job types.
+func statsTop(c *client.Client, kind, by string, limit int) error {
+	if kind != "queues" && kind != "types" {
+		return fmt.Errorf("invalid --top value %q\n\nUsage: ojs stats --top queues|types [--by throughput|depth] [--limit 10]", kind)
+	}
+	if by != "throughput" && by != "depth" {
+		return fmt.Errorf("invalid --by value %q (expected throughput or depth)", by)
+	}
+
+	q := url.Values{}
+	q.Set("detail", "true")
+	data, _, err := c.Get("/admin/stats?" + q.Encode())
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Queues []hotspot `json:"queues"`
+		Types  []hotspot `json:"types"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return fmt.Errorf("parse stats response: %w", err)
+	}
+	items := resp.Queues
+	if kind == "types" {
+		items = resp.Types
+	}
+	top := topHotspots(items, by, limit)
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{kind: top, "by": by})
+	}
+	if len(top) == 0 {
+		fmt.Printf("No %s activity reported.\n", kind)
+		return nil
+	}
+
+	label := "QUEUE"
+	if kind == "types" {
+		label = "TYPE"
+	}
+	headers := []string{"#", label, "THROUGHPUT/MIN", "DEPTH", "AVG DURATION", "ERROR RATE"}
+	rows := make([][]string, 0, len(top))
+	for i, h := range top {
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			h.Name,
+			fmt.Sprintf("%.1f", h.ThroughputPerMin),
+			fmt.Sprintf("%d", h.Depth),
+			fmt.Sprintf("%.1fms", h.AvgDurationMs),
+			fmt.Sprintf("%.1f%%", h.ErrorRate*100),
+		})
+	}
+	output.Table(headers, rows)
+	return nil
+}
+
+// topHotspots returns up to limit entries ordered by the chosen metric,
+// breaking ties on the other metric and then by name.
+func topHotspots(items []hotspot, by string, limit int) []hotspot {
+	sorted := append([]hotspot(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if by == "depth" {
+			if a.Depth != b.Depth {
+				return a.Depth > b.Depth
+			}
+			if a.ThroughputPerMin != b.ThroughputPerMin {
+				return a.ThroughputPerMin > b.ThroughputPerMin
+			}
+		} else {
+			if a.ThroughputPerMin != b.ThroughputPerMin {
+				return a.ThroughputPerMin > b.ThroughputPerMin
+			}
+			if a.Depth != b.Depth {
+				return a.Depth > b.Depth
+			}
+		}
+		return a.Name < b.Name
+	})
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	if sorted == nil {
+		sorted = []hotspot{}
+	}
+	return sorted
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// statsInterval is the job activity between two overview fetches.
+type statsInterval struct {
+	Seconds         float64 `json:"seconds"`
+	Completed       int     `json:"completed"`
+	Discarded       int     `json:"discarded"`
+	Cancelled       int     `json:"cancelled"`
+	CompletedPerSec float64 `json:"completed_per_sec"`
+	DiscardedPerSec float64 `json:"discarded_per_sec"`
+}
+
+// statsDelta computes the activity between prev and cur. A counter that went
+// backwards (e.g. after a server restart) counts from zero.
+func statsDelta(prev, cur *statsSnapshot, elapsed time.Duration) statsInterval {
+	counter := func(before, after int) int {
+		if after < before {
+			return after
+		}
+		return after - before
+	}
+	d := statsInterval{
+		Seconds:   elapsed.Seconds(),
+		Completed: counter(prev.Jobs.Completed, cur.Jobs.Completed),
+		Discarded: counter(prev.Jobs.Discarded, cur.Jobs.Discarded),
+		Cancelled: counter(prev.Jobs.Cancelled, cur.Jobs.Cancelled),
+	}
+	if d.Seconds > 0 {
+		d.CompletedPerSec = float64(d.Completed) / d.Seconds
+		d.DiscardedPerSec = float64(d.Discarded) / d.Seconds
+	}
+	return d
+}
+
+// statsWatch re-fetches the overview every interval and redraws it in
+// place until interrupted.
+func statsWatch(c *client.Client, queue string, interval time.Duration) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var prev *statsSnapshot
+	var prevAt time.Time
+	for {
+		cur, data, err := fetchStatsOverview(c, queue)
+		now := time.Now()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ refresh error: %v\n", err)
+		} else {
+			var delta *statsInterval
+			if prev != nil {
+				d := statsDelta(prev, cur, now.Sub(prevAt))
+				delta = &d
+			}
+			if output.Format == "json" {
+				var result any
+				json.Unmarshal(data, &result)
+				output.JSON(map[string]any{"timestamp": now.UTC().Format(time.RFC3339), "stats": result, "interval": delta})
+			} else {
+				renderStatsWatch(cur, delta, now)
+			}
+			prev, prevAt = cur, now
+		}
+
+		select {
+		case <-ticker.C:
+		case <-sigCh:
+			fmt.Println("\nStats watch stopped.")
+			return nil
+		}
+	}
+}
+
+func renderStatsWatch(s *statsSnapshot, delta *statsInterval, now time.Time) {
+	fmt.Print("\033[2J\033[H")
+	fmt.Printf("Updated %s (Ctrl-C to stop)\n\n", now.Format("15:04:05"))
+	printStatsOverview(s)
+	fmt.Println()
+
+	fmt.Println("This interval:")
+	if delta == nil {
+		fmt.Println("  (waiting for the next refresh)")
+		return
+	}
+	output.Table([]string{"METRIC", "VALUE"}, [][]string{
+		{"Completed", fmt.Sprintf("%d (%.2f/s)", delta.Completed, delta.CompletedPerSec)},
+		{"Discarded", fmt.Sprintf("%d (%.2f/s)", delta.Discarded, delta.DiscardedPerSec)},
+		{"Cancelled", fmt.Sprintf("%d", delta.Cancelled)},
+	})
+}
@@ -2,22 +2,34 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/jobstate"
 	"github.com/openjobspec/ojs-cli/internal/output"
 )
 
 // Status retrieves the status of a job.
 func Status(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	fs := newFlagSet("status")
 	detail := fs.Bool("detail", false, "Show full job envelope with args, meta, and errors")
-	fs.Parse(args)
+	watch := fs.Bool("watch", false, "Re-poll the job and redraw its progress until it finishes")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs status <job-id> [flags]", "Show the state and progress of a job.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	remaining := fs.Args()
 	if len(remaining) == 0 {
-		return fmt.Errorf("job ID required\n\nUsage: ojs status <job-id> [--detail]")
+		return fmt.Errorf("job ID required\n\nUsage: ojs status <job-id> [--detail | --watch]")
 	}
 
 	jobID := remaining[0]
@@ -25,10 +37,13 @@ func Status(c *client.Client, args []string) error {
 	if *detail {
 		return jobDetail(c, jobID)
 	}
+	if *watch {
+		return watchStatus(c, jobID)
+	}
 
 	data, _, err := c.Get("/jobs/" + jobID)
 	if err != nil {
-		return err
+		return jobError(jobID, err)
 	}
 
 	if output.Format == "json" {
@@ -39,8 +54,11 @@ func Status(c *client.Client, args []string) error {
 
 	var job map[string]any
 	json.Unmarshal(data, &job)
+	output.Table([]string{"FIELD", "VALUE"}, statusRows(job))
+	return nil
+}
 
-	headers := []string{"FIELD", "VALUE"}
+func statusRows(job map[string]any) [][]string {
 	rows := [][]string{
 		{"ID", str(job["id"])},
 		{"Type", str(job["type"])},
@@ -57,17 +75,108 @@ func Status(c *client.Client, args []string) error {
 		rows = append(rows, []string{"Completed", str(job["completed_at"])})
 	}
 	if job["progress"] != nil {
-		rows = append(rows, []string{"Progress", fmt.Sprintf("%.0f%%", toFloat(job["progress"])*100)})
-	}
-	if job["progress_data"] != nil {
-		progressJSON, _ := json.Marshal(job["progress_data"])
-		rows = append(rows, []string{"Progress Data", string(progressJSON)})
+		rows = append(rows, []string{"Progress", progressBar(toFloat(job["progress"]))})
 	}
+	rows = append(rows, progressDataRows(job["progress_data"])...)
 	if job["error"] != nil {
 		rows = append(rows, []string{"Error", str(job["error"])})
 	}
-	output.Table(headers, rows)
-	return nil
+	return rows
+}
+
+const statusBarWidth = 20
+
+// progressBar renders a 0..1 progress fraction as a bar with a percentage,
+// clamping values outside that range.
+func progressBar(p float64) string {
+	p = max(0, min(p, 1))
+	filled := int(p*statusBarWidth + 0.5)
+	return fmt.Sprintf("[%s%s] %3.0f%%", strings.Repeat("█", filled), strings.Repeat("░", statusBarWidth-filled), p*100)
+}
+
+// progressDataRows lists the fields of a job's progress_data, one row each
+// in key order. Non-object data is shown as a single JSON row.
+func progressDataRows(data any) [][]string {
+	if data == nil {
+		return nil
+	}
+	fields, ok := data.(map[string]any)
+	if !ok {
+		encoded, _ := json.Marshal(data)
+		return [][]string{{"Progress Data", string(encoded)}}
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := make([][]string, 0, len(keys))
+	for _, k := range keys {
+		v := fields[k]
+		value := fmt.Sprintf("%v", v)
+		switch v.(type) {
+		case map[string]any, []any, nil:
+			encoded, _ := json.Marshal(v)
+			value = string(encoded)
+		}
+		rows = append(rows, []string{"  " + k, value})
+	}
+	return rows
+}
+
+// watchStatus re-polls a job until it reaches a terminal state. On a
+// terminal the status table is redrawn in place; otherwise one line is
+// printed whenever the state or progress changes.
+func watchStatus(c *client.Client, jobID string) error {
+	interactive := stdoutIsTerminal() && output.Format != "json"
+	last := ""
+	for {
+		data, _, err := c.Get("/jobs/" + jobID)
+		if err != nil {
+			return jobError(jobID, err)
+		}
+		var job map[string]any
+		if err := json.Unmarshal(data, &job); err != nil {
+			return fmt.Errorf("parse job response: %w", err)
+		}
+		state := str(job["state"])
+		done := jobstate.Terminal(state)
+
+		switch {
+		case output.Format == "json":
+			if done {
+				var result any
+				json.Unmarshal(data, &result)
+				return output.JSON(result)
+			}
+		case interactive:
+			fmt.Print("\033[2J\033[H")
+			fmt.Printf("Updated %s (Ctrl-C to stop)\n\n", time.Now().Format("15:04:05"))
+			output.Table([]string{"FIELD", "VALUE"}, statusRows(job))
+		default:
+			if line := statusLine(job); line != last {
+				fmt.Printf("[%s] %s\n", time.Now().Format("15:04:05"), line)
+				last = line
+			}
+		}
+
+		if done {
+			return nil
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// statusLine summarizes a job's state and progress on one line.
+func statusLine(job map[string]any) string {
+	line := fmt.Sprintf("%s %s", str(job["id"]), colorState(str(job["state"])))
+	if job["progress"] != nil {
+		line += " " + progressBar(toFloat(job["progress"]))
+	}
+	for _, row := range progressDataRows(job["progress_data"]) {
+		line += fmt.Sprintf(" %s=%s", strings.TrimSpace(row[0]), row[1])
+	}
+	return line
 }
 
 func str(v any) string {
@@ -88,10 +197,62 @@ func toFloat(v any) float64 {
 	}
 }
 
+// parseDuration is time.ParseDuration plus a "d" suffix for days (e.g. 7d),
+// matching the duration strings the server accepts.
+func parseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// ExitNotFound is the process exit code when a command fails because the
+// job (or other resource) it was given does not exist.
+const ExitNotFound = 3
+
+// ExitCode maps the error returned by a command to a process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case client.IsNotFound(err):
+		return ExitNotFound
+	default:
+		return 1
+	}
+}
+
+// jobError replaces the message of a not-found error for a job lookup with a
+// friendlier one, keeping the APIError reachable through errors.As. Other
+// errors are returned unchanged.
+func jobError(jobID string, err error) error {
+	if client.IsNotFound(err) {
+		return &friendlyError{msg: fmt.Sprintf("job %s not found", jobID), err: err}
+	}
+	return err
+}
+
+// friendlyError shows msg in place of the wrapped error's own text.
+type friendlyError struct {
+	msg string
+	err error
+}
+
+func (e *friendlyError) Error() string { return e.msg }
+func (e *friendlyError) Unwrap() error { return e.err }
+
 func jobDetail(c *client.Client, jobID string) error {
 	data, _, err := c.Get("/admin/jobs/" + jobID)
 	if err != nil {
-		return err
+		return jobError(jobID, err)
 	}
 
 	if output.Format == "json" {
@@ -135,7 +296,7 @@ func jobDetail(c *client.Client, jobID string) error {
 		rows = append(rows, []string{"Completed", str(job["completed_at"])})
 	}
 	if job["progress"] != nil {
-		rows = append(rows, []string{"Progress", fmt.Sprintf("%.0f%%", toFloat(job["progress"])*100)})
+		rows = append(rows, []string{"Progress", progressBar(toFloat(job["progress"]))})
 	}
 	if job["result"] != nil {
 		resultJSON, _ := json.Marshal(job["result"])
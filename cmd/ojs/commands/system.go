@@ -2,8 +2,10 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -14,23 +16,36 @@ func System(c *client.Client, args []string) error {
 	if len(args) == 0 {
 		return printSystemUsage()
 	}
+	if isHelpArg(args[0]) {
+		return printSubcommandHelp(printSystemUsage())
+	}
 
 	switch args[0] {
 	case "maintenance":
 		return systemMaintenance(c, args[1:])
 	case "config":
-		return systemConfig(c)
+		return systemConfig(c, args[1:])
+	case "drain":
+		return systemDrain(c, args[1:])
 	default:
 		return printSystemUsage()
 	}
 }
 
 func systemMaintenance(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("system maintenance", flag.ExitOnError)
+	fs := newFlagSet("system maintenance")
 	enable := fs.Bool("enable", false, "Enable maintenance mode")
 	disable := fs.Bool("disable", false, "Disable maintenance mode")
 	reason := fs.String("reason", "", "Reason for maintenance")
-	fs.Parse(args)
+	allowTypes := fs.String("allow-types", "", "Job types still processed during maintenance (comma-separated, with --enable)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs system maintenance [flags]", "Show, enable or disable maintenance mode.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	if !*enable && !*disable {
 		// Show current status
@@ -54,6 +69,13 @@ func systemMaintenance(c *client.Client, args []string) error {
 			if resp["started_at"] != nil {
 				fmt.Printf("Since: %s\n", str(resp["started_at"]))
 			}
+			if allowed, _ := resp["allowed_types"].([]any); len(allowed) > 0 {
+				names := make([]string, len(allowed))
+				for i, t := range allowed {
+					names[i] = str(t)
+				}
+				fmt.Printf("Allowed types: %s\n", strings.Join(names, ", "))
+			}
 		} else {
 			fmt.Println("Maintenance mode: DISABLED")
 		}
@@ -63,6 +85,10 @@ func systemMaintenance(c *client.Client, args []string) error {
 	if *enable && *disable {
 		return fmt.Errorf("cannot use both --enable and --disable")
 	}
+	allowed := splitCommaStr(*allowTypes)
+	if len(allowed) > 0 && !*enable {
+		return fmt.Errorf("--allow-types requires --enable\n\nUsage: ojs system maintenance --enable --allow-types <type,...>")
+	}
 
 	body := map[string]any{
 		"enabled": *enable,
@@ -70,6 +96,9 @@ func systemMaintenance(c *client.Client, args []string) error {
 	if *reason != "" {
 		body["reason"] = *reason
 	}
+	if len(allowed) > 0 {
+		body["allowed_types"] = allowed
+	}
 
 	data, _, err := c.Post("/admin/maintenance", body)
 	if err != nil {
@@ -87,6 +116,9 @@ func systemMaintenance(c *client.Client, args []string) error {
 		if *reason != "" {
 			msg += fmt.Sprintf(" (reason: %s)", *reason)
 		}
+		if len(allowed) > 0 {
+			msg += fmt.Sprintf("; allowing %s", strings.Join(allowed, ", "))
+		}
 		output.Success(msg)
 	} else {
 		output.Success("Maintenance mode disabled")
@@ -94,7 +126,33 @@ func systemMaintenance(c *client.Client, args []string) error {
 	return nil
 }
 
-func systemConfig(c *client.Client) error {
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+func systemConfig(c *client.Client, args []string) error {
+	fs := newFlagSet("system config")
+	var sets stringList
+	fs.Var(&sets, "set", "Update a setting as key=value (repeatable)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs system config [--set <key>=<value> ...]", "Show or update the server configuration.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if len(sets) > 0 {
+		return systemConfigSet(c, sets)
+	}
+
 	data, _, err := c.Get("/admin/config")
 	if err != nil {
 		return err
@@ -111,9 +169,118 @@ func systemConfig(c *client.Client) error {
 	return output.JSON(result)
 }
 
+// systemConfigSet PATCHes the given key=value settings, sending numbers and
+// booleans as typed JSON values.
+func systemConfigSet(c *client.Client, sets []string) error {
+	body := make(map[string]any, len(sets))
+	for _, kv := range sets {
+		key, value, ok := strings.Cut(kv, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("invalid setting %q: expected key=value\n\nUsage: ojs system config --set <key>=<value> [--set ...]", kv)
+		}
+		body[key] = coerceValue(value)
+	}
+
+	data, _, err := c.Patch("/admin/config", body)
+	if err != nil {
+		return err
+	}
+
+	var result any
+	json.Unmarshal(data, &result)
+	if output.Format != "json" {
+		output.Success("Updated %d setting(s)", len(body))
+	}
+	return output.JSON(result)
+}
+
+// coerceValue converts a command-line value to an int, float, or bool when it
+// parses as one, and leaves it a string otherwise.
+func coerceValue(s string) any {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	if s == "true" || s == "false" {
+		return s == "true"
+	}
+	return s
+}
+
+// systemDrain prepares the system for shutdown: it enables maintenance mode,
+// quiets every worker, and waits until no jobs are active.
+func systemDrain(c *client.Client, args []string) error {
+	fs := newFlagSet("system drain")
+	timeout := fs.Int("timeout", 300, "Seconds to wait for active jobs to finish")
+	noMaintenance := fs.Bool("no-maintenance", false, "Do not enable maintenance mode")
+	reason := fs.String("reason", "draining for shutdown", "Maintenance reason")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs system drain [flags]", "Enable maintenance mode, quiet every worker and wait until no jobs are active.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	progress := func(format string, a ...any) {
+		if output.Format != "json" {
+			fmt.Printf(format+"\n", a...)
+		}
+	}
+
+	if !*noMaintenance {
+		progress("[1/3] Enabling maintenance mode...")
+		if _, _, err := c.Post("/admin/maintenance", map[string]any{"enabled": true, "reason": *reason}); err != nil {
+			return fmt.Errorf("enable maintenance mode: %w", err)
+		}
+	} else {
+		progress("[1/3] Skipping maintenance mode")
+	}
+
+	progress("[2/3] Quieting workers...")
+	quieted, total, err := quietEachWorker(c)
+	if err != nil {
+		return err
+	}
+
+	progress("[3/3] Waiting for active jobs to finish...")
+	deadline := time.Now().Add(time.Duration(*timeout) * time.Second)
+	for {
+		s, _, err := fetchStatsOverview(c, "")
+		if err != nil {
+			return err
+		}
+		if s.Jobs.Active == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %ds with %d active job(s); workers remain quiet and maintenance mode is still on", *timeout, s.Jobs.Active)
+		}
+		progress("      %d active job(s) remaining", s.Jobs.Active)
+		time.Sleep(pollInterval)
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{
+			"maintenance":     !*noMaintenance,
+			"workers_quieted": len(quieted),
+			"workers_total":   total,
+			"active_jobs":     0,
+			"drained":         true,
+		})
+	}
+	output.Success("System drained: %d worker(s) quiet, no active jobs", len(quieted))
+	return nil
+}
+
 func printSystemUsage() error {
 	return fmt.Errorf("subcommand required\n\nUsage: ojs system <subcommand>\n\n" +
 		"Subcommands:\n" +
 		"  maintenance  Manage maintenance mode (--enable/--disable)\n" +
-		"  config       View system configuration")
+		"  config       View or update system configuration (--set key=value)\n" +
+		"  drain        Enable maintenance, quiet workers, and wait for active jobs")
 }
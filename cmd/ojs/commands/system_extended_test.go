@@ -0,0 +1,173 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSystem_ConfigSet(t *testing.T) {
+	var body map[string]any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/ojs/v1/admin/config" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(body)
+	})
+
+	captureStdout(t, func() {
+		err := System(c, []string{"config",
+			"--set", "max_retry_attempts=5",
+			"--set", "default_queue=work",
+			"--set", "strict_mode=true",
+			"--set", "backoff_factor=1.5",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := map[string]any{
+		"max_retry_attempts": float64(5),
+		"default_queue":      "work",
+		"strict_mode":        true,
+		"backoff_factor":     1.5,
+	}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %#v (%T), want %#v", k, body[k], body[k], v)
+		}
+	}
+	if len(body) != len(want) {
+		t.Errorf("unexpected extra keys in body: %v", body)
+	}
+}
+
+func TestSystem_ConfigSetInvalid(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("no request expected for invalid settings, got %s", r.Method)
+	})
+	for _, bad := range []string{"=5", "novalue", " =x"} {
+		if err := System(c, []string{"config", "--set", bad}); err == nil {
+			t.Errorf("expected error for --set %q", bad)
+		}
+	}
+}
+
+func TestCoerceValue(t *testing.T) {
+	tests := []struct {
+		in   string
+		want any
+	}{
+		{"5", int64(5)},
+		{"-2", int64(-2)},
+		{"0.25", 0.25},
+		{"false", false},
+		{"TRUE", "TRUE"},
+		{"work", "work"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := coerceValue(tt.in); got != tt.want {
+			t.Errorf("coerceValue(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func drainHandler(t *testing.T, calls *[]string, activeSeq []int) http.HandlerFunc {
+	statsCalls := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" "+r.URL.Path)
+		switch {
+		case r.URL.Path == "/ojs/v1/admin/maintenance":
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["enabled"] != true {
+				t.Errorf("expected maintenance enabled, got %v", body)
+			}
+			w.Write([]byte(`{"enabled": true}`))
+		case r.URL.Path == "/ojs/v1/admin/workers":
+			w.Write([]byte(`{"items": [{"id": "w1"}, {"id": "w2"}]}`))
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/quiet"):
+			w.Write([]byte(`{}`))
+		case r.URL.Path == "/ojs/v1/admin/stats":
+			active := activeSeq[len(activeSeq)-1]
+			if statsCalls < len(activeSeq) {
+				active = activeSeq[statsCalls]
+			}
+			statsCalls++
+			json.NewEncoder(w).Encode(map[string]any{"jobs": map[string]int{"active": active}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+func TestSystem_Drain(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	var calls []string
+	c := newTestClient(drainHandler(t, &calls, []int{3, 1, 0}))
+
+	out := captureStdout(t, func() {
+		if err := System(c, []string{"drain", "--timeout", "5"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	want := []string{
+		"POST /ojs/v1/admin/maintenance",
+		"GET /ojs/v1/admin/workers",
+		"POST /ojs/v1/admin/workers/w1/quiet",
+		"POST /ojs/v1/admin/workers/w2/quiet",
+		"GET /ojs/v1/admin/stats",
+		"GET /ojs/v1/admin/stats",
+		"GET /ojs/v1/admin/stats",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected call sequence:\n%s\nwant:\n%s", strings.Join(calls, "\n"), strings.Join(want, "\n"))
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result["drained"] != true || result["workers_quieted"] != float64(2) {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
+
+func TestSystem_DrainNoMaintenance(t *testing.T) {
+	var calls []string
+	c := newTestClient(drainHandler(t, &calls, []int{0}))
+
+	captureStdout(t, func() {
+		if err := System(c, []string{"drain", "--no-maintenance"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, call := range calls {
+		if strings.Contains(call, "maintenance") {
+			t.Errorf("maintenance should be skipped, got %s", call)
+		}
+	}
+}
+
+func TestSystem_DrainTimeout(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	var calls []string
+	c := newTestClient(drainHandler(t, &calls, []int{4}))
+
+	err := System(c, []string{"drain", "--timeout", "0"})
+	if err == nil || !strings.Contains(err.Error(), "4 active job(s)") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// parseTags parses repeated --tag key=value flags. A job must carry every
+// tag to match, so repeating a key is rejected rather than silently merged.
+func parseTags(args []string) (map[string]string, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	tags := make(map[string]string, len(args))
+	for _, arg := range args {
+		k, v, ok := strings.Cut(arg, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid --tag %q: expected key=value", arg)
+		}
+		if _, dup := tags[k]; dup {
+			return nil, fmt.Errorf("--tag %q given more than once", k)
+		}
+		tags[k] = strings.TrimSpace(v)
+	}
+	return tags, nil
+}
+
+// tagQuery encodes tags as repeated "tags=key:value" query parameters,
+// sorted by key.
+func tagQuery(tags map[string]string) string {
+	q := url.Values{}
+	for _, k := range sortedTagKeys(tags) {
+		q.Add("tags", k+":"+tags[k])
+	}
+	return q.Encode()
+}
+
+// formatTags renders tags as "k1=v1,k2=v2" for messages.
+func formatTags(tags map[string]string) string {
+	parts := make([]string, 0, len(tags))
+	for _, k := range sortedTagKeys(tags) {
+		parts = append(parts, k+"="+tags[k])
+	}
+	return strings.Join(parts, ",")
+}
+
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// newBulkFilter builds the filter object of a bulk request. Tags are sent as
+// an object; the server matches jobs carrying all of them.
+func newBulkFilter(state, queue string, tags map[string]string) map[string]any {
+	filter := map[string]any{}
+	if state != "" {
+		filter["state"] = state
+	}
+	if queue != "" {
+		filter["queue"] = queue
+	}
+	if len(tags) > 0 {
+		filter["tags"] = tags
+	}
+	return filter
+}
@@ -0,0 +1,459 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// Job templates are job envelopes stored as JSON files in the template
+// directory. String values may contain {{name}} placeholders that are filled
+// from --param name=value when the template is enqueued. An optional
+// top-level "defaults" object gives values for placeholders that may be
+// omitted; every other placeholder is required.
+//
+//	{
+//	  "type": "email.send",
+//	  "args": [{"to": "{{to}}", "subject": "{{subject}}"}],
+//	  "options": {"queue": "email"},
+//	  "defaults": {"subject": "Welcome!"}
+//	}
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// templateDir returns $OJS_TEMPLATE_DIR, or ~/.ojs/templates.
+func templateDir() (string, error) {
+	if dir := os.Getenv("OJS_TEMPLATE_DIR"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locate home directory: %w (set OJS_TEMPLATE_DIR)", err)
+	}
+	return filepath.Join(home, ".ojs", "templates"), nil
+}
+
+// templatePath returns the file a named template is stored in. Names are
+// plain file names so a template can never point outside the directory.
+func templatePath(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid template name %q", name)
+	}
+	dir, err := templateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, strings.TrimSuffix(name, ".json")+".json"), nil
+}
+
+// Template manages the local job template store.
+func Template(c *client.Client, args []string) error {
+	if len(args) == 0 {
+		return printTemplateUsage()
+	}
+	if isHelpArg(args[0]) {
+		return printSubcommandHelp(printTemplateUsage())
+	}
+
+	switch args[0] {
+	case "list":
+		return templateList(args[1:])
+	case "show":
+		return templateShow(args[1:])
+	case "save":
+		return templateSave(c, args[1:])
+	case "delete":
+		return templateDelete(args[1:])
+	default:
+		return printTemplateUsage()
+	}
+}
+
+func printTemplateUsage() error {
+	return fmt.Errorf("subcommand required\n\nUsage: ojs template <subcommand>\n\n" +
+		"Subcommands:\n" +
+		"  list     List stored job templates\n" +
+		"  show     Print a template and the parameters it takes\n" +
+		"  save     Store a template from a file or an existing job\n" +
+		"  delete   Delete a stored template\n\n" +
+		"Templates are enqueued with: ojs enqueue --from-template <name> --param k=v")
+}
+
+// templateSummary describes a stored template for "template list".
+type templateSummary struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Queue  string   `json:"queue,omitempty"`
+	Params []string `json:"params"`
+	Path   string   `json:"path"`
+}
+
+func templateList(args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs template list", "List the job templates in the template store.")
+		return nil
+	}
+	dir, err := templateDir()
+	if err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	summaries := []templateSummary{}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		tmpl, err := loadTemplate(name)
+		if err != nil {
+			output.Warn("Skipping %s: %v", path, err)
+			continue
+		}
+		opts, _ := tmpl["options"].(map[string]any)
+		jobType, _ := tmpl["type"].(string)
+		queue, _ := opts["queue"].(string)
+		summaries = append(summaries, templateSummary{
+			Name:   name,
+			Type:   jobType,
+			Queue:  queue,
+			Params: templateParams(tmpl),
+			Path:   path,
+		})
+	}
+
+	if output.Format == "json" {
+		return output.JSON(summaries)
+	}
+	if len(summaries) == 0 {
+		fmt.Printf("No templates in %s\n", dir)
+		return nil
+	}
+	headers := []string{"NAME", "TYPE", "QUEUE", "PARAMS"}
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		queue, params := s.Queue, strings.Join(s.Params, ", ")
+		if queue == "" {
+			queue = "-"
+		}
+		if params == "" {
+			params = "-"
+		}
+		rows = append(rows, []string{s.Name, s.Type, queue, params})
+	}
+	output.Table(headers, rows)
+	return nil
+}
+
+func templateShow(args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs template show <name>", "Print a stored template and the parameters it takes.")
+		return nil
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("template name required\n\nUsage: ojs template show <name>")
+	}
+	tmpl, err := loadTemplate(args[0])
+	if err != nil {
+		return err
+	}
+	if output.Format == "json" {
+		return output.JSON(tmpl)
+	}
+
+	data, _ := json.MarshalIndent(tmpl, "", "  ")
+	fmt.Println(string(data))
+	if params := templateParams(tmpl); len(params) > 0 {
+		fmt.Printf("\nParameters: %s\n", strings.Join(params, ", "))
+	}
+	return nil
+}
+
+func templateSave(c *client.Client, args []string) error {
+	fs := newFlagSet("template save")
+	fromJob := fs.String("from-job", "", "Capture the envelope of an existing job (as shown by status --detail)")
+	file := fs.String("file", "", "Read the template JSON from a file (- for stdin)")
+	force := fs.Bool("force", false, "Overwrite an existing template")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs template save <name> (--file <path> | --from-job <job-id>) [flags]",
+			"Store a job template. Edit the saved file to replace values with {{name}}\n"+
+				"placeholders, optionally listing fallback values under \"defaults\".")
+		return nil
+	}
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("template name required\n\nUsage: ojs template save <name> (--file <path> | --from-job <job-id>) [--force]")
+	}
+	name := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if (*fromJob == "") == (*file == "") {
+		return fmt.Errorf("exactly one of --file or --from-job is required")
+	}
+	path, err := templatePath(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil && !*force {
+		return fmt.Errorf("template %q already exists (use --force to overwrite)", name)
+	}
+
+	var tmpl map[string]any
+	if *fromJob != "" {
+		job, err := fetchJobEnvelope(c, *fromJob)
+		if err != nil {
+			return jobError(*fromJob, err)
+		}
+		tmpl = templateFromJob(job)
+	} else {
+		var data []byte
+		if *file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(*file)
+		}
+		if err != nil {
+			return fmt.Errorf("read template file: %w", err)
+		}
+		if err := json.Unmarshal(data, &tmpl); err != nil {
+			return fmt.Errorf("invalid template JSON: %w", err)
+		}
+	}
+	if t, _ := tmpl["type"].(string); t == "" {
+		return fmt.Errorf("template must have a \"type\"")
+	}
+
+	data, _ := json.MarshalIndent(tmpl, "", "  ")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create template directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write template: %w", err)
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"name": name, "path": path, "params": templateParams(tmpl)})
+	}
+	output.Success("Template %q saved to %s", name, path)
+	return nil
+}
+
+func templateDelete(args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs template delete <name>", "Delete a stored template.")
+		return nil
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("template name required\n\nUsage: ojs template delete <name>")
+	}
+	path, err := templatePath(args[0])
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); os.IsNotExist(err) {
+		return fmt.Errorf("template %q not found in %s", args[0], filepath.Dir(path))
+	} else if err != nil {
+		return fmt.Errorf("delete template: %w", err)
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"name": args[0], "deleted": true})
+	}
+	output.Success("Template %q deleted", args[0])
+	return nil
+}
+
+// templateFromJob keeps the parts of a job envelope that describe what to
+// enqueue, dropping server-assigned fields such as id, state and timestamps.
+func templateFromJob(job map[string]any) map[string]any {
+	tmpl := map[string]any{"type": job["type"]}
+	if job["args"] != nil {
+		tmpl["args"] = job["args"]
+	}
+	if job["meta"] != nil {
+		tmpl["meta"] = job["meta"]
+	}
+
+	opts := map[string]any{}
+	if o, ok := job["options"].(map[string]any); ok {
+		for k, v := range o {
+			opts[k] = v
+		}
+	}
+	// Admin envelopes report queue and priority at the top level.
+	for _, k := range []string{"queue", "priority", "max_attempts", "tags"} {
+		if _, ok := opts[k]; !ok && job[k] != nil {
+			opts[k] = job[k]
+		}
+	}
+	if len(opts) > 0 {
+		tmpl["options"] = opts
+	}
+	return tmpl
+}
+
+// templateParams returns the placeholders of a template; those with a
+// default are marked with "?".
+func templateParams(tmpl map[string]any) []string {
+	defaults, _ := tmpl["defaults"].(map[string]any)
+	params := templatePlaceholders(templateBody(tmpl))
+	for i, p := range params {
+		if _, ok := defaults[p]; ok {
+			params[i] = p + "?"
+		}
+	}
+	return params
+}
+
+func loadTemplate(name string) (map[string]any, error) {
+	path, err := templatePath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("template %q not found in %s", name, filepath.Dir(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+	var tmpl map[string]any
+	if err := json.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// parseParams parses repeated --param name=value flags.
+func parseParams(args []string) (map[string]string, error) {
+	params := make(map[string]string, len(args))
+	for _, arg := range args {
+		k, v, ok := strings.Cut(arg, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid --param %q: expected name=value", arg)
+		}
+		params[k] = v
+	}
+	return params, nil
+}
+
+// renderTemplate substitutes params into every string of tmpl and returns
+// the job envelope without the "defaults" section. It fails if a required
+// placeholder has no value or a param matches no placeholder.
+func renderTemplate(tmpl map[string]any, params map[string]string) (map[string]any, error) {
+	values := map[string]string{}
+	if defaults, ok := tmpl["defaults"].(map[string]any); ok {
+		for k, v := range defaults {
+			values[k] = fmt.Sprint(v)
+		}
+	}
+
+	body := templateBody(tmpl)
+	used := templatePlaceholders(body)
+
+	var unknown []string
+	for k, v := range params {
+		if !contains(used, k) {
+			unknown = append(unknown, k)
+		}
+		values[k] = v
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown --param %s (template uses: %s)", strings.Join(unknown, ", "), strings.Join(used, ", "))
+	}
+
+	var missing []string
+	for _, name := range used {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required --param: %s", strings.Join(missing, ", "))
+	}
+
+	return substitutePlaceholders(body, values).(map[string]any), nil
+}
+
+// templateBody returns the job envelope of a template: everything except
+// its "defaults".
+func templateBody(tmpl map[string]any) map[string]any {
+	body := make(map[string]any, len(tmpl))
+	for k, v := range tmpl {
+		if k != "defaults" {
+			body[k] = v
+		}
+	}
+	return body
+}
+
+// templatePlaceholders returns the sorted, distinct placeholder names in v.
+func templatePlaceholders(v any) []string {
+	seen := map[string]bool{}
+	var walk func(any)
+	walk = func(v any) {
+		switch t := v.(type) {
+		case string:
+			for _, m := range placeholderRe.FindAllStringSubmatch(t, -1) {
+				seen[m[1]] = true
+			}
+		case map[string]any:
+			for k, child := range t {
+				walk(k)
+				walk(child)
+			}
+		case []any:
+			for _, child := range t {
+				walk(child)
+			}
+		}
+	}
+	walk(v)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// substitutePlaceholders returns a copy of v with placeholders in string
+// values and object keys replaced. Values are always inserted as strings.
+func substitutePlaceholders(v any, values map[string]string) any {
+	replace := func(s string) string {
+		return placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+			return values[placeholderRe.FindStringSubmatch(m)[1]]
+		})
+	}
+	switch t := v.(type) {
+	case string:
+		return replace(t)
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		for k, child := range t {
+			out[replace(k)] = substitutePlaceholders(child, values)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, child := range t {
+			out[i] = substitutePlaceholders(child, values)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// withTemplateDir points the template store at a temp dir holding the given
+// templates.
+func withTemplateDir(t *testing.T, templates map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("OJS_TEMPLATE_DIR", dir)
+	for name, content := range templates {
+		if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const welcomeTemplate = `{
+  "type": "email.send",
+  "args": [{"to": "{{to}}", "subject": "{{subject}}", "body": "Hi {{name}}, welcome to {{ product }}"}],
+  "options": {"queue": "email", "tags": {"campaign": "{{campaign}}"}},
+  "defaults": {"subject": "Welcome!", "product": "OJS"}
+}`
+
+func TestEnqueue_FromTemplate(t *testing.T) {
+	withTemplateDir(t, map[string]string{"welcome-email": welcomeTemplate})
+
+	var body map[string]any
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": "job-1"})
+	})
+
+	captureStdout(t, func() {
+		err := Enqueue(c, []string{"--from-template", "welcome-email",
+			"--param", "to=user@x.com", "--param", "name=Ada", "--param", "campaign=spring",
+			"--queue", "bulk-email", "--tag", "env=prod"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	if body["type"] != "email.send" {
+		t.Errorf("type = %v, want email.send", body["type"])
+	}
+	wantArgs := []any{map[string]any{"to": "user@x.com", "subject": "Welcome!", "body": "Hi Ada, welcome to OJS"}}
+	if !reflect.DeepEqual(body["args"], wantArgs) {
+		t.Errorf("args = %v, want %v", body["args"], wantArgs)
+	}
+	opts, _ := body["options"].(map[string]any)
+	if opts["queue"] != "bulk-email" {
+		t.Errorf("explicit --queue should override the template, got %v", opts["queue"])
+	}
+	wantTags := map[string]any{"campaign": "spring", "env": "prod"}
+	if !reflect.DeepEqual(opts["tags"], wantTags) {
+		t.Errorf("tags = %v, want %v", opts["tags"], wantTags)
+	}
+	if _, ok := body["defaults"]; ok {
+		t.Error("defaults must not be sent to the server")
+	}
+}
+
+func TestEnqueue_FromTemplateErrors(t *testing.T) {
+	withTemplateDir(t, map[string]string{"welcome-email": welcomeTemplate})
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--from-template", "welcome-email", "--param", "to=a@b.c"}, "missing required --param: campaign, name"},
+		{[]string{"--from-template", "welcome-email", "--param", "to=a@b.c", "--param", "name=A", "--param", "campaign=x", "--param", "nmae=B"}, "unknown --param nmae"},
+		{[]string{"--from-template", "welcome-email", "--param", "to"}, `invalid --param "to"`},
+		{[]string{"--from-template", "nope"}, `template "nope" not found`},
+		{[]string{"--from-template", "../etc/passwd"}, "invalid template name"},
+		{[]string{"--type", "t", "--param", "to=a"}, "--param requires --from-template"},
+	}
+	for _, tt := range tests {
+		err := Enqueue(c, tt.args)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: err = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+func TestTemplatePlaceholders(t *testing.T) {
+	var tmpl map[string]any
+	json.Unmarshal([]byte(welcomeTemplate), &tmpl)
+	delete(tmpl, "defaults")
+	got := templatePlaceholders(tmpl)
+	want := []string{"campaign", "name", "product", "subject", "to"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("placeholders = %v, want %v", got, want)
+	}
+}
+
+func TestTemplate_RoundTrip(t *testing.T) {
+	dir := withTemplateDir(t, nil)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/admin/jobs/job-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"id": "job-1", "type": "email.send", "state": "completed", "queue": "email", "priority": 7,
+			"args": []any{"user@x.com"}, "created_at": "2024-01-01T00:00:00Z",
+		})
+	})
+
+	captureStdout(t, func() {
+		if err := Template(c, []string{"save", "welcome", "--from-job", "job-1"}); err != nil {
+			t.Fatalf("save: %v", err)
+		}
+	})
+	if err := Template(c, []string{"save", "welcome", "--from-job", "job-1"}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("saving over an existing template without --force: err = %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "digest.json")
+	os.WriteFile(file, []byte(`{"type":"digest.build","args":["{{user}}"],"defaults":{"user":"all"}}`), 0644)
+	captureStdout(t, func() {
+		if err := Template(c, []string{"save", "digest", "--file", file}); err != nil {
+			t.Fatalf("save --file: %v", err)
+		}
+	})
+
+	var list []templateSummary
+	out := captureStdout(t, func() {
+		if err := Template(c, []string{"list"}); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	json.Unmarshal([]byte(out), &list)
+	want := []templateSummary{
+		{Name: "digest", Type: "digest.build", Params: []string{"user?"}, Path: filepath.Join(dir, "digest.json")},
+		{Name: "welcome", Type: "email.send", Queue: "email", Params: []string{}, Path: filepath.Join(dir, "welcome.json")},
+	}
+	if !reflect.DeepEqual(list, want) {
+		t.Errorf("list = %+v, want %+v", list, want)
+	}
+
+	var shown map[string]any
+	out = captureStdout(t, func() {
+		if err := Template(c, []string{"show", "welcome"}); err != nil {
+			t.Fatalf("show: %v", err)
+		}
+	})
+	json.Unmarshal([]byte(out), &shown)
+	wantShown := map[string]any{
+		"type":    "email.send",
+		"args":    []any{"user@x.com"},
+		"options": map[string]any{"queue": "email", "priority": float64(7)},
+	}
+	if !reflect.DeepEqual(shown, wantShown) {
+		t.Errorf("show = %v, want %v (server-assigned fields must be dropped)", shown, wantShown)
+	}
+
+	captureStdout(t, func() {
+		if err := Template(c, []string{"delete", "welcome"}); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	})
+	if err := Template(c, []string{"show", "welcome"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("show after delete: err = %v", err)
+	}
+	if err := Template(c, []string{"delete", "welcome"}); err == nil {
+		t.Error("deleting a missing template should fail")
+	}
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// activeJob is one row of the top view.
+type activeJob struct {
+	ID      string        `json:"id"`
+	Type    string        `json:"type"`
+	Queue   string        `json:"queue"`
+	Attempt string        `json:"attempt"`
+	Elapsed time.Duration `json:"-"`
+	Seconds float64       `json:"elapsed_seconds"`
+}
+
+// Top shows a continuously refreshing, job-centric view of active jobs.
+func Top(c *client.Client, args []string) error {
+	fs := newFlagSet("top")
+	sortBy := fs.String("sort", "elapsed", "Sort by elapsed, type, queue, or id")
+	limit := fs.Int("limit", 20, "Max jobs to show")
+	queue := fs.String("queue", "", "Only show jobs in this queue")
+	interval := fs.Duration("interval", 2*time.Second, "Refresh interval")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs top [flags]", "Show a live view of active jobs sorted by runtime.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch *sortBy {
+	case "elapsed", "type", "queue", "id":
+	default:
+		return fmt.Errorf("invalid --sort %q\n\nUsage: ojs top [--sort elapsed|type|queue|id] [--limit 20] [--queue <name>]", *sortBy)
+	}
+	if *interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	path := "/jobs?state=active&limit=500"
+	if *queue != "" {
+		path += "&queue=" + *queue
+	}
+
+	// JSON output is a single snapshot so it can be piped.
+	if output.Format == "json" {
+		jobs, total, err := fetchActiveJobs(c, path, *sortBy, *limit)
+		if err != nil {
+			return err
+		}
+		return output.JSON(map[string]any{"total": total, "jobs": jobs})
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+
+	for {
+		jobs, total, err := fetchActiveJobs(c, path, *sortBy, *limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ refresh error: %v\n", err)
+		} else {
+			renderTop(jobs, total, *sortBy)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-sigCh:
+			fmt.Println("\nTop stopped.")
+			return nil
+		}
+	}
+}
+
+func fetchActiveJobs(c *client.Client, path, sortBy string, limit int) ([]activeJob, int, error) {
+	data, _, err := c.Get(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	var resp struct {
+		Jobs []map[string]any `json:"jobs"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, 0, fmt.Errorf("parse jobs response: %w", err)
+	}
+	jobs := sortActiveJobs(resp.Jobs, time.Now(), sortBy)
+	total := len(jobs)
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, total, nil
+}
+
+// sortActiveJobs converts raw jobs to rows with their runtime as of now and
+// sorts them. Elapsed sorts longest-running first; the other keys sort
+// ascending with the longest-running first among equals.
+func sortActiveJobs(raw []map[string]any, now time.Time, sortBy string) []activeJob {
+	jobs := make([]activeJob, 0, len(raw))
+	for _, j := range raw {
+		job := activeJob{
+			ID:      str(j["id"]),
+			Type:    str(j["type"]),
+			Queue:   str(j["queue"]),
+			Attempt: str(j["attempt"]),
+		}
+		for _, key := range []string{"started_at", "created_at"} {
+			if t, err := time.Parse(time.RFC3339, str(j[key])); err == nil {
+				job.Elapsed = now.Sub(t)
+				break
+			}
+		}
+		if job.Elapsed < 0 {
+			job.Elapsed = 0
+		}
+		job.Seconds = job.Elapsed.Seconds()
+		jobs = append(jobs, job)
+	}
+
+	key := func(j activeJob) string {
+		switch sortBy {
+		case "type":
+			return j.Type
+		case "queue":
+			return j.Queue
+		case "id":
+			return j.ID
+		}
+		return ""
+	}
+	sort.SliceStable(jobs, func(a, b int) bool {
+		if ka, kb := key(jobs[a]), key(jobs[b]); ka != kb {
+			return ka < kb
+		}
+		if jobs[a].Elapsed != jobs[b].Elapsed {
+			return jobs[a].Elapsed > jobs[b].Elapsed
+		}
+		return jobs[a].ID < jobs[b].ID
+	})
+	return jobs
+}
+
+// countByQueue tallies rows per queue, busiest first.
+func countByQueue(jobs []activeJob) []string {
+	counts := map[string]int{}
+	for _, j := range jobs {
+		counts[j.Queue]++
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(a, b int) bool {
+		if counts[names[a]] != counts[names[b]] {
+			return counts[names[a]] > counts[names[b]]
+		}
+		return names[a] < names[b]
+	})
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = fmt.Sprintf("%s=%d", name, counts[name])
+	}
+	return out
+}
+
+func renderTop(jobs []activeJob, total int, sortBy string) {
+	fmt.Print("\033[2J\033[H")
+	fmt.Printf("ojs top — %s — %d active job(s), sorted by %s\n", time.Now().Format("15:04:05"), total, sortBy)
+	if len(jobs) > 0 {
+		fmt.Printf("By queue: %s\n", strings.Join(countByQueue(jobs), "  "))
+	}
+	fmt.Println()
+
+	if len(jobs) == 0 {
+		fmt.Println("No active jobs.")
+		return
+	}
+	headers := []string{"ID", "TYPE", "QUEUE", "ATTEMPT", "ELAPSED"}
+	rows := make([][]string, 0, len(jobs))
+	for _, j := range jobs {
+		rows = append(rows, []string{j.ID, j.Type, j.Queue, j.Attempt, j.Elapsed.Truncate(time.Second).String()})
+	}
+	output.Table(headers, rows)
+	fmt.Println("\nPress Ctrl+C to exit")
+}
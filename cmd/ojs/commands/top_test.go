@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSortActiveJobs(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	raw := []map[string]any{
+		{"id": "j1", "type": "email.send", "queue": "default", "started_at": "2026-01-01T11:59:50Z"},
+		{"id": "j2", "type": "report.build", "queue": "reports", "started_at": "2026-01-01T11:00:00Z"},
+		{"id": "j3", "type": "email.send", "queue": "default", "started_at": "2026-01-01T11:58:00Z"},
+		{"id": "j4", "type": "billing.charge", "queue": "billing", "created_at": "2026-01-01T11:59:00Z"},
+	}
+
+	byElapsed := sortActiveJobs(raw, now, "elapsed")
+	want := []string{"j2", "j3", "j4", "j1"}
+	for i, id := range want {
+		if byElapsed[i].ID != id {
+			t.Errorf("elapsed[%d] = %s, want %s", i, byElapsed[i].ID, id)
+		}
+	}
+	if byElapsed[0].Elapsed != time.Hour {
+		t.Errorf("expected j2 elapsed 1h, got %v", byElapsed[0].Elapsed)
+	}
+	if byElapsed[2].Elapsed != time.Minute {
+		t.Errorf("expected created_at fallback for j4, got %v", byElapsed[2].Elapsed)
+	}
+
+	byType := sortActiveJobs(raw, now, "type")
+	want = []string{"j4", "j3", "j1", "j2"}
+	for i, id := range want {
+		if byType[i].ID != id {
+			t.Errorf("type[%d] = %s, want %s", i, byType[i].ID, id)
+		}
+	}
+}
+
+func TestCountByQueue(t *testing.T) {
+	jobs := []activeJob{{Queue: "default"}, {Queue: "billing"}, {Queue: "default"}, {Queue: "alpha"}}
+	got := countByQueue(jobs)
+	want := []string{"default=2", "alpha=1", "billing=1"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestTop_JSONSnapshot(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "active" {
+			t.Errorf("expected state=active, got %q", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": []map[string]any{
+			{"id": "a", "type": "t", "queue": "q", "started_at": time.Now().Add(-time.Minute).Format(time.RFC3339)},
+			{"id": "b", "type": "t", "queue": "q", "started_at": time.Now().Add(-time.Hour).Format(time.RFC3339)},
+		}})
+	})
+
+	out := captureStdout(t, func() {
+		if err := Top(c, []string{"--limit", "1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	var result struct {
+		Total int         `json:"total"`
+		Jobs  []activeJob `json:"jobs"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Total != 2 || len(result.Jobs) != 1 || result.Jobs[0].ID != "b" {
+		t.Errorf("expected the longest-running job only, got %+v", result)
+	}
+}
+
+func TestTop_InvalidInterval(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(noRequestClient(t))
+	for _, interval := range []string{"0s", "-1s"} {
+		if err := Top(c, []string{"--interval", interval}); err == nil || !strings.Contains(err.Error(), "--interval") {
+			t.Errorf("--interval %s: expected an interval error, got %v", interval, err)
+		}
+	}
+}
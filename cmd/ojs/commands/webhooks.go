@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -14,10 +16,15 @@ func Webhooks(c *client.Client, args []string) error {
 	if len(args) == 0 {
 		return printWebhooksUsage()
 	}
+	if isHelpArg(args[0]) {
+		return printSubcommandHelp(printWebhooksUsage())
+	}
 
 	switch args[0] {
 	case "create":
 		return webhookCreate(c, args[1:])
+	case "update":
+		return webhookUpdate(c, args[1:])
 	case "list":
 		return webhookList(c, args[1:])
 	case "get":
@@ -28,17 +35,34 @@ func Webhooks(c *client.Client, args []string) error {
 		return webhookTest(c, args[1:])
 	case "rotate-secret":
 		return webhookRotateSecret(c, args[1:])
+	case "events":
+		if helpRequested(args[1:]) {
+			printHelp(nil, "ojs webhooks events", "List the event types webhooks can subscribe to.")
+			return nil
+		}
+		return webhookEventTypes(c)
+	case "replay":
+		return webhookReplay(c, args[1:])
 	default:
 		return printWebhooksUsage()
 	}
 }
 
 func webhookCreate(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("webhooks create", flag.ExitOnError)
+	fs := newFlagSet("webhooks create")
 	url := fs.String("url", "", "Webhook endpoint URL (required)")
 	events := fs.String("events", "", "Comma-separated event types to subscribe to (required)")
 	secret := fs.String("secret", "", "Shared secret for HMAC signature verification")
-	fs.Parse(args)
+	retryPolicy := retryPolicyFlags(fs)
+	force := fs.Bool("force", false, "Skip validating --events against the server's event catalog")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs webhooks create --url <url> --events <e1,e2> [flags]", "Create a webhook subscription.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	if *url == "" || *events == "" {
 		return fmt.Errorf("--url and --events are required\n\n" +
@@ -47,6 +71,12 @@ func webhookCreate(c *client.Client, args []string) error {
 			"  ojs webhooks create --url https://example.com/hooks --events job.completed,job.failed")
 	}
 
+	if !*force {
+		if err := validateWebhookEvents(c, splitIDs(*events)); err != nil {
+			return err
+		}
+	}
+
 	body := map[string]any{
 		"url":    *url,
 		"events": splitIDs(*events),
@@ -54,6 +84,9 @@ func webhookCreate(c *client.Client, args []string) error {
 	if *secret != "" {
 		body["secret"] = *secret
 	}
+	if policy := retryPolicy(); len(policy) > 0 {
+		body["retry_policy"] = policy
+	}
 
 	data, _, err := c.Post("/webhooks/subscriptions", body)
 	if err != nil {
@@ -72,10 +105,93 @@ func webhookCreate(c *client.Client, args []string) error {
 	return nil
 }
 
+func webhookUpdate(c *client.Client, args []string) error {
+	fs := newFlagSet("webhooks update")
+	url := fs.String("url", "", "New webhook endpoint URL")
+	events := fs.String("events", "", "New comma-separated event types")
+	active := fs.String("active", "", "Enable or disable the subscription (true/false)")
+	retryPolicy := retryPolicyFlags(fs)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs webhooks update <subscription-id> [flags]", "Change the URL, events, state or retry policy of a webhook subscription.")
+		return nil
+	}
+
+	if len(args) == 0 || args[0] == "" || args[0][0] == '-' {
+		return fmt.Errorf("subscription ID required\n\n" +
+			"Usage: ojs webhooks update <subscription-id> [--url <url>] [--events <e1,e2>] [--max-retries <n>] [--retry-backoff <strategy>] [--timeout-ms <ms>]")
+	}
+	subID := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	body := map[string]any{}
+	if *url != "" {
+		body["url"] = *url
+	}
+	if *events != "" {
+		body["events"] = splitIDs(*events)
+	}
+	if *active != "" {
+		body["active"] = *active == "true"
+	}
+	if policy := retryPolicy(); len(policy) > 0 {
+		body["retry_policy"] = policy
+	}
+
+	if len(body) == 0 {
+		return fmt.Errorf("at least one field must be specified for update\n\n" +
+			"Usage: ojs webhooks update <subscription-id> [--url <url>] [--events <e1,e2>] [--max-retries <n>] [--retry-backoff <strategy>] [--timeout-ms <ms>]")
+	}
+
+	data, _, err := c.Patch("/webhooks/subscriptions/"+subID, body)
+	if err != nil {
+		return err
+	}
+
+	if output.Format == "json" {
+		var result any
+		json.Unmarshal(data, &result)
+		return output.JSON(result)
+	}
+
+	output.Success("Webhook subscription %s updated", subID)
+	return nil
+}
+
+// retryPolicyFlags registers the delivery retry flags shared by create and
+// update, returning a func that builds the retry_policy object from them.
+func retryPolicyFlags(fs *flag.FlagSet) func() map[string]any {
+	maxRetries := fs.Int("max-retries", -1, "Max delivery retries before giving up")
+	backoff := fs.String("retry-backoff", "", "Retry backoff strategy (e.g. exponential, linear, fixed)")
+	timeoutMS := fs.Int("timeout-ms", 0, "Per-delivery timeout in milliseconds")
+	return func() map[string]any {
+		policy := map[string]any{}
+		if *maxRetries >= 0 {
+			policy["max_retries"] = *maxRetries
+		}
+		if *backoff != "" {
+			policy["backoff"] = *backoff
+		}
+		if *timeoutMS > 0 {
+			policy["timeout_ms"] = *timeoutMS
+		}
+		return policy
+	}
+}
+
 func webhookList(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("webhooks list", flag.ExitOnError)
+	fs := newFlagSet("webhooks list")
 	limit := fs.Int("limit", 25, "Max results to return")
-	fs.Parse(args)
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs webhooks list [flags]", "List webhook subscriptions.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	data, _, err := c.Get(fmt.Sprintf("/webhooks/subscriptions?limit=%d", *limit))
 	if err != nil {
@@ -128,6 +244,11 @@ func webhookList(c *client.Client, args []string) error {
 }
 
 func webhookGet(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs webhooks get <subscription-id>", "Show a webhook subscription.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("subscription ID required\n\nUsage: ojs webhooks get <subscription-id>")
 	}
@@ -153,6 +274,11 @@ func webhookGet(c *client.Client, args []string) error {
 		LastDeliveryAt string   `json:"last_delivery_at"`
 		SuccessCount   int      `json:"success_count"`
 		FailureCount   int      `json:"failure_count"`
+		RetryPolicy    struct {
+			MaxRetries *int   `json:"max_retries"`
+			Backoff    string `json:"backoff"`
+			TimeoutMS  int    `json:"timeout_ms"`
+		} `json:"retry_policy"`
 	}
 	json.Unmarshal(data, &sub)
 
@@ -183,11 +309,29 @@ func webhookGet(c *client.Client, args []string) error {
 		{"Successes", fmt.Sprintf("%d", sub.SuccessCount)},
 		{"Failures", fmt.Sprintf("%d", sub.FailureCount)},
 	}
+	maxRetries := "-"
+	if sub.RetryPolicy.MaxRetries != nil {
+		maxRetries = fmt.Sprintf("%d", *sub.RetryPolicy.MaxRetries)
+	}
+	timeoutMS := "-"
+	if sub.RetryPolicy.TimeoutMS > 0 {
+		timeoutMS = fmt.Sprintf("%d", sub.RetryPolicy.TimeoutMS)
+	}
+	rows = append(rows,
+		[]string{"Max Retries", maxRetries},
+		[]string{"Retry Backoff", orDash(sub.RetryPolicy.Backoff)},
+		[]string{"Timeout (ms)", timeoutMS},
+	)
 	output.Table(headers, rows)
 	return nil
 }
 
 func webhookDelete(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs webhooks delete <subscription-id>", "Delete a webhook subscription.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("subscription ID required\n\nUsage: ojs webhooks delete <subscription-id>")
 	}
@@ -203,6 +347,11 @@ func webhookDelete(c *client.Client, args []string) error {
 }
 
 func webhookTest(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs webhooks test <subscription-id>", "Send a test event to a webhook subscription.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("subscription ID required\n\nUsage: ojs webhooks test <subscription-id>")
 	}
@@ -234,6 +383,11 @@ func webhookTest(c *client.Client, args []string) error {
 }
 
 func webhookRotateSecret(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs webhooks rotate-secret <subscription-id>", "Generate a new signing secret for a webhook subscription.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("subscription ID required\n\nUsage: ojs webhooks rotate-secret <subscription-id>")
 	}
@@ -259,13 +413,199 @@ func webhookRotateSecret(c *client.Client, args []string) error {
 	return nil
 }
 
+func webhookReplay(c *client.Client, args []string) error {
+	const usage = "Usage: ojs webhooks replay <subscription-id> --event <delivery-id>\n" +
+		"       ojs webhooks replay <subscription-id> --failed-since <duration>"
+	fs := newFlagSet("webhooks replay")
+	deliveryID := fs.String("event", "", "Delivery ID to replay")
+	failedSince := fs.String("failed-since", "", "Replay all failed deliveries within this window (e.g. 1h, 2d)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs webhooks replay <subscription-id> (--event <delivery-id> | --failed-since <duration>)", "Redeliver one webhook delivery, or every failed delivery within a window.")
+		return nil
+	}
+
+	if len(args) == 0 || args[0] == "" || args[0][0] == '-' {
+		return fmt.Errorf("subscription ID required\n\n" + usage)
+	}
+	subID := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	if *deliveryID == "" && *failedSince == "" {
+		return fmt.Errorf("--event or --failed-since is required\n\n" + usage)
+	}
+
+	var ids []string
+	if *deliveryID != "" {
+		ids = []string{*deliveryID}
+	} else {
+		window, err := parseDuration(*failedSince)
+		if err != nil {
+			return err
+		}
+		since := time.Now().Add(-window).UTC().Format(time.RFC3339)
+		data, _, err := c.Get("/webhooks/subscriptions/" + subID + "/deliveries?status=failed&since=" + since)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			Deliveries []struct {
+				ID string `json:"id"`
+			} `json:"deliveries"`
+		}
+		json.Unmarshal(data, &resp)
+		for _, d := range resp.Deliveries {
+			ids = append(ids, d.ID)
+		}
+		if len(ids) == 0 {
+			if output.Format == "json" {
+				return output.JSON(map[string]any{"replayed": []any{}})
+			}
+			fmt.Printf("No failed deliveries since %s.\n", since)
+			return nil
+		}
+	}
+
+	type replayResult struct {
+		DeliveryID string `json:"delivery_id"`
+		AttemptID  string `json:"attempt_id,omitempty"`
+		Status     string `json:"status"`
+		StatusCode int    `json:"status_code,omitempty"`
+		Error      string `json:"error,omitempty"`
+	}
+	results := make([]replayResult, 0, len(ids))
+	failed := 0
+	for _, id := range ids {
+		data, _, err := c.Post("/webhooks/subscriptions/"+subID+"/deliveries/"+id+"/replay", nil)
+		if err != nil {
+			failed++
+			results = append(results, replayResult{DeliveryID: id, Status: "error", Error: err.Error()})
+			continue
+		}
+		var resp struct {
+			ID         string `json:"id"`
+			Status     string `json:"status"`
+			StatusCode int    `json:"status_code"`
+		}
+		json.Unmarshal(data, &resp)
+		results = append(results, replayResult{DeliveryID: id, AttemptID: resp.ID, Status: resp.Status, StatusCode: resp.StatusCode})
+	}
+
+	if output.Format == "json" {
+		if err := output.JSON(map[string]any{"replayed": results}); err != nil {
+			return err
+		}
+	} else {
+		headers := []string{"DELIVERY", "NEW ATTEMPT", "STATUS", "HTTP"}
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			status := r.Status
+			if r.Error != "" {
+				status = "error: " + r.Error
+			}
+			code := "-"
+			if r.StatusCode > 0 {
+				code = fmt.Sprintf("%d", r.StatusCode)
+			}
+			rows = append(rows, []string{r.DeliveryID, orDash(r.AttemptID), status, code})
+		}
+		output.Table(headers, rows)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d replays failed", failed, len(ids))
+	}
+	return nil
+}
+
+type webhookEventType struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func fetchWebhookEventTypes(c *client.Client) ([]webhookEventType, []byte, error) {
+	data, _, err := c.Get("/webhooks/event-types")
+	if err != nil {
+		return nil, nil, err
+	}
+	var resp struct {
+		EventTypes []webhookEventType `json:"event_types"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, nil, fmt.Errorf("parse event types: %w", err)
+	}
+	return resp.EventTypes, data, nil
+}
+
+func webhookEventTypes(c *client.Client) error {
+	types, data, err := fetchWebhookEventTypes(c)
+	if err != nil {
+		return err
+	}
+
+	if output.Format == "json" {
+		var result any
+		json.Unmarshal(data, &result)
+		return output.JSON(result)
+	}
+
+	if len(types) == 0 {
+		fmt.Println("No event types reported by the server.")
+		return nil
+	}
+
+	headers := []string{"EVENT TYPE", "DESCRIPTION"}
+	rows := make([][]string, 0, len(types))
+	for _, et := range types {
+		rows = append(rows, []string{et.Name, et.Description})
+	}
+	output.Table(headers, rows)
+	return nil
+}
+
+// validateWebhookEvents rejects event types missing from the server's
+// catalog. If the catalog can't be fetched (e.g. an older server), it warns
+// and lets the server decide.
+func validateWebhookEvents(c *client.Client, events []string) error {
+	types, _, err := fetchWebhookEventTypes(c)
+	if err != nil {
+		output.Warn("Could not fetch event type catalog, skipping validation: %v", err)
+		return nil
+	}
+
+	known := make(map[string]bool, len(types))
+	names := make([]string, 0, len(types))
+	for _, et := range types {
+		known[et.Name] = true
+		names = append(names, et.Name)
+	}
+
+	var unknown []string
+	for _, e := range events {
+		if !known[e] {
+			unknown = append(unknown, e)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown event type(s): %s\n\nValid event types: %s\n"+
+			"Run 'ojs webhooks events' for descriptions, or pass --force to skip validation.",
+			strings.Join(unknown, ", "), strings.Join(names, ", "))
+	}
+	return nil
+}
+
 func printWebhooksUsage() error {
 	return fmt.Errorf("subcommand required\n\nUsage: ojs webhooks <subcommand>\n\n" +
 		"Subcommands:\n" +
 		"  create         Create a webhook subscription\n" +
+		"  update         Update a webhook subscription\n" +
 		"  list           List webhook subscriptions\n" +
 		"  get            Get webhook subscription details\n" +
 		"  delete         Delete a webhook subscription\n" +
 		"  test           Send a test webhook\n" +
-		"  rotate-secret  Rotate the webhook signing secret")
+		"  rotate-secret  Rotate the webhook signing secret\n" +
+		"  events         List valid webhook event types\n" +
+		"  replay         Resend past webhook deliveries")
 }
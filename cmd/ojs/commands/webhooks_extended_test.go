@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// withTableOutput switches to human-readable output for the duration of a test.
+func withTableOutput(t *testing.T) {
+	t.Helper()
+	orig := output.Format
+	output.Format = "table"
+	t.Cleanup(func() { output.Format = orig })
+}
+
+// serveEventTypes answers the event-type catalog request made by
+// webhooks create, reporting whether it handled r.
+func serveEventTypes(w http.ResponseWriter, r *http.Request) bool {
+	if r.URL.Path != "/ojs/v1/webhooks/event-types" {
+		return false
+	}
+	json.NewEncoder(w).Encode(map[string]any{
+		"event_types": []map[string]any{
+			{"name": "job.completed", "description": "A job finished successfully"},
+			{"name": "job.failed", "description": "A job attempt failed"},
+		},
+	})
+	return true
+}
+
+func TestWebhooks_Create_RetryPolicy(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if serveEventTypes(w, r) {
+			return
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		want := map[string]any{"max_retries": float64(5), "backoff": "exponential", "timeout_ms": float64(2500)}
+		if !reflect.DeepEqual(body["retry_policy"], want) {
+			t.Errorf("retry_policy = %v, want %v", body["retry_policy"], want)
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": "wh-1"})
+	})
+	err := Webhooks(c, []string{"create", "--url", "https://example.com/hook", "--events", "job.completed",
+		"--max-retries", "5", "--retry-backoff", "exponential", "--timeout-ms", "2500"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhooks_Create_NoRetryPolicy(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if serveEventTypes(w, r) {
+			return
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["retry_policy"]; ok {
+			t.Errorf("retry_policy should be omitted when no retry flags are set, got %v", body["retry_policy"])
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "wh-1"})
+	})
+	err := Webhooks(c, []string{"create", "--url", "https://example.com/hook", "--events", "job.completed"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhooks_Update_RetryPolicy(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/ojs/v1/webhooks/subscriptions/wh-1" {
+			t.Errorf("request = %s %s, want PATCH /ojs/v1/webhooks/subscriptions/wh-1", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		want := map[string]any{"max_retries": float64(0)}
+		if !reflect.DeepEqual(body["retry_policy"], want) {
+			t.Errorf("retry_policy = %v, want %v", body["retry_policy"], want)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "wh-1"})
+	})
+	if err := Webhooks(c, []string{"update", "wh-1", "--max-retries", "0"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWebhooks_Update_NoFields(t *testing.T) {
+	c := newTestClient(nil)
+	if err := Webhooks(c, []string{"update", "wh-1"}); err == nil {
+		t.Fatal("expected error when no fields are given")
+	}
+}
+
+func TestWebhooks_Get_ShowsRetryPolicy(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"id": "wh-1", "url": "https://example.com/hook", "events": []string{"job.completed"},
+			"retry_policy": map[string]any{"max_retries": 5, "backoff": "exponential", "timeout_ms": 2500},
+		})
+	})
+	out := captureStdout(t, func() {
+		if err := Webhooks(c, []string{"get", "wh-1"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	for _, want := range []string{"Max Retries", "5", "exponential", "2500"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWebhooks_Events_List(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if !serveEventTypes(w, r) {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	out := captureStdout(t, func() {
+		if err := Webhooks(c, []string{"events"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, "job.completed") || !strings.Contains(out, "A job attempt failed") {
+		t.Errorf("output missing event types:\n%s", out)
+	}
+}
+
+func TestWebhooks_Create_RejectsUnknownEvent(t *testing.T) {
+	posted := false
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if serveEventTypes(w, r) {
+			return
+		}
+		posted = true
+		json.NewEncoder(w).Encode(map[string]any{"id": "wh-1"})
+	})
+	err := Webhooks(c, []string{"create", "--url", "https://example.com/hook", "--events", "job.completed,job.finished"})
+	if err == nil || !strings.Contains(err.Error(), "job.finished") {
+		t.Fatalf("expected unknown event error, got %v", err)
+	}
+	if posted {
+		t.Error("subscription should not be created with an unknown event type")
+	}
+
+	if err := Webhooks(c, []string{"create", "--url", "https://example.com/hook", "--events", "job.finished", "--force"}); err != nil {
+		t.Fatalf("--force should bypass validation: %v", err)
+	}
+	if !posted {
+		t.Error("expected subscription to be created with --force")
+	}
+}
+
+func TestWebhooks_Replay_Single(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/ojs/v1/webhooks/subscriptions/wh-1/deliveries/dlv-9/replay" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "dlv-10", "status": "delivered", "status_code": 200})
+	})
+	out := captureStdout(t, func() {
+		if err := Webhooks(c, []string{"replay", "wh-1", "--event", "dlv-9"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+	if !strings.Contains(out, `"attempt_id": "dlv-10"`) || !strings.Contains(out, `"status": "delivered"`) {
+		t.Errorf("output = %s, want new attempt status", out)
+	}
+}
+
+func TestWebhooks_Replay_FailedSince(t *testing.T) {
+	var replayed []string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/webhooks/subscriptions/wh-1/deliveries":
+			if r.URL.Query().Get("status") != "failed" || r.URL.Query().Get("since") == "" {
+				t.Errorf("query = %s, want status=failed and since", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"deliveries": []map[string]any{{"id": "dlv-1"}, {"id": "dlv-2"}},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/replay"):
+			replayed = append(replayed, strings.Split(r.URL.Path, "/")[7])
+			json.NewEncoder(w).Encode(map[string]any{"status": "delivered"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	if err := Webhooks(c, []string{"replay", "wh-1", "--failed-since", "2d"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(replayed, ",") != "dlv-1,dlv-2" {
+		t.Errorf("replayed = %v, want dlv-1,dlv-2", replayed)
+	}
+}
+
+func TestWebhooks_Replay_MissingTarget(t *testing.T) {
+	c := newTestClient(nil)
+	if err := Webhooks(c, []string{"replay", "wh-1"}); err == nil {
+		t.Fatal("expected error without --event or --failed-since")
+	}
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+// identity is the authenticated principal reported by the server. Servers
+// use either OAuth introspection names (sub, scope, exp) or the OJS ones.
+type identity struct {
+	Subject   string
+	Name      string
+	Roles     []string
+	Scopes    []string
+	ExpiresAt time.Time // zero when the token does not expire or is unknown
+}
+
+// Whoami shows the identity, roles, scopes and token expiry of the
+// configured credentials.
+func Whoami(c *client.Client, args []string) error {
+	fs := newFlagSet("whoami")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs whoami", "Show who the configured token authenticates as: subject, roles, scopes\nand token expiry. Exits non-zero when not authenticated.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	data, _, err := c.Get("/auth/me")
+	if client.IsStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented) {
+		data, _, err = c.Get("/me")
+	}
+	if client.IsStatus(err, http.StatusUnauthorized) {
+		reason := "the server rejected the token"
+		if c.AuthToken() == "" {
+			reason = "no token configured (set OJS_AUTH_TOKEN)"
+		}
+		if output.Format == "json" {
+			if err := output.JSON(map[string]any{"authenticated": false, "server": c.BaseURL(), "reason": reason}); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("Not authenticated to %s: %s\n", c.BaseURL(), reason)
+		}
+		return fmt.Errorf("not authenticated: %s", reason)
+	}
+	if client.IsStatus(err, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented) {
+		return fmt.Errorf("server does not support identity introspection (no /auth/me or /me endpoint)")
+	}
+	if err != nil {
+		return err
+	}
+
+	if output.Format == "json" {
+		var result any
+		json.Unmarshal(data, &result)
+		return output.JSON(result)
+	}
+
+	id := parseIdentity(data)
+	rows := [][]string{
+		{"Server", c.BaseURL()},
+		{"Subject", orDash(id.Subject)},
+	}
+	if id.Name != "" {
+		rows = append(rows, []string{"Name", id.Name})
+	}
+	rows = append(rows,
+		[]string{"Roles", orDash(strings.Join(id.Roles, ", "))},
+		[]string{"Scopes", orDash(strings.Join(id.Scopes, ", "))},
+		[]string{"Token Expires", describeExpiry(id.ExpiresAt, time.Now())},
+	)
+	output.Table([]string{"FIELD", "VALUE"}, rows)
+	return nil
+}
+
+// parseIdentity reads an identity from an introspection response.
+func parseIdentity(data []byte) identity {
+	var raw map[string]any
+	json.Unmarshal(data, &raw)
+
+	id := identity{
+		Subject: firstString(raw, "subject", "sub"),
+		Name:    firstString(raw, "name", "username"),
+		Roles:   stringsOf(raw["roles"]),
+		Scopes:  stringsOf(raw["scopes"]),
+	}
+	if scope, ok := raw["scope"].(string); ok && len(id.Scopes) == 0 {
+		id.Scopes = strings.Fields(scope)
+	}
+	if s, ok := raw["expires_at"].(string); ok {
+		id.ExpiresAt, _ = time.Parse(time.RFC3339, s)
+	} else if exp, ok := raw["exp"].(float64); ok {
+		id.ExpiresAt = time.Unix(int64(exp), 0).UTC()
+	}
+	return id
+}
+
+func firstString(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func stringsOf(v any) []string {
+	list, _ := v.([]any)
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		out = append(out, str(item))
+	}
+	return out
+}
+
+// describeExpiry renders a token expiry with the time remaining.
+func describeExpiry(expiresAt, now time.Time) string {
+	if expiresAt.IsZero() {
+		return "never (or not reported)"
+	}
+	stamp := expiresAt.UTC().Format(time.RFC3339)
+	left := expiresAt.Sub(now)
+	if left <= 0 {
+		return fmt.Sprintf("%s (expired %s ago)", stamp, (-left).Truncate(time.Minute))
+	}
+	return fmt.Sprintf("%s (in %s)", stamp, left.Truncate(time.Minute))
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWhoami_Authenticated(t *testing.T) {
+	withTableOutput(t)
+	expires := time.Now().Add(2*time.Hour + 30*time.Second).UTC()
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/auth/me" {
+			t.Errorf("path = %s, want /ojs/v1/auth/me", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"subject":    "svc-billing",
+			"roles":      []string{"operator"},
+			"scope":      "jobs:write queues:read",
+			"expires_at": expires.Format(time.RFC3339),
+		})
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = Whoami(c, nil) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"svc-billing", "operator", "jobs:write, queues:read", expires.Format(time.RFC3339), "(in 2h0m0s)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWhoami_FallsBackToMe(t *testing.T) {
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/me" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"sub": "alice", "exp": 1893456000})
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = Whoami(c, nil) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"sub": "alice"`) {
+		t.Errorf("expected /me response passed through, got:\n%s", out)
+	}
+}
+
+func TestWhoami_Unauthenticated(t *testing.T) {
+	withTableOutput(t)
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":"unauthorized","message":"missing bearer token"}}`))
+	})
+
+	var err error
+	out := captureStdout(t, func() { err = Whoami(c, nil) })
+	if err == nil || !strings.Contains(err.Error(), "not authenticated") {
+		t.Errorf("err = %v, want not authenticated", err)
+	}
+	if !strings.Contains(out, "Not authenticated to") || !strings.Contains(out, "no token configured") {
+		t.Errorf("expected a clear unauthenticated message, got:\n%s", out)
+	}
+}
+
+func TestParseIdentity(t *testing.T) {
+	id := parseIdentity([]byte(`{"sub":"alice","name":"Alice","scopes":["a","b"],"scope":"ignored","exp":1893456000}`))
+	if id.Subject != "alice" || id.Name != "Alice" || strings.Join(id.Scopes, ",") != "a,b" {
+		t.Errorf("identity = %+v", id)
+	}
+	if !id.ExpiresAt.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expires = %v, want 2030-01-01", id.ExpiresAt)
+	}
+
+	now := time.Date(2030, 1, 1, 1, 30, 0, 0, time.UTC)
+	if got := describeExpiry(id.ExpiresAt, now); got != "2030-01-01T00:00:00Z (expired 1h30m0s ago)" {
+		t.Errorf("describeExpiry = %q", got)
+	}
+	if got := describeExpiry(time.Time{}, now); !strings.HasPrefix(got, "never") {
+		t.Errorf("describeExpiry(zero) = %q", got)
+	}
+}
@@ -2,8 +2,8 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -11,14 +11,31 @@ import (
 
 // Workers lists active workers and manages worker state.
 func Workers(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("workers", flag.ExitOnError)
+	fs := newFlagSet("workers")
 	quiet := fs.Bool("quiet", false, "Signal all workers to stop fetching new jobs")
 	resume := fs.Bool("resume", false, "Signal all workers to resume fetching jobs")
 	detail := fs.String("detail", "", "Show detailed info for a specific worker ID")
 	quietWorker := fs.String("quiet-worker", "", "Signal a specific worker to stop fetching")
 	deregister := fs.String("deregister", "", "Deregister a stale worker by ID")
-	fs.Parse(args)
+	pruneStale := fs.Bool("prune-stale", false, "Deregister all stale workers")
+	olderThan := fs.Duration("older-than", 15*time.Minute, "Heartbeat age after which a worker is considered stale (with --prune-stale)")
+	dryRun := fs.Bool("dry-run", false, "Show which workers would be pruned without deregistering them")
+	quietAll := fs.Bool("quiet-all", false, "Quiet every registered worker individually")
+	waitDrain := fs.Bool("wait-drain", false, "Wait until all workers report zero active jobs (with --quiet-all)")
+	timeout := fs.Int("timeout", 120, "Drain wait timeout in seconds (with --wait-drain)")
+	watch := fs.Bool("watch", false, "Re-poll the worker until it deregisters or has no active jobs (with --detail)")
 
+	if helpRequested(args) {
+		printHelp(fs, "ojs workers [flags]", "List workers and manage their state: quiet, resume, deregister and prune.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *detail != "" && *watch {
+		return watchWorker(c, *detail)
+	}
 	if *detail != "" {
 		return workerDetail(c, *detail)
 	}
@@ -28,6 +45,12 @@ func Workers(c *client.Client, args []string) error {
 	if *deregister != "" {
 		return deregisterWorker(c, *deregister)
 	}
+	if *pruneStale {
+		return pruneStaleWorkers(c, *olderThan, *dryRun)
+	}
+	if *quietAll {
+		return quietAllWorkers(c, *waitDrain, time.Duration(*timeout)*time.Second)
+	}
 	if *quiet {
 		return setWorkerDirective(c, "quiet")
 	}
@@ -130,8 +153,14 @@ func workerDetail(c *client.Client, workerID string) error {
 		StartedAt     string   `json:"started_at"`
 		Hostname      string   `json:"hostname"`
 		PID           int      `json:"pid"`
+		workerCounters
+		Stats *workerCounters `json:"stats"`
 	}
 	json.Unmarshal(data, &w)
+	counters := w.workerCounters
+	if w.Stats != nil {
+		counters = *w.Stats
+	}
 
 	queuesStr := "-"
 	if len(w.Queues) > 0 {
@@ -156,10 +185,41 @@ func workerDetail(c *client.Client, workerID string) error {
 		{"Started", w.StartedAt},
 		{"Last Heartbeat", w.LastHeartbeat},
 	}
+	rows = append(rows, counters.rows()...)
 	output.Table(headers, rows)
 	return nil
 }
 
+// workerCounters are a worker's cumulative job counters. Servers report
+// them at the top level of the worker or under "stats"; each is shown only
+// when present.
+type workerCounters struct {
+	Processed     *int64   `json:"processed"`
+	Failed        *int64   `json:"failed"`
+	Retried       *int64   `json:"retried"`
+	AvgDurationMs *float64 `json:"avg_duration_ms"`
+}
+
+func (wc workerCounters) rows() [][]string {
+	var rows [][]string
+	for _, n := range []struct {
+		label string
+		value *int64
+	}{
+		{"Processed", wc.Processed},
+		{"Failed", wc.Failed},
+		{"Retried", wc.Retried},
+	} {
+		if n.value != nil {
+			rows = append(rows, []string{n.label, fmt.Sprintf("%d", *n.value)})
+		}
+	}
+	if wc.AvgDurationMs != nil {
+		rows = append(rows, []string{"Avg Duration", fmt.Sprintf("%.2fms", *wc.AvgDurationMs)})
+	}
+	return rows
+}
+
 func quietSpecificWorker(c *client.Client, workerID string) error {
 	_, _, err := c.Post("/admin/workers/"+workerID+"/quiet", nil)
 	if err != nil {
@@ -177,3 +237,223 @@ func deregisterWorker(c *client.Client, workerID string) error {
 	output.Success("Worker %s deregistered", workerID)
 	return nil
 }
+
+// pruneStaleWorkers deregisters every worker that the server reports as stale
+// or whose last heartbeat is older than the given threshold.
+func pruneStaleWorkers(c *client.Client, olderThan time.Duration, dryRun bool) error {
+	workers, err := listWorkers(c)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var stale []string
+	for _, w := range workers {
+		if w.State == "stale" {
+			stale = append(stale, w.ID)
+			continue
+		}
+		hb, err := time.Parse(time.RFC3339, w.LastHeartbeat)
+		if err == nil && hb.Before(cutoff) {
+			stale = append(stale, w.ID)
+		}
+	}
+
+	var pruned, failed []string
+	if !dryRun {
+		for _, id := range stale {
+			if _, _, err := c.Delete("/admin/workers/" + id); err != nil {
+				output.Warn("Failed to deregister worker %s: %v", id, err)
+				failed = append(failed, id)
+				continue
+			}
+			pruned = append(pruned, id)
+		}
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{
+			"dry_run":    dryRun,
+			"stale":      nonNil(stale),
+			"pruned":     nonNil(pruned),
+			"failed":     nonNil(failed),
+			"total":      len(workers),
+			"older_than": olderThan.String(),
+		})
+	}
+
+	if len(stale) == 0 {
+		fmt.Println("No stale workers found.")
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("Would deregister %d stale worker(s):\n", len(stale))
+		for _, id := range stale {
+			fmt.Printf("  %s\n", id)
+		}
+		return nil
+	}
+
+	output.Success("Pruned %d stale worker(s) of %d total", len(pruned), len(workers))
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to deregister %d worker(s)", len(failed))
+	}
+	return nil
+}
+
+type workerSummary struct {
+	ID            string `json:"id"`
+	State         string `json:"state"`
+	Directive     string `json:"directive"`
+	ActiveJobs    int    `json:"active_jobs"`
+	LastHeartbeat string `json:"last_heartbeat"`
+}
+
+func listWorkers(c *client.Client) ([]workerSummary, error) {
+	data, _, err := c.Get("/admin/workers")
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Items []workerSummary `json:"items"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("parse workers response: %w", err)
+	}
+	return resp.Items, nil
+}
+
+// quietAllWorkers quiets each registered worker and optionally waits for
+// their active job counts to reach zero, e.g. before a deploy.
+func quietAllWorkers(c *client.Client, waitDrain bool, timeout time.Duration) error {
+	quieted, total, err := quietEachWorker(c)
+	if err != nil {
+		return err
+	}
+
+	active := -1
+	if waitDrain {
+		active, err = waitForWorkerDrain(c, timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	if output.Format == "json" {
+		result := map[string]any{
+			"quieted": nonNil(quieted),
+			"total":   total,
+		}
+		if waitDrain {
+			result["drained"] = true
+			result["active_jobs"] = active
+		}
+		return output.JSON(result)
+	}
+	if waitDrain {
+		output.Success("All workers drained")
+	}
+	return nil
+}
+
+// quietEachWorker sends the quiet directive to every registered worker and
+// returns the IDs that were quieted along with the total worker count.
+func quietEachWorker(c *client.Client) ([]string, int, error) {
+	workers, err := listWorkers(c)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var quieted, failed []string
+	for _, w := range workers {
+		if _, _, err := c.Post("/admin/workers/"+w.ID+"/quiet", nil); err != nil {
+			output.Warn("Failed to quiet worker %s: %v", w.ID, err)
+			failed = append(failed, w.ID)
+			continue
+		}
+		quieted = append(quieted, w.ID)
+	}
+	if output.Format != "json" {
+		output.Success("Quieted %d of %d worker(s)", len(quieted), len(workers))
+	}
+	if len(failed) > 0 {
+		return quieted, len(workers), fmt.Errorf("failed to quiet %d worker(s)", len(failed))
+	}
+	return quieted, len(workers), nil
+}
+
+// waitForWorkerDrain polls the worker list until the sum of active jobs is
+// zero or the timeout elapses.
+func waitForWorkerDrain(c *client.Client, timeout time.Duration) (int, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		workers, err := listWorkers(c)
+		if err != nil {
+			return 0, err
+		}
+		active := 0
+		for _, w := range workers {
+			active += w.ActiveJobs
+		}
+		if active == 0 {
+			return 0, nil
+		}
+		if time.Now().After(deadline) {
+			return active, fmt.Errorf("timed out after %s waiting for workers to drain (%d active jobs remaining)", timeout, active)
+		}
+		if output.Format != "json" {
+			fmt.Printf("Waiting for %d active job(s) to finish...\n", active)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// watchWorker re-polls a single worker and prints its active job count,
+// directive, and heartbeat age until it deregisters or goes idle.
+func watchWorker(c *client.Client, workerID string) error {
+	for {
+		data, _, err := c.Get("/admin/workers/" + workerID)
+		if err != nil {
+			if client.IsNotFound(err) {
+				if output.Format == "json" {
+					return output.JSON(map[string]any{"id": workerID, "deregistered": true})
+				}
+				output.Success("Worker %s deregistered", workerID)
+				return nil
+			}
+			return err
+		}
+
+		var w workerSummary
+		if err := json.Unmarshal(data, &w); err != nil {
+			return fmt.Errorf("parse worker response: %w", err)
+		}
+
+		if output.Format != "json" {
+			age := "-"
+			if hb, err := time.Parse(time.RFC3339, w.LastHeartbeat); err == nil {
+				age = time.Since(hb).Truncate(time.Second).String()
+			}
+			fmt.Printf("[%s] %s: active=%d directive=%s heartbeat=%s ago\n",
+				time.Now().Format("15:04:05"), w.ID, w.ActiveJobs, w.Directive, age)
+		}
+
+		if w.ActiveJobs == 0 {
+			if output.Format == "json" {
+				var result any
+				json.Unmarshal(data, &result)
+				return output.JSON(result)
+			}
+			output.Success("Worker %s has no active jobs", workerID)
+			return nil
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
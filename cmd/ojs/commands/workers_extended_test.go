@@ -3,7 +3,11 @@ package commands
 import (
 	"encoding/json"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestWorkers_List_MultipleWorkers(t *testing.T) {
@@ -147,3 +151,195 @@ func TestWorkers_Quiet_ServerError(t *testing.T) {
 		t.Fatal("expected error for server error on quiet")
 	}
 }
+
+func staleWorkersHandler(t *testing.T, mu *sync.Mutex, deleted *[]string) http.HandlerFunc {
+	now := time.Now().UTC()
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/admin/workers":
+			json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{"id": "fresh-1", "state": "running", "last_heartbeat": now.Add(-10 * time.Second).Format(time.RFC3339)},
+					{"id": "old-1", "state": "running", "last_heartbeat": now.Add(-1 * time.Hour).Format(time.RFC3339)},
+					{"id": "stale-1", "state": "stale", "last_heartbeat": now.Format(time.RFC3339)},
+					{"id": "fresh-2", "state": "quiet", "last_heartbeat": now.Add(-5 * time.Minute).Format(time.RFC3339)},
+				},
+			})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/ojs/v1/admin/workers/"):
+			mu.Lock()
+			*deleted = append(*deleted, strings.TrimPrefix(r.URL.Path, "/ojs/v1/admin/workers/"))
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestWorkers_PruneStale_OnlyStaleDeregistered(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	c := newTestClient(staleWorkersHandler(t, &mu, &deleted))
+
+	if err := Workers(c, []string{"--prune-stale", "--older-than", "15m"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sort.Strings(deleted)
+	if got := strings.Join(deleted, ","); got != "old-1,stale-1" {
+		t.Errorf("deleted = %s, want old-1,stale-1", got)
+	}
+}
+
+func TestWorkers_PruneStale_DryRun(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	c := newTestClient(staleWorkersHandler(t, &mu, &deleted))
+
+	if err := Workers(c, []string{"--prune-stale", "--dry-run"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("dry run deregistered workers: %v", deleted)
+	}
+}
+
+func TestWorkers_QuietAll_QuietsEachWorker(t *testing.T) {
+	var mu sync.Mutex
+	var quieted []string
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/ojs/v1/admin/workers":
+			json.NewEncoder(w).Encode(map[string]any{
+				"items": []map[string]any{
+					{"id": "worker-1", "state": "running", "active_jobs": 0},
+					{"id": "worker-2", "state": "running", "active_jobs": 0},
+				},
+			})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/quiet"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/ojs/v1/admin/workers/"), "/quiet")
+			mu.Lock()
+			quieted = append(quieted, id)
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if err := Workers(c, []string{"--quiet-all"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(quieted)
+	if got := strings.Join(quieted, ","); got != "worker-1,worker-2" {
+		t.Errorf("quieted = %s, want worker-1,worker-2", got)
+	}
+}
+
+func TestWorkers_QuietAll_WaitDrain(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	var mu sync.Mutex
+	polls := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{}`))
+			return
+		}
+		mu.Lock()
+		active := 2 - polls
+		if active < 0 {
+			active = 0
+		}
+		polls++
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{{"id": "worker-1", "active_jobs": active}},
+		})
+	})
+
+	if err := Workers(c, []string{"--quiet-all", "--wait-drain", "--timeout", "5"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// One list for quieting, then polls at 1 and 0 active jobs.
+	if polls != 3 {
+		t.Errorf("polls = %d, want 3", polls)
+	}
+}
+
+func TestWorkers_QuietAll_WaitDrainTimeout(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{{"id": "worker-1", "active_jobs": 4}},
+		})
+	})
+
+	err := Workers(c, []string{"--quiet-all", "--wait-drain", "--timeout", "0"})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+func TestWorkers_DetailWatch_ExitsWhenIdle(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	counts := []int{3, 2, 1, 0, 0}
+	polls := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/admin/workers/worker-1" {
+			t.Errorf("path = %s, want /ojs/v1/admin/workers/worker-1", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"id": "worker-1", "directive": "quiet", "active_jobs": counts[polls],
+			"last_heartbeat": time.Now().UTC().Format(time.RFC3339),
+		})
+		polls++
+	})
+
+	if err := Workers(c, []string{"--detail", "worker-1", "--watch"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 4 {
+		t.Errorf("polls = %d, want 4", polls)
+	}
+}
+
+func TestWorkers_DetailWatch_ExitsOnDeregister(t *testing.T) {
+	orig := pollInterval
+	pollInterval = time.Millisecond
+	defer func() { pollInterval = orig }()
+
+	polls := 0
+	c := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls > 1 {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{
+				"error": map[string]any{"code": "not_found", "message": "worker not found"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "worker-1", "active_jobs": 5})
+	})
+
+	if err := Workers(c, []string{"--detail", "worker-1", "--watch"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("polls = %d, want 2", polls)
+	}
+}
@@ -2,8 +2,12 @@ package commands
 
 import (
 	"encoding/json"
-	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/client"
 	"github.com/openjobspec/ojs-cli/internal/output"
@@ -14,6 +18,9 @@ func Workflow(c *client.Client, args []string) error {
 	if len(args) == 0 {
 		return printWorkflowUsage()
 	}
+	if isHelpArg(args[0]) {
+		return printSubcommandHelp(printWorkflowUsage())
+	}
 
 	switch args[0] {
 	case "create":
@@ -24,27 +31,60 @@ func Workflow(c *client.Client, args []string) error {
 		return workflowCancel(c, args[1:])
 	case "list":
 		return workflowList(c, args[1:])
+	case "export":
+		return workflowExport(c, args[1:])
 	default:
 		return printWorkflowUsage()
 	}
 }
 
 func workflowCreate(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("workflow create", flag.ExitOnError)
-	name := fs.String("name", "", "Workflow name (required)")
-	stepsJSON := fs.String("steps", "", "Steps as JSON array (required)")
-	fs.Parse(args)
-
-	if *name == "" || *stepsJSON == "" {
-		return fmt.Errorf("--name and --steps are required\n\n" +
-			"Usage: ojs workflow create --name <name> --steps '<json>'\n\n" +
+	fs := newFlagSet("workflow create")
+	name := fs.String("name", "", "Workflow name (required unless the --file definition has one)")
+	stepsJSON := fs.String("steps", "", "Steps as JSON array")
+	file := fs.String("file", "", "YAML or JSON workflow definition (e.g. from \"ojs workflow export\")")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs workflow create --name <name> --steps '<json>' | --file <pipeline.yaml>", "Create a workflow from a JSON array of steps or a definition file. Each step\n"+
+			"needs an id and a type and may set args, depends_on, a timeout (e.g. \"30s\",\n"+
+			"\"5m\") and a retry policy\n"+
+			"({\"max_attempts\": 3, \"backoff\": \"exponential\", \"initial_interval\": \"1s\"}).")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *file != "" && *stepsJSON != "" {
+		return fmt.Errorf("--steps and --file are mutually exclusive")
+	}
+
+	var steps []map[string]any
+	var err error
+	if *file != "" {
+		var fileName string
+		fileName, steps, err = loadWorkflowDefinition(*file)
+		if err != nil {
+			return err
+		}
+		if *name == "" {
+			*name = fileName
+		}
+	}
+
+	if *name == "" || (*stepsJSON == "" && *file == "") {
+		return fmt.Errorf("--name and --steps (or --file) are required\n\n" +
+			"Usage: ojs workflow create --name <name> --steps '<json>'\n" +
+			"       ojs workflow create --file <pipeline.yaml> [--name <name>]\n\n" +
 			"Example:\n" +
 			`  ojs workflow create --name order-pipeline --steps '[{"id":"validate","type":"order.validate","args":["order-123"]},{"id":"charge","type":"payment.charge","args":["order-123"],"depends_on":["validate"]}]'`)
 	}
 
-	var steps json.RawMessage
-	if err := json.Unmarshal([]byte(*stepsJSON), &steps); err != nil {
-		return fmt.Errorf("invalid --steps JSON: %w", err)
+	if *file == "" {
+		steps, err = parseWorkflowSteps([]byte(*stepsJSON))
+		if err != nil {
+			return err
+		}
 	}
 
 	body := map[string]any{
@@ -70,6 +110,11 @@ func workflowCreate(c *client.Client, args []string) error {
 }
 
 func workflowStatus(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs workflow status <workflow-id>", "Show the state of a workflow and its steps.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("workflow ID required\n\nUsage: ojs workflow status <workflow-id>")
 	}
@@ -91,12 +136,14 @@ func workflowStatus(c *client.Client, args []string) error {
 		Name  string `json:"name"`
 		State string `json:"state"`
 		Steps []struct {
-			ID          string `json:"id"`
-			Type        string `json:"type"`
-			State       string `json:"state"`
-			JobID       string `json:"job_id"`
-			StartedAt   string `json:"started_at"`
-			CompletedAt string `json:"completed_at"`
+			ID          string         `json:"id"`
+			Type        string         `json:"type"`
+			State       string         `json:"state"`
+			JobID       string         `json:"job_id"`
+			StartedAt   string         `json:"started_at"`
+			CompletedAt string         `json:"completed_at"`
+			Timeout     any            `json:"timeout"`
+			Retry       map[string]any `json:"retry"`
 		} `json:"steps"`
 		CreatedAt   string `json:"created_at"`
 		CompletedAt string `json:"completed_at"`
@@ -112,7 +159,7 @@ func workflowStatus(c *client.Client, args []string) error {
 	fmt.Println()
 
 	if len(wf.Steps) > 0 {
-		headers := []string{"STEP", "TYPE", "STATE", "JOB ID", "STARTED", "COMPLETED"}
+		headers := []string{"STEP", "TYPE", "STATE", "JOB ID", "TIMEOUT", "RETRY", "STARTED", "COMPLETED"}
 		rows := make([][]string, 0, len(wf.Steps))
 		for _, s := range wf.Steps {
 			started := s.StartedAt
@@ -127,7 +174,7 @@ func workflowStatus(c *client.Client, args []string) error {
 			if jobID == "" {
 				jobID = "-"
 			}
-			rows = append(rows, []string{s.ID, s.Type, s.State, jobID, started, completed})
+			rows = append(rows, []string{s.ID, s.Type, s.State, jobID, formatStepTimeout(s.Timeout), formatStepRetry(s.Retry), started, completed})
 		}
 		output.Table(headers, rows)
 	}
@@ -136,6 +183,11 @@ func workflowStatus(c *client.Client, args []string) error {
 }
 
 func workflowCancel(c *client.Client, args []string) error {
+	if helpRequested(args) {
+		printHelp(nil, "ojs workflow cancel <workflow-id>", "Cancel a workflow and its pending steps.")
+		return nil
+	}
+
 	if len(args) == 0 {
 		return fmt.Errorf("workflow ID required\n\nUsage: ojs workflow cancel <workflow-id>")
 	}
@@ -159,17 +211,33 @@ func workflowCancel(c *client.Client, args []string) error {
 }
 
 func workflowList(c *client.Client, args []string) error {
-	fs := flag.NewFlagSet("workflow list", flag.ExitOnError)
+	fs := newFlagSet("workflow list")
 	limit := fs.Int("limit", 25, "Max results to return")
 	state := fs.String("state", "", "Filter by state (running, completed, failed, cancelled)")
-	fs.Parse(args)
+	watch := fs.Bool("watch", false, "Continuously refresh the list with counts by state")
+	interval := fs.Int("interval", 5, "Refresh interval in seconds for --watch")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs workflow list [flags]", "List workflows.")
+		return nil
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
 	path := fmt.Sprintf("/workflows?limit=%d", *limit)
 	if *state != "" {
 		path += "&state=" + *state
 	}
 
-	data, _, err := c.Get(path)
+	if *watch {
+		if *interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		return workflowWatch(c, path, time.Duration(*interval)*time.Second)
+	}
+
+	data, resp, err := fetchWorkflows(c, path)
 	if err != nil {
 		return err
 	}
@@ -180,29 +248,44 @@ func workflowList(c *client.Client, args []string) error {
 		return output.JSON(result)
 	}
 
-	var resp struct {
-		Workflows []struct {
-			ID          string `json:"id"`
-			Name        string `json:"name"`
-			State       string `json:"state"`
-			StepCount   int    `json:"step_count"`
-			CreatedAt   string `json:"created_at"`
-			CompletedAt string `json:"completed_at"`
-		} `json:"workflows"`
-		Total int `json:"total"`
+	fmt.Printf("Workflows: %d total\n\n", resp.Total)
+	printWorkflowTable(resp.Workflows)
+	return nil
+}
+
+type workflowSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	StepCount   int    `json:"step_count"`
+	CreatedAt   string `json:"created_at"`
+	CompletedAt string `json:"completed_at"`
+}
+
+type workflowListResponse struct {
+	Workflows []workflowSummary `json:"workflows"`
+	Total     int               `json:"total"`
+}
+
+func fetchWorkflows(c *client.Client, path string) ([]byte, workflowListResponse, error) {
+	var resp workflowListResponse
+	data, _, err := c.Get(path)
+	if err != nil {
+		return nil, resp, err
 	}
 	json.Unmarshal(data, &resp)
+	return data, resp, nil
+}
 
-	fmt.Printf("Workflows: %d total\n\n", resp.Total)
-
-	if len(resp.Workflows) == 0 {
+func printWorkflowTable(workflows []workflowSummary) {
+	if len(workflows) == 0 {
 		fmt.Println("No workflows found.")
-		return nil
+		return
 	}
 
 	headers := []string{"ID", "NAME", "STATE", "STEPS", "CREATED", "COMPLETED"}
-	rows := make([][]string, 0, len(resp.Workflows))
-	for _, wf := range resp.Workflows {
+	rows := make([][]string, 0, len(workflows))
+	for _, wf := range workflows {
 		completed := wf.CompletedAt
 		if completed == "" {
 			completed = "-"
@@ -214,7 +297,91 @@ func workflowList(c *client.Client, args []string) error {
 		})
 	}
 	output.Table(headers, rows)
-	return nil
+}
+
+// workflowStateOrder is the display order of workflow states in the
+// --watch summary. Unknown states follow in name order.
+var workflowStateOrder = []string{"pending", "running", "completed", "failed", "cancelled"}
+
+// stateCount is the number of workflows in one state.
+type stateCount struct {
+	State string `json:"state"`
+	Count int    `json:"count"`
+}
+
+// workflowStateCounts counts workflows by state in display order, leaving
+// out states with no workflows.
+func workflowStateCounts(workflows []workflowSummary) []stateCount {
+	counts := map[string]int{}
+	for _, wf := range workflows {
+		counts[wf.State]++
+	}
+	var out []stateCount
+	for _, st := range workflowStateOrder {
+		if counts[st] > 0 {
+			out = append(out, stateCount{st, counts[st]})
+			delete(counts, st)
+		}
+	}
+	rest := make([]string, 0, len(counts))
+	for st := range counts {
+		rest = append(rest, st)
+	}
+	sort.Strings(rest)
+	for _, st := range rest {
+		out = append(out, stateCount{st, counts[st]})
+	}
+	return out
+}
+
+// formatStateCounts renders counts as "⟳ running 2  ✓ completed 5".
+func formatStateCounts(counts []stateCount) string {
+	parts := make([]string, 0, len(counts))
+	for _, sc := range counts {
+		parts = append(parts, fmt.Sprintf("%s %d", colorState(sc.State), sc.Count))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// workflowWatch re-fetches the workflow list every interval and redraws it
+// in place, with counts by state at the top, until interrupted.
+func workflowWatch(c *client.Client, path string, interval time.Duration) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		data, resp, err := fetchWorkflows(c, path)
+		now := time.Now()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠ refresh error: %v\n", err)
+		} else {
+			counts := workflowStateCounts(resp.Workflows)
+			if output.Format == "json" {
+				var result any
+				json.Unmarshal(data, &result)
+				output.JSON(map[string]any{"timestamp": now.UTC().Format(time.RFC3339), "counts": counts, "workflows": result})
+			} else {
+				fmt.Print("\033[2J\033[H")
+				fmt.Printf("Updated %s (Ctrl-C to stop)\n\n", now.Format("15:04:05"))
+				fmt.Printf("Workflows: %d total  %s\n\n", resp.Total, formatStateCounts(counts))
+				for i := range resp.Workflows {
+					resp.Workflows[i].State = colorState(resp.Workflows[i].State)
+				}
+				printWorkflowTable(resp.Workflows)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-sigCh:
+			fmt.Println("\nWorkflow watch stopped.")
+			return nil
+		}
+	}
 }
 
 func printWorkflowUsage() error {
@@ -223,5 +390,6 @@ func printWorkflowUsage() error {
 		"  create   Create a new workflow\n" +
 		"  status   Get workflow status\n" +
 		"  cancel   Cancel a workflow\n" +
-		"  list     List workflows")
+		"  list     List workflows\n" +
+		"  export   Save a workflow's steps as a reusable definition")
 }
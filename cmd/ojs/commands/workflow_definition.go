@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/client"
+	"github.com/openjobspec/ojs-cli/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+// workflowDefinition is the file format read by "workflow create --file"
+// and written by "workflow export". JSON files are accepted too, since JSON
+// is valid YAML.
+type workflowDefinition struct {
+	Name  string            `yaml:"name" json:"name"`
+	Steps []workflowStepDef `yaml:"steps" json:"steps"`
+}
+
+// workflowStepDef is the reusable part of a step. Decoding a workflow from
+// the server into it drops runtime fields such as state, job_id and the
+// started/completed times.
+type workflowStepDef struct {
+	ID        string         `yaml:"id" json:"id"`
+	Type      string         `yaml:"type" json:"type"`
+	Args      any            `yaml:"args,omitempty" json:"args,omitempty"`
+	DependsOn []string       `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+	Timeout   any            `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+	Retry     map[string]any `yaml:"retry,omitempty" json:"retry,omitempty"`
+}
+
+// loadWorkflowDefinition reads a definition file and returns its name and
+// its steps, validated the same way as --steps. Step fields other than the
+// ones workflowStepDef names are passed through.
+func loadWorkflowDefinition(path string) (string, []map[string]any, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var def struct {
+		Name  string           `yaml:"name"`
+		Steps []map[string]any `yaml:"steps"`
+	}
+	if err := yaml.Unmarshal(raw, &def); err != nil {
+		return "", nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	stepsJSON, err := json.Marshal(def.Steps)
+	if err != nil {
+		return "", nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	steps, err := parseWorkflowSteps(stepsJSON)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %s", path, strings.TrimPrefix(err.Error(), "--steps "))
+	}
+	return def.Name, steps, nil
+}
+
+// workflowExport writes an existing workflow's step graph as a definition
+// that "workflow create --file" can recreate.
+func workflowExport(c *client.Client, args []string) error {
+	fs := newFlagSet("workflow export")
+	out := fs.String("out", "", "File to write the definition to (default: stdout)")
+
+	if helpRequested(args) {
+		printHelp(fs, "ojs workflow export <workflow-id> [--out <pipeline.yaml>]", "Save a workflow's steps (types, args, depends_on, timeout, retry) as a YAML\ndefinition for \"ojs workflow create --file\". Runtime state is left out.")
+		return nil
+	}
+
+	var wfID string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		wfID = args[0]
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+	} else {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		wfID = fs.Arg(0)
+	}
+	if wfID == "" {
+		return fmt.Errorf("workflow ID required\n\nUsage: ojs workflow export <workflow-id> [--out <pipeline.yaml>]")
+	}
+
+	data, _, err := c.Get("/workflows/" + wfID)
+	if err != nil {
+		return err
+	}
+	var def workflowDefinition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return fmt.Errorf("parse workflow: %w", err)
+	}
+	if len(def.Steps) == 0 {
+		return fmt.Errorf("workflow %s has no steps to export", wfID)
+	}
+	for i := range def.Steps {
+		def.Steps[i].Timeout = exportStepTimeout(def.Steps[i].Timeout)
+	}
+
+	if *out == "" && output.Format == "json" {
+		return output.JSON(def)
+	}
+	encoded, err := yaml.Marshal(def)
+	if err != nil {
+		return fmt.Errorf("encode definition: %w", err)
+	}
+	if *out == "" {
+		fmt.Print(string(encoded))
+		return nil
+	}
+	if err := os.WriteFile(*out, encoded, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", *out, err)
+	}
+
+	if output.Format == "json" {
+		return output.JSON(map[string]any{"workflow_id": wfID, "steps": len(def.Steps), "file": *out})
+	}
+	output.Success("Exported workflow %s (%d steps) to %s", wfID, len(def.Steps), *out)
+	return nil
+}
+
+// exportStepTimeout converts a numeric step timeout, which servers report in
+// seconds, to a duration string that "workflow create --file" accepts.
+func exportStepTimeout(t any) any {
+	if secs, ok := t.(float64); ok {
+		return (time.Duration(secs * float64(time.Second))).String()
+	}
+	return t
+}
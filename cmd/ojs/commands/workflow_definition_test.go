@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func workflowFixtureHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ojs/v1/workflows/wf-1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"id": "wf-1", "name": "order-pipeline", "state": "completed",
+			"created_at": "2026-01-01T10:00:00Z", "completed_at": "2026-01-01T10:05:00Z",
+			"steps": []map[string]any{
+				{
+					"id": "validate", "type": "order.validate", "args": []any{"order-123", map[string]any{"strict": true}},
+					"state": "completed", "job_id": "job-1", "started_at": "2026-01-01T10:00:01Z", "completed_at": "2026-01-01T10:00:02Z",
+					"timeout": "30s",
+				},
+				{
+					"id": "charge", "type": "payment.charge", "args": []any{"order-123"}, "depends_on": []any{"validate"},
+					"state": "completed", "job_id": "job-2", "result": map[string]any{"ok": true},
+					"retry": map[string]any{"max_attempts": 3, "backoff": "exponential", "delay_ms": 2000}, "timeout": 60,
+				},
+			},
+		})
+	}
+}
+
+func TestWorkflow_Export_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pipeline.yaml")
+	c := newTestClient(workflowFixtureHandler(t))
+	if err := Workflow(c, []string{"export", "wf-1", "--out", path}); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	raw, _ := os.ReadFile(path)
+	for _, runtime := range []string{"state:", "job_id:", "started_at:", "completed_at:", "result:", "wf-1"} {
+		if strings.Contains(string(raw), runtime) {
+			t.Errorf("exported definition contains runtime field %q:\n%s", runtime, raw)
+		}
+	}
+
+	name, steps, err := loadWorkflowDefinition(path)
+	if err != nil {
+		t.Fatalf("exported definition rejected by create: %v\n%s", err, raw)
+	}
+	if name != "order-pipeline" || len(steps) != 2 {
+		t.Fatalf("name = %q, steps = %v", name, steps)
+	}
+	if steps[0]["timeout"] != "30s" || !reflect.DeepEqual(steps[1]["depends_on"], []any{"validate"}) {
+		t.Errorf("step graph not preserved: %v", steps)
+	}
+	if !reflect.DeepEqual(steps[0]["args"], []any{"order-123", map[string]any{"strict": true}}) {
+		t.Errorf("args = %v", steps[0]["args"])
+	}
+
+	var sent map[string]any
+	c = newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": "wf-2", "state": "running"})
+	})
+	if err := Workflow(c, []string{"create", "--file", path, "--name", "nightly"}); err != nil {
+		t.Fatalf("create --file: %v", err)
+	}
+	charge := sent["steps"].([]any)[1].(map[string]any)
+	if sent["name"] != "nightly" || !reflect.DeepEqual(charge["retry"], map[string]any{"max_attempts": float64(3), "backoff": "exponential", "delay_ms": float64(2000)}) {
+		t.Errorf("create body = %v", sent)
+	}
+	if charge["timeout"] != "1m0s" {
+		t.Errorf("numeric timeout should be exported as a duration, got %v", charge["timeout"])
+	}
+}
+
+func TestWorkflow_Export_Stdout(t *testing.T) {
+	c := newTestClient(workflowFixtureHandler(t))
+	out := captureStdout(t, func() {
+		if err := Workflow(c, []string{"export", "wf-1"}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	var def workflowDefinition
+	if err := json.Unmarshal([]byte(out), &def); err != nil {
+		t.Fatalf("expected JSON definition, got %q", out)
+	}
+	if def.Name != "order-pipeline" || len(def.Steps) != 2 || def.Steps[1].DependsOn[0] != "validate" {
+		t.Errorf("definition = %+v", def)
+	}
+}
+
+func TestWorkflow_Create_FileValidation(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.yaml")
+	os.WriteFile(bad, []byte("name: etl\nsteps:\n  - id: extract\n    type: etl.extract\n    timeout: -1m\n"), 0644)
+	unnamed := filepath.Join(dir, "unnamed.yaml")
+	os.WriteFile(unnamed, []byte("steps:\n  - id: extract\n    type: etl.extract\n"), 0644)
+
+	c := newTestClient(noRequestClient(t))
+	if err := Workflow(c, []string{"create", "--file", bad}); err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+	if err := Workflow(c, []string{"create", "--file", unnamed}); err == nil {
+		t.Error("expected error for a definition without a name")
+	}
+	if err := Workflow(c, []string{"create", "--file", unnamed, "--steps", "[]"}); err == nil {
+		t.Error("expected error for --file with --steps")
+	}
+}
@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// workflowRetryKeys are the fields accepted in a step's retry policy.
+// initial_interval is a duration that is sent as delay_ms, the same shape
+// "ojs enqueue --initial-interval" produces.
+var workflowRetryKeys = []string{"max_attempts", "backoff", "initial_interval", "delay_ms"}
+
+// parseWorkflowSteps decodes --steps and checks each step before anything is
+// sent: id and type are required, ids are unique, depends_on names earlier
+// or later steps of the same workflow, timeout is a positive duration such
+// as "30s" or "5m", and retry is a policy like
+// {"max_attempts": 3, "backoff": "exponential", "initial_interval": "1s"}.
+// Other step fields are passed through unchanged.
+func parseWorkflowSteps(raw []byte) ([]map[string]any, error) {
+	var steps []map[string]any
+	if err := json.Unmarshal(raw, &steps); err != nil {
+		return nil, fmt.Errorf("invalid --steps JSON: %w", err)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("--steps must contain at least one step")
+	}
+
+	ids := make(map[string]bool, len(steps))
+	for i, s := range steps {
+		id, _ := s["id"].(string)
+		if id == "" {
+			return nil, fmt.Errorf("step %d: id is required", i+1)
+		}
+		if ids[id] {
+			return nil, fmt.Errorf("step %q: duplicate id", id)
+		}
+		ids[id] = true
+		if t, _ := s["type"].(string); t == "" {
+			return nil, fmt.Errorf("step %q: type is required", id)
+		}
+	}
+
+	for _, s := range steps {
+		id := s["id"].(string)
+		if deps, ok := s["depends_on"]; ok {
+			list, ok := deps.([]any)
+			if !ok {
+				return nil, fmt.Errorf("step %q: depends_on must be an array of step ids", id)
+			}
+			for _, d := range list {
+				dep, _ := d.(string)
+				if !ids[dep] || dep == id {
+					return nil, fmt.Errorf("step %q: depends_on references unknown step %v", id, d)
+				}
+			}
+		}
+		if t, ok := s["timeout"]; ok {
+			ts, _ := t.(string)
+			d, err := parseDuration(ts)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("step %q: timeout must be a positive duration such as 30s or 5m, got %v", id, t)
+			}
+		}
+		if r, ok := s["retry"]; ok {
+			retry, err := workflowStepRetry(id, r)
+			if err != nil {
+				return nil, err
+			}
+			s["retry"] = retry
+		}
+	}
+	return steps, nil
+}
+
+// workflowStepRetry validates a step's retry policy and converts it to the
+// wire shape (max_attempts, backoff, delay_ms).
+func workflowStepRetry(id string, r any) (map[string]any, error) {
+	policy, ok := r.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("step %q: retry must be an object such as {\"max_attempts\": 3}", id)
+	}
+	for k := range policy {
+		if !contains(workflowRetryKeys, k) {
+			return nil, fmt.Errorf("step %q: unknown retry field %q (use %s)", id, k, strings.Join(workflowRetryKeys, ", "))
+		}
+	}
+
+	maxAttempts := 0
+	if v, ok := policy["max_attempts"]; ok {
+		n, ok := v.(float64)
+		if !ok || n < 0 || n != float64(int(n)) {
+			return nil, fmt.Errorf("step %q: retry.max_attempts must be a non-negative integer, got %v", id, v)
+		}
+		maxAttempts = int(n)
+	}
+	backoff, _ := policy["backoff"].(string)
+	if backoff != "" && !contains(retryBackoffs, backoff) {
+		return nil, fmt.Errorf("step %q: invalid retry.backoff %q (use %s)", id, backoff, strings.Join(retryBackoffs, ", "))
+	}
+	if v, ok := policy["delay_ms"]; ok {
+		if n, ok := v.(float64); !ok || n < 0 {
+			return nil, fmt.Errorf("step %q: retry.delay_ms must be a non-negative number, got %v", id, v)
+		}
+	}
+	interval, _ := policy["initial_interval"].(string)
+	if _, ok := policy["initial_interval"]; ok && interval == "" {
+		return nil, fmt.Errorf("step %q: retry.initial_interval must be a duration such as 500ms or 30s", id)
+	}
+
+	existing := map[string]any{}
+	if v, ok := policy["delay_ms"]; ok {
+		existing["delay_ms"] = v
+	}
+	retry, err := retryPolicy(existing, maxAttempts, backoff, interval)
+	if err != nil {
+		return nil, fmt.Errorf("step %q: invalid retry.initial_interval %q (e.g. 500ms, 30s, 5m)", id, interval)
+	}
+	if _, ok := policy["max_attempts"]; ok {
+		retry["max_attempts"] = maxAttempts
+	}
+	return retry, nil
+}
+
+// formatStepTimeout renders a step's timeout for the status table.
+func formatStepTimeout(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return orDash(t)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// formatStepRetry renders a step's retry policy as e.g. "3x exponential".
+func formatStepRetry(retry map[string]any) string {
+	if len(retry) == 0 {
+		return "-"
+	}
+	var parts []string
+	if n, ok := retry["max_attempts"].(float64); ok {
+		parts = append(parts, strconv.Itoa(int(n))+"x")
+	}
+	if b, ok := retry["backoff"].(string); ok && b != "" {
+		parts = append(parts, b)
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	return strings.Join(parts, " ")
+}
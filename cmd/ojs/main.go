@@ -1,8 +1,11 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/cmd/ojs/commands"
 	"github.com/openjobspec/ojs-cli/internal/client"
@@ -14,21 +17,103 @@ const version = "0.1.0"
 
 func main() {
 	cfg := config.Load()
-	c := client.New(cfg)
+	output.MaxColumnWidth = cfg.MaxColumnWidth
 
 	if len(os.Args) < 2 {
 		printUsage()
 		os.Exit(1)
 	}
 
-	// Global flags
-	args := os.Args[1:]
+	args, err := parseGlobalFlags(cfg, os.Args[1:])
+	switch {
+	case errors.Is(err, errShowVersion):
+		fmt.Println("ojs version", version)
+		os.Exit(0)
+	case errors.Is(err, errShowUsage):
+		printUsage()
+		os.Exit(0)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	c := client.New(cfg)
+
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	if err := c.Err(); err != nil {
+		output.Error(err)
+		os.Exit(1)
+	}
+
+	err = run(cfg, c, args)
+	if errors.Is(err, client.ErrRequestNotSent) {
+		return
+	}
+	if errors.Is(err, errUnknownCommand) {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", args[0])
+		printUsage()
+		os.Exit(1)
+	}
+	if err != nil {
+		output.Error(err)
+		os.Exit(commands.ExitCode(err))
+	}
+}
+
+var errUnknownCommand = errors.New("unknown command")
+
+var (
+	errShowVersion = errors.New("show version")
+	errShowUsage   = errors.New("show usage")
+)
+
+// parseGlobalFlags applies the global flags in args to cfg and the output
+// settings and returns the remaining arguments. Flags that a command may
+// define itself (--url, --proxy, --timeout, --quiet, --help) are only global
+// before the command name; after it they are left for the command.
+func parseGlobalFlags(cfg *config.Config, args []string) ([]string, error) {
+	args = append([]string(nil), args...)
+	commandSeen := false
 	for i := 0; i < len(args); i++ {
 		switch args[i] {
 		case "--url":
-			if i+1 < len(args) {
+			if !commandSeen && i+1 < len(args) {
 				cfg.ServerURL = args[i+1]
-				c = client.New(cfg)
+				args = append(args[:i], args[i+2:]...)
+				i--
+			}
+		case "--proxy":
+			if !commandSeen && i+1 < len(args) {
+				cfg.Proxy = args[i+1]
+				args = append(args[:i], args[i+2:]...)
+				i--
+			}
+		case "--timeout":
+			// Only before the command name: several commands have their
+			// own --timeout for polling.
+			if !commandSeen && i+1 < len(args) {
+				d, err := time.ParseDuration(args[i+1])
+				if err != nil || d <= 0 {
+					return nil, fmt.Errorf("invalid --timeout %q (expected a duration like 30s or 2m)", args[i+1])
+				}
+				cfg.Timeout = d
+				args = append(args[:i], args[i+2:]...)
+				i--
+			}
+		case "--print-request", "--print-only":
+			if args[i] == "--print-only" {
+				cfg.PrintOnly = true
+			}
+			cfg.PrintRequest = true
+			args = append(args[:i], args[i+1:]...)
+			i--
+		case "--query":
+			if i+1 < len(args) {
+				output.Query = args[i+1]
+				output.Format = "json"
 				args = append(args[:i], args[i+2:]...)
 				i--
 			}
@@ -36,20 +121,36 @@ func main() {
 			output.Format = "json"
 			args = append(args[:i], args[i+1:]...)
 			i--
+		case "--quiet", "-q":
+			// Only before the command name: "workers --quiet" is a
+			// command flag.
+			if !commandSeen {
+				output.Quiet = true
+				args = append(args[:i], args[i+1:]...)
+				i--
+			}
+		case "--wide":
+			output.Wide = true
+			args = append(args[:i], args[i+1:]...)
+			i--
 		case "--version", "-v":
-			fmt.Println("ojs version", version)
-			os.Exit(0)
+			return nil, errShowVersion
 		case "--help", "-h":
-			printUsage()
-			os.Exit(0)
+			// After the command name, help is the command's own.
+			if !commandSeen {
+				return nil, errShowUsage
+			}
+		default:
+			if !strings.HasPrefix(args[i], "-") {
+				commandSeen = true
+			}
 		}
 	}
+	return args, nil
+}
 
-	if len(args) == 0 {
-		printUsage()
-		os.Exit(1)
-	}
-
+// run dispatches one command. args[0] is the command name.
+func run(cfg *config.Config, c *client.Client, args []string) error {
 	var err error
 	switch args[0] {
 	case "enqueue":
@@ -58,6 +159,8 @@ func main() {
 		err = commands.Status(c, args[1:])
 	case "cancel":
 		err = commands.Cancel(c, args[1:])
+	case "ping":
+		err = commands.Ping(c, args[1:])
 	case "health":
 		err = commands.Health(c, args[1:])
 	case "queues":
@@ -70,16 +173,26 @@ func main() {
 		err = commands.Cron(c, args[1:])
 	case "monitor":
 		err = commands.Monitor(c, args[1:])
+	case "top":
+		err = commands.Top(c, args[1:])
 	case "workflow":
 		err = commands.Workflow(c, args[1:])
 	case "migrate":
 		err = commands.Migrate(c, args[1:])
+	case "shell":
+		err = commands.Shell(cfg, c, func(c *client.Client, args []string) error {
+			return run(cfg, c, args)
+		}, args[1:])
 	case "completion":
 		err = commands.Completion(args[1:])
 	case "jobs":
 		err = commands.Jobs(c, args[1:])
 	case "result":
 		err = commands.Result(c, args[1:])
+	case "logs":
+		err = commands.Logs(c, args[1:])
+	case "attach":
+		err = commands.Attach(c, args[1:])
 	case "bulk":
 		err = commands.Bulk(c, args[1:])
 	case "priority":
@@ -102,22 +215,24 @@ func main() {
 		err = commands.Retry(c, args[1:])
 	case "doctor":
 		err = commands.Doctor(c, args[1:])
+	case "whoami":
+		err = commands.Whoami(c, args[1:])
+	case "replay-file":
+		err = commands.ReplayFile(c, args[1:])
+	case "diff":
+		err = commands.Diff(c, args[1:])
 	case "debug":
 		err = commands.Debug(c, args[1:])
+	case "template":
+		err = commands.Template(c, args[1:])
 	case "codegen":
 		err = commands.Codegen(args[1:])
 	case "contract":
 		err = commands.RunContractCommand(args[1:])
 	default:
-		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", args[0])
-		printUsage()
-		os.Exit(1)
-	}
-
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("%w: %s", errUnknownCommand, args[0])
 	}
+	return err
 }
 
 func printUsage() {
@@ -132,10 +247,13 @@ Job Commands:
   cancel       Cancel a job
   retry        Retry a job
   result       Get job result
+  logs         Show job execution logs (--follow, --tail)
+  attach       Follow a job to completion and print its result (--out, --timeout)
   jobs         List and search jobs
   priority     Update job priority
   retries      View job retry history
   bulk         Bulk cancel/retry/delete operations
+  replay-file  Re-enqueue jobs from an NDJSON export (--queue, --dry-run)
 
 Queue & Server Commands:
   queues       List, create, delete, purge, configure, pause/resume queues
@@ -150,26 +268,55 @@ Queue & Server Commands:
   stats        Aggregate system statistics
   system       System maintenance mode and config
   health       Check server health
+  ping         Measure /health latency (--count, --interval, --strict)
   monitor      Live monitoring dashboard
+  top          Live view of active jobs sorted by runtime
 
 Utility Commands:
   migrate      Migrate jobs from other systems
   contract     Validate producer/consumer schema contracts
-  doctor       Run health and production readiness checks
+  doctor       Audit server production readiness
+  whoami       Show the authenticated identity, roles, scopes and token expiry
   debug        Interactive job debugging (inspect, trace, replay, history, bottleneck)
+  diff         Compare two jobs field by field (diff jobs <a> <b>)
   codegen      Generate type-safe SDK code from job definitions
-  completion   Generate shell completions
+  template     Manage local job templates (list, show, save, delete)
+  completion   Generate or install shell completions (completion install <shell>)
+  shell        Interactive shell sharing one connection
 
 Global Flags:
   --url <url>  OJS server URL (default: $OJS_URL or http://localhost:8080)
   --json       Output as JSON
+  --quiet, -q  Suppress success and warning messages (before the command name)
+  --wide       Show full table cells instead of truncating them to $OJS_MAX_COLUMN_WIDTH (default 60)
+  --query <p>  Print only the value at a JSON path, e.g. jobs.0.id or jobs.#.state
+  --print-request  Print each mutating request (method, URL, body) to stderr before sending
+  --print-only     Print mutating requests without sending them
+  --timeout    Per-request HTTP timeout, e.g. 10s or 2m (default: 30s)
+  --proxy      HTTP proxy URL (default: $HTTPS_PROXY / $HTTP_PROXY, honoring $NO_PROXY)
   --version    Show version
   --help       Show help
 
+  --url, --proxy, --timeout and --quiet are global only before the command
+  name; after it they belong to the command (e.g. webhooks update --url).
+
+Exit Codes:
+  0  Success
+  1  Error
+  3  Job or resource not found
+
 Environment Variables:
   OJS_URL         Server URL
   OJS_AUTH_TOKEN  Authentication token
   OJS_OUTPUT      Default output format (table|json)
+  OJS_CA_CERT     PEM file of CA certificates to trust
+  OJS_CLIENT_CERT Client certificate for mTLS (with OJS_CLIENT_KEY)
+  OJS_CLIENT_KEY  Client private key for mTLS
+  OJS_INSECURE_SKIP_VERIFY  Skip TLS certificate verification (true|false)
+  OJS_COMPRESS_REQUESTS     Gzip request bodies of 64 KiB or more (true|false)
+  OJS_PRIORITY_NAMES  Symbolic priorities for enqueue --priority-name (critical=10,high=7,normal=5,low=1)
+  OJS_MAX_COLUMN_WIDTH  Truncate table cells wider than this (default 60, 0 disables)
+  OJS_TEMPLATE_DIR    Job templates for enqueue --from-template (default: ~/.ojs/templates)
 `)
 }
 
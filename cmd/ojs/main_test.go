@@ -0,0 +1,46 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/config"
+	"github.com/openjobspec/ojs-cli/internal/output"
+)
+
+func TestParseGlobalFlags_CommandURLIsNotGlobal(t *testing.T) {
+	cfg := &config.Config{ServerURL: "http://ojs:8080"}
+	args, err := parseGlobalFlags(cfg, []string{"webhooks", "update", "wh-1", "--url", "https://hook.example", "--proxy", "http://p:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ServerURL != "http://ojs:8080" || cfg.Proxy != "" {
+		t.Errorf("command flags changed the server: url %q, proxy %q", cfg.ServerURL, cfg.Proxy)
+	}
+	want := []string{"webhooks", "update", "wh-1", "--url", "https://hook.example", "--proxy", "http://p:3128"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("args = %q, want %q", args, want)
+	}
+}
+
+func TestParseGlobalFlags_BeforeCommand(t *testing.T) {
+	origFormat := output.Format
+	t.Cleanup(func() { output.Format = origFormat })
+
+	cfg := &config.Config{}
+	args, err := parseGlobalFlags(cfg, []string{"--url", "http://other:8080", "--timeout", "5s", "status", "job-1", "--json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ServerURL != "http://other:8080" || cfg.Timeout != 5*time.Second || output.Format != "json" {
+		t.Errorf("cfg = %+v, format %q", cfg, output.Format)
+	}
+	if !reflect.DeepEqual(args, []string{"status", "job-1"}) {
+		t.Errorf("args = %q", args)
+	}
+
+	if _, err := parseGlobalFlags(&config.Config{}, []string{"--timeout", "0", "status"}); err == nil {
+		t.Error("expected error for a non-positive --timeout")
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// APIError is returned for responses with a 4xx or 5xx status. Code and
+// Message come from the OJS error body when the server sends one; otherwise
+// Code is empty and Message holds the raw response body.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Retryable  bool
+	RequestID  string
+}
+
+// Error formats the error as "code: message" for OJS error bodies and as
+// "HTTP <status>: <body>" otherwise.
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Message)
+}
+
+// newAPIError builds an APIError from a failed response and its body.
+func newAPIError(resp *http.Response, data []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    string(data),
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	var errResp ErrorResponse
+	if json.Unmarshal(data, &errResp) == nil && errResp.Error.Code != "" {
+		e.Code = errResp.Error.Code
+		e.Message = errResp.Error.Message
+		e.Retryable = errResp.Error.Retryable
+		if e.RequestID == "" {
+			e.RequestID = errResp.Error.RequestID
+		}
+	}
+	return e
+}
+
+// IsStatus reports whether err is an APIError with one of the given HTTP
+// status codes.
+func IsStatus(err error, codes ...int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether err is a 404 or an OJS "not_found" error.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.Code == "not_found")
+}
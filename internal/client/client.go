@@ -2,10 +2,15 @@ package client
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/config"
@@ -15,16 +20,60 @@ import (
 type Client struct {
 	cfg    *config.Config
 	http   *http.Client
+	err    error
+
+	requestLog io.Writer // where PrintRequest output goes
 }
 
+// DefaultTimeout is the per-request timeout used when the config sets none.
+const DefaultTimeout = 30 * time.Second
+
 // New creates a new OJS API client.
 func New(cfg *config.Config) *Client {
-	return &Client{
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	c := &Client{
 		cfg: cfg,
 		http: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: timeout,
 		},
+		requestLog: os.Stderr,
+	}
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		c.err = err
+	} else {
+		c.http.Transport = transport
 	}
+	return c
+}
+
+// Err reports a configuration error (such as an unreadable certificate)
+// detected while constructing the client. Requests fail with the same error.
+func (c *Client) Err() error {
+	return c.err
+}
+
+// ErrRequestNotSent is returned for mutating requests when the config sets
+// PrintOnly: the request was printed but deliberately not sent.
+var ErrRequestNotSent = errors.New("request not sent (--print-only)")
+
+// gzipThreshold is the request body size above which bodies are sent
+// gzip-compressed when the config sets CompressRequests.
+const gzipThreshold = 64 << 10
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 // ErrorResponse represents an OJS error response.
@@ -33,19 +82,52 @@ type ErrorResponse struct {
 		Code      string `json:"code"`
 		Message   string `json:"message"`
 		Retryable bool   `json:"retryable,omitempty"`
+		RequestID string `json:"request_id,omitempty"`
 	} `json:"error"`
 }
 
 func (c *Client) do(method, path string, body any) ([]byte, int, error) {
+	if c.err != nil {
+		return nil, 0, c.err
+	}
 	url := c.cfg.BaseURL() + path
 
-	var bodyReader io.Reader
+	var payload []byte
 	if body != nil {
-		data, err := json.Marshal(body)
-		if err != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
 			return nil, 0, fmt.Errorf("marshal request: %w", err)
 		}
-		bodyReader = bytes.NewReader(data)
+	}
+
+	if method != http.MethodGet && (c.cfg.PrintRequest || c.cfg.PrintOnly) {
+		c.printRequest(method, url, payload)
+		if c.cfg.PrintOnly {
+			return nil, 0, ErrRequestNotSent
+		}
+	}
+
+	compress := c.cfg.CompressRequests && len(payload) >= gzipThreshold
+	data, status, err := c.send(method, url, payload, body != nil, compress)
+	if compress && status == http.StatusUnsupportedMediaType {
+		// The server doesn't accept compressed bodies; send it as is.
+		data, status, err = c.send(method, url, payload, true, false)
+	}
+	return data, status, err
+}
+
+// send performs one request with payload as the body, if hasBody, gzipping
+// it when compress is set.
+func (c *Client) send(method, url string, payload []byte, hasBody, compress bool) ([]byte, int, error) {
+	var bodyReader io.Reader
+	if hasBody {
+		if compress {
+			var err error
+			if payload, err = gzipBytes(payload); err != nil {
+				return nil, 0, fmt.Errorf("compress request: %w", err)
+			}
+		}
+		bodyReader = bytes.NewReader(payload)
 	}
 
 	req, err := http.NewRequest(method, url, bodyReader)
@@ -55,6 +137,10 @@ func (c *Client) do(method, path string, body any) ([]byte, int, error) {
 
 	req.Header.Set("Content-Type", "application/openjobspec+json")
 	req.Header.Set("Accept", "application/openjobspec+json")
+	req.Header.Set("Accept-Encoding", "gzip")
+	if compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
 	if c.cfg.AuthToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.cfg.AuthToken)
 	}
@@ -65,27 +151,121 @@ func (c *Client) do(method, path string, body any) ([]byte, int, error) {
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	var respBody io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, resp.StatusCode, fmt.Errorf("decompress response: %w", err)
+		}
+		defer gz.Close()
+		respBody = gz
+	}
+
+	data, err := io.ReadAll(respBody)
 	if err != nil {
 		return nil, resp.StatusCode, fmt.Errorf("read response: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if json.Unmarshal(data, &errResp) == nil && errResp.Error.Code != "" {
-			return data, resp.StatusCode, fmt.Errorf("%s: %s", errResp.Error.Code, errResp.Error.Message)
-		}
-		return data, resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(data))
+		return data, resp.StatusCode, newAPIError(resp, data)
 	}
 
 	return data, resp.StatusCode, nil
 }
 
+// printRequest writes a mutating request as "METHOD URL" followed by the
+// indented JSON body, if any.
+func (c *Client) printRequest(method, url string, body []byte) {
+	fmt.Fprintf(c.requestLog, "%s %s\n", method, url)
+	if len(body) == 0 {
+		return
+	}
+	var buf bytes.Buffer
+	if json.Indent(&buf, body, "", "  ") != nil {
+		buf.Reset()
+		buf.Write(body)
+	}
+	fmt.Fprintf(c.requestLog, "%s\n", buf.Bytes())
+}
+
+// Stream opens a long-lived GET request, such as a server-sent event stream,
+// without the per-request timeout. The caller must close the response body.
+// Non-2xx responses are returned as errors in the same form as Get.
+func (c *Client) Stream(path string) (*http.Response, error) {
+	return c.StreamContext(context.Background(), path)
+}
+
+// StreamContext is Stream bounded by ctx: cancelling it aborts the request
+// and any read of the response body.
+func (c *Client) StreamContext(ctx context.Context, path string) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.BaseURL()+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.cfg.AuthToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.AuthToken)
+	}
+
+	streamClient := &http.Client{Transport: c.http.Transport}
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return nil, newAPIError(resp, data)
+	}
+	return resp, nil
+}
+
 // BaseURL returns the raw server URL (without API prefix).
 func (c *Client) BaseURL() string {
 	return c.cfg.ServerURL
 }
 
+// Transport returns the round tripper used for requests, carrying the
+// configured TLS and proxy settings.
+func (c *Client) Transport() http.RoundTripper {
+	return c.http.Transport
+}
+
+// AuthToken returns the configured bearer token, if any.
+func (c *Client) AuthToken() string {
+	return c.cfg.AuthToken
+}
+
+// Priorities returns the symbolic priority names and their numeric values.
+func (c *Client) Priorities() map[string]int {
+	return c.cfg.Priorities()
+}
+
+// Do performs a request with any method. Get, Post and the other verb
+// methods are shorthands for it.
+func (c *Client) Do(method, path string, body any) ([]byte, int, error) {
+	return c.do(method, path, body)
+}
+
+// DoJSON performs a request and decodes the JSON response into out, which
+// may be nil to discard it. Server errors are returned as from Do.
+func (c *Client) DoJSON(method, path string, body, out any) error {
+	data, _, err := c.do(method, path, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
 // Get performs a GET request.
 func (c *Client) Get(path string) ([]byte, int, error) {
 	return c.do(http.MethodGet, path, nil)
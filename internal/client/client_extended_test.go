@@ -1,11 +1,15 @@
 package client
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/openjobspec/ojs-cli/internal/config"
 )
@@ -189,3 +193,299 @@ func TestClient_ErrorResponse_NoBody(t *testing.T) {
 	}
 }
 
+
+func TestClient_TimeoutFromConfig(t *testing.T) {
+	c := New(&config.Config{ServerURL: "http://localhost", Timeout: 5 * time.Minute})
+	if c.http.Timeout != 5*time.Minute {
+		t.Errorf("timeout = %v, want 5m", c.http.Timeout)
+	}
+
+	c = New(&config.Config{ServerURL: "http://localhost"})
+	if c.http.Timeout != DefaultTimeout {
+		t.Errorf("timeout = %v, want default %v", c.http.Timeout, DefaultTimeout)
+	}
+}
+
+func TestClient_GzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		json.NewEncoder(zw).Encode(map[string]string{"status": "ok"})
+		zw.Close()
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL})
+	data, _, err := c.Get("/health")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"status":"ok"`) {
+		t.Errorf("body = %q, want decompressed JSON", data)
+	}
+}
+
+func TestClient_UncompressedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL})
+	data, status, err := c.Get("/health")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != http.StatusOK || string(data) != `{"status":"ok"}` {
+		t.Errorf("status = %d body = %q", status, data)
+	}
+}
+
+func TestClient_GzipLargeRequestBody(t *testing.T) {
+	var small, large map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Errorf("gzip reader: %v", err)
+				return
+			}
+			body = gz
+		}
+		var decoded map[string]any
+		json.NewDecoder(body).Decode(&decoded)
+		if r.URL.Path == "/ojs/v1/small" {
+			if r.Header.Get("Content-Encoding") != "" {
+				t.Errorf("small body should not be compressed")
+			}
+			small = decoded
+		} else {
+			if r.Header.Get("Content-Encoding") != "gzip" {
+				t.Errorf("large body Content-Encoding = %q, want gzip", r.Header.Get("Content-Encoding"))
+			}
+			large = decoded
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL, CompressRequests: true})
+	if _, _, err := c.Post("/small", map[string]any{"type": "a"}); err != nil {
+		t.Fatalf("small post: %v", err)
+	}
+	payload := strings.Repeat("x", 2*gzipThreshold)
+	if _, _, err := c.Post("/large", map[string]any{"payload": payload}); err != nil {
+		t.Fatalf("large post: %v", err)
+	}
+	if small["type"] != "a" {
+		t.Errorf("small body = %v", small)
+	}
+	if large["payload"] != payload {
+		t.Error("large body did not round-trip through gzip")
+	}
+}
+
+func TestClient_GzipRequestOptional(t *testing.T) {
+	var encodings []string
+	rejectGzip := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		if rejectGzip && r.Header.Get("Content-Encoding") != "" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		var decoded map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&decoded); err != nil {
+			t.Errorf("body is not plain JSON: %v", err)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	payload := map[string]any{"payload": strings.Repeat("x", 2*gzipThreshold)}
+
+	// Off by default.
+	if _, _, err := New(&config.Config{ServerURL: server.URL}).Post("/large", payload); err != nil {
+		t.Fatalf("default post: %v", err)
+	}
+	if encodings[0] != "" {
+		t.Errorf("large body compressed without CompressRequests: %q", encodings[0])
+	}
+
+	// A 415 for a compressed body is retried once uncompressed.
+	encodings, rejectGzip = nil, true
+	if _, _, err := New(&config.Config{ServerURL: server.URL, CompressRequests: true}).Post("/large", payload); err != nil {
+		t.Fatalf("post after 415: %v", err)
+	}
+	if strings.Join(encodings, ",") != "gzip," {
+		t.Errorf("encodings = %q, want gzip then uncompressed", encodings)
+	}
+}
+
+func TestClient_PrintOnly(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL, PrintOnly: true})
+	var log strings.Builder
+	c.requestLog = &log
+
+	_, _, err := c.Post("/jobs", map[string]any{"type": "email.send"})
+	if !errors.Is(err, ErrRequestNotSent) {
+		t.Fatalf("err = %v, want ErrRequestNotSent", err)
+	}
+	if _, _, err := c.Delete("/jobs/job-1"); !errors.Is(err, ErrRequestNotSent) {
+		t.Fatalf("delete err = %v, want ErrRequestNotSent", err)
+	}
+	// Reads still go through so commands can look things up.
+	if _, _, err := c.Get("/health"); err != nil {
+		t.Fatalf("GET should be sent: %v", err)
+	}
+
+	if strings.Join(sent, ",") != "GET" {
+		t.Errorf("requests sent = %v, want only the GET", sent)
+	}
+	want := "POST " + server.URL + "/ojs/v1/jobs\n{\n  \"type\": \"email.send\"\n}\n" +
+		"DELETE " + server.URL + "/ojs/v1/jobs/job-1\n"
+	if log.String() != want {
+		t.Errorf("printed:\n%s\nwant:\n%s", log.String(), want)
+	}
+}
+
+func TestClient_PrintRequest(t *testing.T) {
+	sent := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent++
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL, PrintRequest: true})
+	var log strings.Builder
+	c.requestLog = &log
+
+	if _, _, err := c.Patch("/queues/q", map[string]int{"concurrency": 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("expected the request to be sent, got %d", sent)
+	}
+	if !strings.HasPrefix(log.String(), "PATCH "+server.URL+"/ojs/v1/queues/q\n") {
+		t.Errorf("unexpected printed request %q", log.String())
+	}
+}
+
+func TestClient_DoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/ojs/v1/jobs/job-1" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]int
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(map[string]any{"id": "job-1", "priority": body["priority"], "tags": []string{"a"}})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL})
+	var job struct {
+		ID       string   `json:"id"`
+		Priority int      `json:"priority"`
+		Tags     []string `json:"tags"`
+	}
+	if err := c.DoJSON(http.MethodPatch, "/jobs/job-1", map[string]int{"priority": 7}, &job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.ID != "job-1" || job.Priority != 7 || len(job.Tags) != 1 {
+		t.Errorf("unexpected decoded job: %+v", job)
+	}
+
+	// A nil out discards the body.
+	if err := c.DoJSON(http.MethodPatch, "/jobs/job-1", nil, nil); err != nil {
+		t.Errorf("unexpected error with nil out: %v", err)
+	}
+}
+
+func TestClient_DoJSONErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ojs/v1/jobs/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"code":"not_found","message":"job not found"}}`))
+		case "/ojs/v1/broken":
+			w.Write([]byte(`not json`))
+		}
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL})
+	var out map[string]any
+	err := c.DoJSON(http.MethodGet, "/jobs/missing", nil, &out)
+	if err == nil || err.Error() != "not_found: job not found" {
+		t.Errorf("expected server error, got %v", err)
+	}
+	if out != nil {
+		t.Errorf("out should be untouched on error, got %v", out)
+	}
+
+	err = c.DoJSON(http.MethodGet, "/broken", nil, &out)
+	if err == nil || !strings.Contains(err.Error(), "decode response") {
+		t.Errorf("expected decode error, got %v", err)
+	}
+}
+
+func TestClient_APIErrorFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{
+				"code":      "duplicate",
+				"message":   "job with unique key already exists",
+				"retryable": false,
+			},
+		})
+	}))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL})
+	_, status, err := c.Post("/jobs", map[string]string{"type": "t"})
+	if status != http.StatusConflict {
+		t.Errorf("status = %d, want 409", status)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.Code != "duplicate" ||
+		apiErr.Message != "job with unique key already exists" || apiErr.RequestID != "req-42" {
+		t.Errorf("unexpected APIError fields: %+v", apiErr)
+	}
+	if err.Error() != "duplicate: job with unique key already exists" {
+		t.Errorf("Error() = %q, want the legacy format", err.Error())
+	}
+	if !IsStatus(err, http.StatusConflict) || IsNotFound(err) {
+		t.Error("IsStatus/IsNotFound disagree with the status code")
+	}
+}
+
+func TestClient_APIErrorPlainBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"not_found","message":"no such job","request_id":"req-7"}}`))
+	}))
+	defer server.Close()
+
+	_, _, err := New(&config.Config{ServerURL: server.URL}).Get("/jobs/x")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.RequestID != "req-7" || !IsNotFound(err) {
+		t.Errorf("expected a not-found APIError with the body's request ID, got %#v", err)
+	}
+}
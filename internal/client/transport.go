@@ -0,0 +1,66 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/openjobspec/ojs-cli/internal/config"
+)
+
+// NewTransport builds the HTTP transport for cfg, applying any custom CA,
+// client certificate, and verification settings. Proxies come from
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY unless cfg.Proxy overrides them.
+func NewTransport(cfg *config.Config) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.Proxy)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsCfg, err := tlsConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	t.TLSClientConfig = tlsCfg
+	return t, nil
+}
+
+func tlsConfig(cfg *config.Config) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CACertFile != "" {
+		pem, err := os.ReadFile(cfg.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("CA certificate %s contains no valid PEM certificates", cfg.CACertFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.ClientCertFile != "" || cfg.ClientKeyFile != "" {
+		if cfg.ClientCertFile == "" || cfg.ClientKeyFile == "" {
+			return nil, fmt.Errorf("both a client certificate and a client key are required for mTLS")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
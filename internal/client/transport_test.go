@@ -0,0 +1,185 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openjobspec/ojs-cli/internal/config"
+)
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+// newClientCert generates a self-signed client certificate and returns the
+// paths of its PEM files along with the parsed certificate.
+func newClientCert(t *testing.T, dir string) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ojs-cli-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, _ = x509.ParseCertificate(der)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	certFile = writePEM(t, dir, "client.crt", "CERTIFICATE", der)
+	keyFile = writePEM(t, dir, "client.key", "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile, cert
+}
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.Write([]byte(`{"status":"ok"}`))
+}
+
+func TestClient_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(okHandler))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := writePEM(t, dir, "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+
+	c := New(&config.Config{ServerURL: server.URL, CACertFile: caFile})
+	if err := c.Err(); err != nil {
+		t.Fatalf("unexpected config error: %v", err)
+	}
+	if _, _, err := c.Get("/health"); err != nil {
+		t.Fatalf("request with custom CA failed: %v", err)
+	}
+
+	// Without the CA the server's certificate is untrusted.
+	c = New(&config.Config{ServerURL: server.URL})
+	if _, _, err := c.Get("/health"); err == nil {
+		t.Fatal("expected certificate verification error without custom CA")
+	}
+}
+
+func TestClient_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(okHandler))
+	defer server.Close()
+
+	c := New(&config.Config{ServerURL: server.URL, InsecureSkipVerify: true})
+	if _, _, err := c.Get("/health"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := newClientCert(t, dir)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(okHandler))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := writePEM(t, dir, "ca.crt", "CERTIFICATE", server.Certificate().Raw)
+
+	c := New(&config.Config{
+		ServerURL:      server.URL,
+		CACertFile:     caFile,
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+	})
+	if _, _, err := c.Get("/health"); err != nil {
+		t.Fatalf("mTLS request failed: %v", err)
+	}
+
+	c = New(&config.Config{ServerURL: server.URL, CACertFile: caFile})
+	if _, _, err := c.Get("/health"); err == nil {
+		t.Fatal("expected handshake failure without client certificate")
+	}
+}
+
+func TestClient_TLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, _, _ := newClientCert(t, dir)
+	otherDir := t.TempDir()
+	_, otherKey, _ := newClientCert(t, otherDir)
+	garbage := filepath.Join(dir, "garbage.pem")
+	os.WriteFile(garbage, []byte("not a certificate"), 0600)
+
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want string
+	}{
+		{"missing CA", config.Config{CACertFile: filepath.Join(dir, "nope.crt")}, "read CA certificate"},
+		{"invalid CA", config.Config{CACertFile: garbage}, "no valid PEM"},
+		{"cert without key", config.Config{ClientCertFile: certFile}, "both a client certificate and a client key"},
+		{"mismatched key", config.Config{ClientCertFile: certFile, ClientKeyFile: otherKey}, "load client certificate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.ServerURL = "https://localhost"
+			c := New(&tt.cfg)
+			if c.Err() == nil || !strings.Contains(c.Err().Error(), tt.want) {
+				t.Fatalf("Err() = %v, want containing %q", c.Err(), tt.want)
+			}
+			if _, _, err := c.Get("/health"); err != c.Err() {
+				t.Errorf("Get() error = %v, want config error", err)
+			}
+		})
+	}
+}
+
+func TestClient_ExplicitProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		proxied = append(proxied, r.Method+" "+r.URL.String())
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer proxy.Close()
+
+	c := New(&config.Config{ServerURL: "http://ojs.internal.example:8080", Proxy: proxy.URL})
+	if _, _, err := c.Get("/health"); err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "GET http://ojs.internal.example:8080/ojs/v1/health" {
+		t.Errorf("proxied requests = %v", proxied)
+	}
+}
+
+func TestClient_InvalidProxy(t *testing.T) {
+	c := New(&config.Config{ServerURL: "http://localhost", Proxy: "not a url"})
+	if c.Err() == nil || !strings.Contains(c.Err().Error(), "invalid proxy URL") {
+		t.Fatalf("Err() = %v, want invalid proxy error", c.Err())
+	}
+}
@@ -3,20 +3,56 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds CLI configuration.
 type Config struct {
 	ServerURL string
 	AuthToken string
-	Output    string // "table", "json"
+	Output    string        // "table", "json"
+	Timeout   time.Duration // per-request HTTP timeout; zero uses the client default
+	Proxy     string        // explicit proxy URL; empty defers to HTTP(S)_PROXY
+
+	// MaxColumnWidth caps table cell width; zero disables truncation.
+	MaxColumnWidth int
+
+	// PrintRequest prints mutating requests (method, URL and body) before
+	// sending them; PrintOnly prints them without sending.
+	PrintRequest bool
+	PrintOnly    bool
+
+	// CompressRequests gzips large request bodies. Off by default, since
+	// not every server decompresses request bodies.
+	CompressRequests bool
+
+	// PriorityNames adds to or overrides DefaultPriorityNames.
+	PriorityNames map[string]int
+
+	// TLS settings for servers behind private CAs or requiring mTLS.
+	CACertFile         string
+	ClientCertFile     string
+	ClientKeyFile      string
+	InsecureSkipVerify bool
+}
+
+// DefaultPriorityNames maps the symbolic priorities accepted by
+// "enqueue --priority-name" to numeric priorities.
+var DefaultPriorityNames = map[string]int{
+	"critical": 10,
+	"high":     7,
+	"normal":   5,
+	"low":      1,
 }
 
 // Load reads configuration from environment variables and flags.
 func Load() *Config {
 	cfg := &Config{
-		ServerURL: "http://localhost:8080",
-		Output:    "table",
+		ServerURL:      "http://localhost:8080",
+		Output:         "table",
+		MaxColumnWidth: 60,
 	}
 
 	if url := os.Getenv("OJS_URL"); url != "" {
@@ -28,10 +64,57 @@ func Load() *Config {
 	if output := os.Getenv("OJS_OUTPUT"); output != "" {
 		cfg.Output = output
 	}
+	cfg.CACertFile = os.Getenv("OJS_CA_CERT")
+	cfg.ClientCertFile = os.Getenv("OJS_CLIENT_CERT")
+	cfg.ClientKeyFile = os.Getenv("OJS_CLIENT_KEY")
+	if v, err := strconv.ParseBool(os.Getenv("OJS_INSECURE_SKIP_VERIFY")); err == nil {
+		cfg.InsecureSkipVerify = v
+	}
+	if v, err := strconv.ParseBool(os.Getenv("OJS_COMPRESS_REQUESTS")); err == nil {
+		cfg.CompressRequests = v
+	}
+	cfg.PriorityNames = parsePriorityNames(os.Getenv("OJS_PRIORITY_NAMES"))
+	if n, err := strconv.Atoi(os.Getenv("OJS_MAX_COLUMN_WIDTH")); err == nil && n >= 0 {
+		cfg.MaxColumnWidth = n
+	}
 
 	return cfg
 }
 
+// Priorities returns the symbolic priority names in effect: the defaults
+// merged with PriorityNames.
+func (c *Config) Priorities() map[string]int {
+	merged := make(map[string]int, len(DefaultPriorityNames)+len(c.PriorityNames))
+	for k, v := range DefaultPriorityNames {
+		merged[k] = v
+	}
+	for k, v := range c.PriorityNames {
+		merged[k] = v
+	}
+	return merged
+}
+
+// parsePriorityNames parses "name=value" pairs separated by commas, such as
+// "critical=100,high=50". Malformed pairs are ignored.
+func parsePriorityNames(s string) map[string]int {
+	if s == "" {
+		return nil
+	}
+	names := map[string]int{}
+	for _, pair := range strings.Split(s, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+		names[strings.ToLower(strings.TrimSpace(name))] = n
+	}
+	return names
+}
+
 // BaseURL returns the API base URL.
 func (c *Config) BaseURL() string {
 	return fmt.Sprintf("%s/ojs/v1", c.ServerURL)
@@ -52,3 +52,46 @@ func TestBaseURL(t *testing.T) {
 		t.Errorf("BaseURL() = %q, want %q", got, want)
 	}
 }
+
+func TestLoad_TLSFromEnv(t *testing.T) {
+	t.Setenv("OJS_CA_CERT", "/etc/ojs/ca.pem")
+	t.Setenv("OJS_CLIENT_CERT", "/etc/ojs/client.pem")
+	t.Setenv("OJS_CLIENT_KEY", "/etc/ojs/client-key.pem")
+	t.Setenv("OJS_INSECURE_SKIP_VERIFY", "true")
+
+	cfg := Load()
+	if cfg.CACertFile != "/etc/ojs/ca.pem" {
+		t.Errorf("CACertFile = %q, want /etc/ojs/ca.pem", cfg.CACertFile)
+	}
+	if cfg.ClientCertFile != "/etc/ojs/client.pem" || cfg.ClientKeyFile != "/etc/ojs/client-key.pem" {
+		t.Errorf("client cert/key = %q/%q", cfg.ClientCertFile, cfg.ClientKeyFile)
+	}
+	if !cfg.InsecureSkipVerify {
+		t.Error("InsecureSkipVerify = false, want true")
+	}
+}
+
+func TestLoad_CompressRequests(t *testing.T) {
+	if Load().CompressRequests {
+		t.Error("CompressRequests should be off by default")
+	}
+	t.Setenv("OJS_COMPRESS_REQUESTS", "true")
+	if !Load().CompressRequests {
+		t.Error("CompressRequests = false, want true from OJS_COMPRESS_REQUESTS")
+	}
+}
+
+func TestLoad_PriorityNames(t *testing.T) {
+	t.Setenv("OJS_PRIORITY_NAMES", "critical=100, Bulk=0,bad,high=x")
+
+	got := Load().Priorities()
+	want := map[string]int{"critical": 100, "high": 7, "normal": 5, "low": 1, "bulk": 0}
+	if len(got) != len(want) {
+		t.Fatalf("Priorities() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Priorities()[%q] = %d, want %d", k, got[k], v)
+		}
+	}
+}
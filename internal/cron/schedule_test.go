@@ -0,0 +1,99 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, expr string) *Schedule {
+	t.Helper()
+	s, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("Parse(%q) error: %v", expr, err)
+	}
+	return s
+}
+
+func TestNext_KnownExpressions(t *testing.T) {
+	// Thursday, 2026-01-15 10:30 UTC.
+	from := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "2026-01-15T10:31:00Z"},
+		{"0 9 * * *", "2026-01-16T09:00:00Z"},
+		{"*/15 * * * *", "2026-01-15T10:45:00Z"},
+		{"30 10 * * *", "2026-01-16T10:30:00Z"},
+		{"0 0 1 * *", "2026-02-01T00:00:00Z"},
+		{"0 8 * * MON", "2026-01-19T08:00:00Z"},
+		{"0 8 * * 1-5", "2026-01-16T08:00:00Z"},
+		{"0 0 1 JAN *", "2027-01-01T00:00:00Z"},
+		{"@hourly", "2026-01-15T11:00:00Z"},
+		{"0 12 * * 7", "2026-01-18T12:00:00Z"},
+		// Both day fields restricted: matches the 20th OR any Sunday.
+		{"0 0 20 * 0", "2026-01-18T00:00:00Z"},
+		{"0 0 29 2 *", "2028-02-29T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		got := mustParse(t, tt.expr).Next(from)
+		if got.Format(time.RFC3339) != tt.want {
+			t.Errorf("Next(%q) = %s, want %s", tt.expr, got.Format(time.RFC3339), tt.want)
+		}
+	}
+}
+
+func TestNextN(t *testing.T) {
+	from := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	runs := mustParse(t, "0 9,17 * * *").NextN(from, 4)
+	want := []string{
+		"2026-01-15T17:00:00Z",
+		"2026-01-16T09:00:00Z",
+		"2026-01-16T17:00:00Z",
+		"2026-01-17T09:00:00Z",
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("got %d runs, want %d", len(runs), len(want))
+	}
+	for i, r := range runs {
+		if r.Format(time.RFC3339) != want[i] {
+			t.Errorf("run %d = %s, want %s", i, r.Format(time.RFC3339), want[i])
+		}
+	}
+}
+
+func TestNextN_NonPositive(t *testing.T) {
+	s := mustParse(t, "* * * * *")
+	for _, n := range []int{0, -1} {
+		if runs := s.NextN(time.Now(), n); runs != nil {
+			t.Errorf("NextN(%d) = %v, want nil", n, runs)
+		}
+	}
+}
+
+func TestNext_Timezone(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	from := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC).In(loc) // 07:00 EST
+	got := mustParse(t, "0 9 * * *").Next(from)
+	if got.UTC().Format(time.RFC3339) != "2026-01-15T14:00:00Z" {
+		t.Errorf("Next = %s, want 09:00 EST (14:00 UTC)", got.UTC().Format(time.RFC3339))
+	}
+}
+
+func TestNext_NeverFires(t *testing.T) {
+	if got := mustParse(t, "0 0 30 2 *").Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next = %v, want zero time", got)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "* * * * FOO"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) expected error", expr)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -56,23 +57,110 @@ type CategoryScore struct {
 	Total    int `json:"total"`
 }
 
+// DefaultConcurrency is the number of checks an Auditor runs at once.
+const DefaultConcurrency = 8
+
 // Auditor runs production readiness checks.
 type Auditor struct {
-	serverURL string
-	apiKey    string
-	client    *http.Client
+	serverURL   string
+	apiKey      string
+	client      *http.Client
+	concurrency int
+	categories  map[string]bool // nil runs every category
+}
+
+// Categories lists the audit check categories.
+var Categories = []string{"security", "reliability", "observability", "operations", "compliance"}
+
+// auditCheck is a check function with the category it reports under, so
+// checks can be filtered before they run.
+type auditCheck struct {
+	category string
+	run      func(context.Context) Check
 }
 
 // NewAuditor creates a new auditor for the given server.
 func NewAuditor(serverURL, apiKey string) *Auditor {
 	return &Auditor{
-		serverURL: strings.TrimRight(serverURL, "/"),
-		apiKey:    apiKey,
-		client:    &http.Client{Timeout: 10 * time.Second},
+		serverURL:   strings.TrimRight(serverURL, "/"),
+		apiKey:      apiKey,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		concurrency: DefaultConcurrency,
+	}
+}
+
+// WithTransport makes the auditor send requests through rt, e.g. to reuse
+// the CLI's TLS and proxy configuration. A nil rt keeps the default.
+func (a *Auditor) WithTransport(rt http.RoundTripper) *Auditor {
+	if rt != nil {
+		a.client.Transport = rt
+	}
+	return a
+}
+
+// WithConcurrency sets how many checks run in parallel. Values below 1 run
+// the checks one at a time.
+func (a *Auditor) WithConcurrency(n int) *Auditor {
+	if n < 1 {
+		n = 1
+	}
+	a.concurrency = n
+	return a
+}
+
+// WithCategories restricts the audit to checks in the given categories, so
+// the score only covers the checks that ran. An empty list runs them all.
+func (a *Auditor) WithCategories(categories []string) *Auditor {
+	a.categories = nil
+	if len(categories) > 0 {
+		a.categories = make(map[string]bool, len(categories))
+		for _, c := range categories {
+			a.categories[c] = true
+		}
+	}
+	return a
+}
+
+// SelectCategories resolves --only and --skip style lists into the
+// categories to run. A nil result means every category.
+func SelectCategories(only, skip []string) ([]string, error) {
+	for _, c := range append(append([]string{}, only...), skip...) {
+		if !containsString(Categories, c) {
+			return nil, fmt.Errorf("unknown check category %q (valid: %s)", c, strings.Join(Categories, ", "))
+		}
+	}
+	if len(only) == 0 && len(skip) == 0 {
+		return nil, nil
 	}
+
+	base := Categories
+	if len(only) > 0 {
+		base = only
+	}
+	var selected []string
+	for _, c := range Categories {
+		if containsString(base, c) && !containsString(skip, c) {
+			selected = append(selected, c)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no check categories left to run")
+	}
+	return selected, nil
 }
 
-// Run executes all checks and returns a report.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes all checks and returns a report. Checks run concurrently on a
+// bounded pool, but the report lists them in a fixed order. Cancelling ctx
+// aborts in-flight requests; checks that have not started yet fail fast.
 func (a *Auditor) Run(ctx context.Context) *Report {
 	report := &Report{
 		ServerURL:  a.serverURL,
@@ -80,33 +168,39 @@ func (a *Auditor) Run(ctx context.Context) *Report {
 		Categories: make(map[string]CategoryScore),
 	}
 
-	checks := []func(context.Context) Check{
-		a.checkHealth,
-		a.checkManifest,
-		a.checkAuth,
-		a.checkTLS,
-		a.checkVersion,
-		a.checkQueueExists,
-		a.checkDeadLetterEmpty,
-		a.checkWorkerHeartbeat,
-		a.checkResponseHeaders,
-		a.checkRequestID,
-		a.checkContentType,
-		a.checkMetricsEndpoint,
-		a.checkErrorFormat,
-		a.checkCronJobs,
-		a.checkRateLimit,
-		a.checkTimeout,
-		a.checkGracefulShutdown,
-		a.checkBackpressure,
-		a.checkJobRetention,
-		a.checkSpecCompliance,
-	}
-
-	for _, fn := range checks {
-		check := fn(ctx)
-		report.Checks = append(report.Checks, check)
+	all := []auditCheck{
+		{"security", a.checkHealth},
+		{"compliance", a.checkManifest},
+		{"security", a.checkAuth},
+		{"security", a.checkTLS},
+		{"compliance", a.checkVersion},
+		{"operations", a.checkQueueExists},
+		{"operations", a.checkDeadLetterEmpty},
+		{"operations", a.checkWorkerHeartbeat},
+		{"security", a.checkResponseHeaders},
+		{"security", a.checkRequestID},
+		{"compliance", a.checkContentType},
+		{"observability", a.checkMetricsEndpoint},
+		{"compliance", a.checkErrorFormat},
+		{"operations", a.checkCronJobs},
+		{"security", a.checkRateLimit},
+		{"reliability", a.checkTimeout},
+		{"reliability", a.checkGracefulShutdown},
+		{"reliability", a.checkBackpressure},
+		{"operations", a.checkJobRetention},
+		{"compliance", a.checkSpecCompliance},
+	}
 
+	var checks []func(context.Context) Check
+	for _, ac := range all {
+		if a.categories == nil || a.categories[ac.category] {
+			checks = append(checks, ac.run)
+		}
+	}
+
+	report.Checks = a.runChecks(ctx, checks)
+
+	for _, check := range report.Checks {
 		cat := report.Categories[check.Category]
 		cat.Total++
 		switch check.Severity {
@@ -133,7 +227,10 @@ func (a *Auditor) Run(ctx context.Context) *Report {
 		}
 	}
 
-	pct := report.Score * 100 / report.MaxScore
+	pct := 100
+	if report.MaxScore > 0 {
+		pct = report.Score * 100 / report.MaxScore
+	}
 	switch {
 	case pct >= 90:
 		report.Grade = "A"
@@ -150,6 +247,37 @@ func (a *Auditor) Run(ctx context.Context) *Report {
 	return report
 }
 
+// runChecks runs checks on a pool of a.concurrency workers and returns the
+// results in the same order as checks.
+func (a *Auditor) runChecks(ctx context.Context, checks []func(context.Context) Check) []Check {
+	results := make([]Check, len(checks))
+	work := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := a.concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(checks) {
+		workers = len(checks)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				results[i] = checks[i](ctx)
+			}
+		}()
+	}
+	for i := range checks {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	return results
+}
+
 func (a *Auditor) get(ctx context.Context, path string) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", a.serverURL+path, nil)
 	if err != nil {
@@ -384,14 +512,66 @@ func (a *Auditor) checkErrorFormat(_ context.Context) Check {
 		Description: "Errors follow OJS error schema", Message: "Validated via conformance tests"}
 }
 
-func (a *Auditor) checkCronJobs(_ context.Context) Check {
-	return Check{ID: "OPS-004", Category: "operations", Name: "Cron Jobs", Severity: SevInfo,
-		Description: "Cron job scheduling is configured", Message: "Check via 'ojs cron' command"}
+func (a *Auditor) checkCronJobs(ctx context.Context) Check {
+	c := Check{ID: "OPS-004", Category: "operations", Name: "Cron Jobs", Description: "Cron job scheduling is configured"}
+	resp, err := a.get(ctx, "/ojs/v1/cron")
+	if err != nil {
+		c.Severity = SevSkip
+		c.Message = "Could not check cron jobs"
+		return c
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		c.Severity = SevWarning
+		c.Message = fmt.Sprintf("Cron endpoint returned %d", resp.StatusCode)
+		c.Fix = "Enable the cron extension on the server"
+		return c
+	}
+	var result struct {
+		CronJobs []json.RawMessage `json:"cron_jobs"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(body, &result)
+	if len(result.CronJobs) > 0 {
+		c.Severity = SevPass
+		c.Message = fmt.Sprintf("%d cron schedule(s) registered", len(result.CronJobs))
+	} else {
+		c.Severity = SevWarning
+		c.Message = "No cron schedules registered"
+		c.Fix = "Register recurring jobs with 'ojs cron --register' or 'ojs cron apply'"
+	}
+	return c
 }
 
-func (a *Auditor) checkRateLimit(_ context.Context) Check {
-	return Check{ID: "SEC-006", Category: "security", Name: "Rate Limiting", Severity: SevInfo,
-		Description: "Rate limiting protects against abuse", Message: "Configure via OJS_RATE_LIMIT or rate-limit middleware"}
+func (a *Auditor) checkRateLimit(ctx context.Context) Check {
+	c := Check{ID: "SEC-006", Category: "security", Name: "Rate Limiting", Description: "Rate limiting protects against abuse"}
+	resp, err := a.get(ctx, "/ojs/v1/rate-limits")
+	if err != nil {
+		c.Severity = SevSkip
+		c.Message = "Could not check rate limits"
+		return c
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		c.Severity = SevWarning
+		c.Message = fmt.Sprintf("Rate limit endpoint returned %d", resp.StatusCode)
+		c.Fix = "Configure via OJS_RATE_LIMIT or rate-limit middleware"
+		return c
+	}
+	var result struct {
+		RateLimits []json.RawMessage `json:"rate_limits"`
+	}
+	body, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(body, &result)
+	if len(result.RateLimits) > 0 {
+		c.Severity = SevPass
+		c.Message = fmt.Sprintf("%d rate limiter(s) configured", len(result.RateLimits))
+	} else {
+		c.Severity = SevWarning
+		c.Message = "No rate limiters configured"
+		c.Fix = "Configure via OJS_RATE_LIMIT or 'ojs rate-limits --override <key> --concurrency <n>'"
+	}
+	return c
 }
 
 func (a *Auditor) checkTimeout(_ context.Context) Check {
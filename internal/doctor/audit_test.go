@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func healthyServer() *httptest.Server {
@@ -138,6 +141,52 @@ func TestAuditCategories(t *testing.T) {
 	}
 }
 
+func TestAuditWithCategories(t *testing.T) {
+	srv := healthyServer()
+	defer srv.Close()
+
+	report := NewAuditor(srv.URL, "").WithCategories([]string{"security"}).Run(context.Background())
+
+	if len(report.Checks) != 6 {
+		t.Errorf("expected 6 security checks, got %d", len(report.Checks))
+	}
+	for _, c := range report.Checks {
+		if c.Category != "security" {
+			t.Errorf("check %s (%s) ran outside --only security", c.ID, c.Category)
+		}
+	}
+	if len(report.Categories) != 1 {
+		t.Errorf("categories = %v, want only security", report.Categories)
+	}
+	if report.MaxScore != len(report.Checks)*5 {
+		t.Errorf("max score = %d, want %d", report.MaxScore, len(report.Checks)*5)
+	}
+}
+
+func TestSelectCategories(t *testing.T) {
+	for _, tt := range []struct {
+		only, skip []string
+		want       []string
+		wantErr    bool
+	}{
+		{nil, nil, nil, false},
+		{[]string{"compliance", "security"}, nil, []string{"security", "compliance"}, false},
+		{nil, []string{"security", "compliance"}, []string{"reliability", "observability", "operations"}, false},
+		{[]string{"security", "operations"}, []string{"operations"}, []string{"security"}, false},
+		{[]string{"security"}, []string{"security"}, nil, true},
+		{[]string{"performance"}, nil, nil, true},
+	} {
+		got, err := SelectCategories(tt.only, tt.skip)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SelectCategories(%v, %v) error = %v, wantErr %v", tt.only, tt.skip, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SelectCategories(%v, %v) = %v, want %v", tt.only, tt.skip, got, tt.want)
+		}
+	}
+}
+
 func TestGrading(t *testing.T) {
 	srv := healthyServer()
 	defer srv.Close()
@@ -150,3 +199,225 @@ func TestGrading(t *testing.T) {
 		t.Error("score should not exceed max score")
 	}
 }
+
+func TestAuditRunsChecksConcurrently(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(delay)
+		w.WriteHeader(200)
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	report := NewAuditor(srv.URL, "").WithConcurrency(20).Run(context.Background())
+	elapsed := time.Since(start)
+
+	n := time.Duration(requests.Load())
+	if n < 5 {
+		t.Fatalf("expected several HTTP checks, got %d requests", n)
+	}
+	if elapsed > 2*delay {
+		t.Errorf("audit took %v; want close to the slowest check (%v), not the sum (%v)", elapsed, delay, n*delay)
+	}
+
+	// Order must match the sequential check list regardless of completion order.
+	want := []string{"SEC-001", "SPEC-001", "SEC-002", "SEC-003"}
+	for i, id := range want {
+		if report.Checks[i].ID != id {
+			t.Errorf("check %d = %s, want %s", i, report.Checks[i].ID, id)
+		}
+	}
+}
+
+func TestAuditBoundedConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+	}))
+	defer srv.Close()
+
+	NewAuditor(srv.URL, "").WithConcurrency(2).Run(context.Background())
+	if p := peak.Load(); p > 2 {
+		t.Errorf("expected at most 2 concurrent requests, saw %d", p)
+	}
+}
+
+func TestAuditCancellation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	report := NewAuditor(srv.URL, "").Run(ctx)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("cancelled audit took %v", elapsed)
+	}
+	if len(report.Checks) != 20 {
+		t.Errorf("expected all 20 checks to be reported, got %d", len(report.Checks))
+	}
+}
+
+func findCheck(t *testing.T, report *Report, id string) Check {
+	t.Helper()
+	for _, c := range report.Checks {
+		if c.ID == id {
+			return c
+		}
+	}
+	t.Fatalf("%s check not found", id)
+	return Check{}
+}
+
+func TestAuditRateLimitAndCron(t *testing.T) {
+	tests := []struct {
+		name      string
+		rateLimit string
+		cron      string
+		want      Severity
+	}{
+		{"configured", `{"rate_limits":[{"key":"email","concurrency":5}]}`, `{"cron_jobs":[{"name":"daily"}]}`, SevPass},
+		{"empty", `{"rate_limits":[]}`, `{"cron_jobs":[]}`, SevWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/ojs/v1/rate-limits":
+					w.Write([]byte(tt.rateLimit))
+				case "/ojs/v1/cron":
+					w.Write([]byte(tt.cron))
+				default:
+					w.WriteHeader(404)
+				}
+			}))
+			defer srv.Close()
+
+			report := NewAuditor(srv.URL, "").Run(context.Background())
+			for _, id := range []string{"SEC-006", "OPS-004"} {
+				c := findCheck(t, report, id)
+				if c.Severity != tt.want {
+					t.Errorf("%s: expected %s, got %s (%s)", id, tt.want, c.Severity, c.Message)
+				}
+				if tt.want == SevWarning && c.Fix == "" {
+					t.Errorf("%s: expected a fix suggestion", id)
+				}
+			}
+		})
+	}
+}
+
+func TestAuditRateLimitEndpointMissing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+	}))
+	defer srv.Close()
+
+	report := NewAuditor(srv.URL, "").Run(context.Background())
+	if c := findCheck(t, report, "SEC-006"); c.Severity != SevWarning {
+		t.Errorf("expected warning when rate-limit endpoint is missing, got %s", c.Severity)
+	}
+}
+
+func TestReportSARIF(t *testing.T) {
+	srv := healthyServer()
+	defer srv.Close()
+
+	report := NewAuditor(srv.URL, "").Run(context.Background())
+	data, err := json.Marshal(report.SARIF())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var log struct {
+		Version string `json:"version"`
+		Schema  string `json:"$schema"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID   string `json:"id"`
+						Help *struct {
+							Text string `json:"text"`
+						} `json:"help"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Kind      string `json:"kind"`
+				Message   struct {
+					Text string `json:"text"`
+				} `json:"message"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatal(err)
+	}
+	if log.Version != "2.1.0" || log.Schema == "" {
+		t.Errorf("unexpected version/schema: %q %q", log.Version, log.Schema)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name == "" {
+		t.Error("expected tool driver name")
+	}
+
+	seen := map[string]bool{}
+	for _, r := range run.Tool.Driver.Rules {
+		if seen[r.ID] {
+			t.Errorf("duplicate rule id %s", r.ID)
+		}
+		seen[r.ID] = true
+	}
+	if len(run.Results) != len(report.Checks) {
+		t.Errorf("expected %d results, got %d", len(report.Checks), len(run.Results))
+	}
+
+	for i, res := range run.Results {
+		check := report.Checks[i]
+		if res.RuleID != check.ID || run.Tool.Driver.Rules[res.RuleIndex].ID != check.ID {
+			t.Errorf("result %d: rule %s (index %d) does not match check %s", i, res.RuleID, res.RuleIndex, check.ID)
+		}
+		want := map[Severity]string{SevCritical: "error", SevWarning: "warning"}[check.Severity]
+		if want == "" {
+			want = "none"
+		}
+		if res.Level != want {
+			t.Errorf("%s: level %s, want %s", check.ID, res.Level, want)
+		}
+		if res.Message.Text == "" {
+			t.Errorf("%s: empty message", check.ID)
+		}
+	}
+
+	// SEC-003 warns on plain HTTP and carries its fix as rule help.
+	for _, r := range run.Tool.Driver.Rules {
+		if r.ID == "SEC-003" && (r.Help == nil || r.Help.Text == "") {
+			t.Error("expected SEC-003 fix as rule help text")
+		}
+	}
+}
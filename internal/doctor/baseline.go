@@ -0,0 +1,123 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// CheckChange describes a check whose severity differs between two reports.
+type CheckChange struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Before     Severity `json:"before,omitempty"` // empty when the check is new
+	After      Severity `json:"after,omitempty"`  // empty when the check was removed
+	Regression bool     `json:"regression"`
+}
+
+// Comparison is the result of comparing a report against a baseline.
+type Comparison struct {
+	BaselineScore int           `json:"baseline_score"`
+	Score         int           `json:"score"`
+	BaselineGrade string        `json:"baseline_grade"`
+	Grade         string        `json:"grade"`
+	Changes       []CheckChange `json:"changes"`
+}
+
+// ScoreDropped reports whether the current score is below the baseline.
+func (c *Comparison) ScoreDropped() bool {
+	return c.Score < c.BaselineScore
+}
+
+// Regressions returns the changed checks that count as regressions.
+func (c *Comparison) Regressions() []CheckChange {
+	var out []CheckChange
+	for _, ch := range c.Changes {
+		if ch.Regression {
+			out = append(out, ch)
+		}
+	}
+	return out
+}
+
+// Failed reports whether the current report is worse than the baseline.
+func (c *Comparison) Failed() bool {
+	return c.ScoreDropped() || len(c.Regressions()) > 0
+}
+
+// Compare diffs current against baseline check by check, in the order the
+// checks appear in current. A check regresses when it moves to warning or
+// critical from a better state.
+func Compare(baseline, current *Report) *Comparison {
+	cmp := &Comparison{
+		BaselineScore: baseline.Score,
+		Score:         current.Score,
+		BaselineGrade: baseline.Grade,
+		Grade:         current.Grade,
+		Changes:       []CheckChange{},
+	}
+
+	before := make(map[string]Severity, len(baseline.Checks))
+	for _, c := range baseline.Checks {
+		before[c.ID] = c.Severity
+	}
+
+	seen := make(map[string]bool, len(current.Checks))
+	for _, c := range current.Checks {
+		seen[c.ID] = true
+		prev, ok := before[c.ID]
+		if ok && prev == c.Severity {
+			continue
+		}
+		cmp.Changes = append(cmp.Changes, CheckChange{
+			ID:         c.ID,
+			Name:       c.Name,
+			Before:     prev,
+			After:      c.Severity,
+			Regression: ok && severityRank(c.Severity) > severityRank(prev),
+		})
+	}
+	for _, c := range baseline.Checks {
+		if !seen[c.ID] {
+			cmp.Changes = append(cmp.Changes, CheckChange{ID: c.ID, Name: c.Name, Before: c.Severity})
+		}
+	}
+	return cmp
+}
+
+// severityRank orders severities from best to worst. Informational and
+// skipped checks rank with passes so that only warnings and criticals regress.
+func severityRank(s Severity) int {
+	switch s {
+	case SevWarning:
+		return 1
+	case SevCritical:
+		return 2
+	}
+	return 0
+}
+
+// LoadReport reads a report previously saved as JSON.
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// Save writes r as indented JSON so it can be used as a baseline.
+func (r *Report) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	return nil
+}
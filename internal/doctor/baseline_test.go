@@ -0,0 +1,78 @@
+package doctor
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func testReport(score int, sevs ...Severity) *Report {
+	r := &Report{Score: score, MaxScore: 5 * len(sevs), Grade: "A"}
+	ids := []string{"SEC-001", "SEC-002", "OPS-001"}
+	for i, s := range sevs {
+		r.Checks = append(r.Checks, Check{ID: ids[i], Name: ids[i], Severity: s})
+	}
+	return r
+}
+
+func TestCompareScoreDrop(t *testing.T) {
+	base := testReport(15, SevPass, SevPass, SevPass)
+	cur := testReport(12, SevPass, SevPass, SevPass)
+
+	cmp := Compare(base, cur)
+	if !cmp.ScoreDropped() {
+		t.Error("expected score drop")
+	}
+	if !cmp.Failed() {
+		t.Error("score drop should fail the comparison")
+	}
+	if len(cmp.Changes) != 0 {
+		t.Errorf("expected no check changes, got %+v", cmp.Changes)
+	}
+}
+
+func TestCompareCheckRegression(t *testing.T) {
+	base := testReport(11, SevPass, SevWarning, SevCritical)
+	cur := testReport(11, SevCritical, SevPass, SevCritical)
+
+	cmp := Compare(base, cur)
+	if cmp.ScoreDropped() {
+		t.Error("score did not drop")
+	}
+	regs := cmp.Regressions()
+	if len(regs) != 1 || regs[0].ID != "SEC-001" {
+		t.Fatalf("expected SEC-001 to regress, got %+v", regs)
+	}
+	if regs[0].Before != SevPass || regs[0].After != SevCritical {
+		t.Errorf("unexpected transition %s -> %s", regs[0].Before, regs[0].After)
+	}
+	if len(cmp.Changes) != 2 {
+		t.Errorf("expected 2 changes (one regression, one improvement), got %d", len(cmp.Changes))
+	}
+	if !cmp.Failed() {
+		t.Error("regression should fail the comparison")
+	}
+}
+
+func TestCompareImprovementPasses(t *testing.T) {
+	base := testReport(13, SevPass, SevWarning, SevPass)
+	cur := testReport(15, SevPass, SevPass, SevPass)
+
+	if cmp := Compare(base, cur); cmp.Failed() {
+		t.Errorf("improvement should not fail: %+v", cmp)
+	}
+}
+
+func TestReportSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	want := testReport(13, SevPass, SevWarning, SevPass)
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Score != want.Score || len(got.Checks) != 3 || got.Checks[1].Severity != SevWarning {
+		t.Errorf("round trip mismatch: %+v", got)
+	}
+}
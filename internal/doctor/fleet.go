@@ -0,0 +1,87 @@
+package doctor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Grades lists the report grades from best to worst.
+var Grades = []string{"A", "B", "C", "D", "F"}
+
+// ServerScore is one row of a fleet scorecard.
+type ServerScore struct {
+	ServerURL string `json:"server_url"`
+	Grade     string `json:"grade"`
+	Score     int    `json:"score"`
+	MaxScore  int    `json:"max_score"`
+	Critical  int    `json:"critical"`
+	Warnings  int    `json:"warnings"`
+}
+
+// Scorecard summarizes the reports of a fleet run, one row per server in
+// the order given.
+func Scorecard(reports []*Report) []ServerScore {
+	rows := make([]ServerScore, 0, len(reports))
+	for _, r := range reports {
+		row := ServerScore{ServerURL: r.ServerURL, Grade: r.Grade, Score: r.Score, MaxScore: r.MaxScore}
+		for _, cat := range r.Categories {
+			row.Critical += cat.Critical
+			row.Warnings += cat.Warnings
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// GradeBelow reports whether grade is worse than min. Unknown grades count
+// as worse than any known grade.
+func GradeBelow(grade, min string) bool {
+	g, m := gradeRank(grade), gradeRank(min)
+	if g < 0 {
+		return true
+	}
+	return g > m
+}
+
+// ValidGrade reports whether g is one of Grades.
+func ValidGrade(g string) bool {
+	return gradeRank(g) >= 0
+}
+
+func gradeRank(g string) int {
+	for i, v := range Grades {
+		if v == g {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReadServerList reads server URLs from a file, one per line. Blank lines
+// and lines starting with # are ignored.
+func ReadServerList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open server list: %w", err)
+	}
+	defer f.Close()
+
+	var urls []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read server list: %w", err)
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("server list %s is empty", path)
+	}
+	return urls, nil
+}
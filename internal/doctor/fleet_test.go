@@ -0,0 +1,78 @@
+package doctor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScorecardTwoServers(t *testing.T) {
+	good := healthyServer()
+	defer good.Close()
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+	}))
+	defer bad.Close()
+
+	var reports []*Report
+	for _, url := range []string{good.URL, bad.URL} {
+		reports = append(reports, NewAuditor(url, "").Run(context.Background()))
+	}
+
+	rows := Scorecard(reports)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0].ServerURL != good.URL || rows[1].ServerURL != bad.URL {
+		t.Errorf("rows out of order: %+v", rows)
+	}
+	if rows[1].Critical <= rows[0].Critical {
+		t.Errorf("failing server should have more criticals: %+v", rows)
+	}
+	if GradeBelow(rows[0].Grade, rows[1].Grade) {
+		t.Errorf("failing server graded %s above healthy %s", rows[1].Grade, rows[0].Grade)
+	}
+	if rows[0].MaxScore != reports[0].MaxScore || rows[0].Score != reports[0].Score {
+		t.Errorf("row does not match report: %+v", rows[0])
+	}
+}
+
+func TestGradeBelow(t *testing.T) {
+	for _, tt := range []struct {
+		grade, min string
+		want       bool
+	}{
+		{"A", "B", false},
+		{"B", "B", false},
+		{"C", "B", true},
+		{"F", "D", true},
+		{"", "F", true},
+	} {
+		if got := GradeBelow(tt.grade, tt.min); got != tt.want {
+			t.Errorf("GradeBelow(%q, %q) = %v, want %v", tt.grade, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestReadServerList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "servers.txt")
+	os.WriteFile(path, []byte("# fleet\nhttps://a.example.com\n\n  https://b.example.com  \n"), 0o644)
+
+	urls, err := ReadServerList(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"https://a.example.com", "https://b.example.com"}
+	if !reflect.DeepEqual(urls, want) {
+		t.Errorf("urls = %v, want %v", urls, want)
+	}
+
+	os.WriteFile(path, []byte("# nothing\n"), 0o644)
+	if _, err := ReadServerList(path); err == nil {
+		t.Error("expected error for empty list")
+	}
+}
@@ -0,0 +1,132 @@
+package doctor
+
+// SARIF 2.1.0 output, for security dashboards and code scanning tools.
+// Only the subset of the schema needed to describe audit results is modeled.
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFLog is the top-level SARIF document.
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SARIFRun `json:"runs"`
+}
+
+// SARIFRun describes one invocation of the audit.
+type SARIFRun struct {
+	Tool    SARIFTool     `json:"tool"`
+	Results []SARIFResult `json:"results"`
+}
+
+// SARIFTool identifies the tool and the rules it evaluates.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the tool component that produced the results.
+type SARIFDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SARIFRule `json:"rules"`
+}
+
+// SARIFRule describes one check. Its ID is the check ID (e.g. SEC-002).
+type SARIFRule struct {
+	ID               string        `json:"id"`
+	Name             string        `json:"name"`
+	ShortDescription SARIFMessage  `json:"shortDescription"`
+	FullDescription  SARIFMessage  `json:"fullDescription"`
+	Help             *SARIFMessage `json:"help,omitempty"`
+	Properties       SARIFRuleProp `json:"properties"`
+}
+
+// SARIFRuleProp holds rule metadata outside the core schema.
+type SARIFRuleProp struct {
+	Category string `json:"category"`
+}
+
+// SARIFResult is the outcome of one check.
+type SARIFResult struct {
+	RuleID    string       `json:"ruleId"`
+	RuleIndex int          `json:"ruleIndex"`
+	Kind      string       `json:"kind"`
+	Level     string       `json:"level"`
+	Message   SARIFMessage `json:"message"`
+}
+
+// SARIFMessage is a plain-text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIF converts the report to a SARIF 2.1.0 log. Each check becomes a rule
+// and a result; critical checks are reported at level "error" and warnings
+// at level "warning". A check's Fix is carried as the rule's help text.
+func (r *Report) SARIF() *SARIFLog {
+	driver := SARIFDriver{
+		Name:           "ojs doctor",
+		InformationURI: "https://openjobspec.org",
+		Rules:          []SARIFRule{},
+	}
+	results := make([]SARIFResult, 0, len(r.Checks))
+	ruleIndex := make(map[string]int, len(r.Checks))
+
+	for _, c := range r.Checks {
+		idx, ok := ruleIndex[c.ID]
+		if !ok {
+			rule := SARIFRule{
+				ID:               c.ID,
+				Name:             c.Name,
+				ShortDescription: SARIFMessage{Text: c.Name},
+				FullDescription:  SARIFMessage{Text: c.Description},
+				Properties:       SARIFRuleProp{Category: c.Category},
+			}
+			if c.Fix != "" {
+				rule.Help = &SARIFMessage{Text: c.Fix}
+			}
+			idx = len(driver.Rules)
+			ruleIndex[c.ID] = idx
+			driver.Rules = append(driver.Rules, rule)
+		} else if c.Fix != "" && driver.Rules[idx].Help == nil {
+			driver.Rules[idx].Help = &SARIFMessage{Text: c.Fix}
+		}
+
+		kind, level := sarifKindLevel(c.Severity)
+		msg := c.Message
+		if msg == "" {
+			msg = c.Description
+		}
+		results = append(results, SARIFResult{
+			RuleID:    c.ID,
+			RuleIndex: idx,
+			Kind:      kind,
+			Level:     level,
+			Message:   SARIFMessage{Text: msg},
+		})
+	}
+
+	return &SARIFLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []SARIFRun{{Tool: SARIFTool{Driver: driver}, Results: results}},
+	}
+}
+
+// sarifKindLevel maps a severity to SARIF's result kind and level. SARIF
+// only allows a level other than "none" on results of kind "fail".
+func sarifKindLevel(s Severity) (kind, level string) {
+	switch s {
+	case SevCritical:
+		return "fail", "error"
+	case SevWarning:
+		return "fail", "warning"
+	case SevPass:
+		return "pass", "none"
+	case SevSkip:
+		return "notApplicable", "none"
+	}
+	return "informational", "none"
+}
@@ -0,0 +1,84 @@
+// Package jobstate lists the OJS job lifecycle states and the transitions
+// between them, so commands can validate state arguments in one place.
+package jobstate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Job lifecycle states.
+const (
+	Scheduled = "scheduled"
+	Available = "available"
+	Pending   = "pending"
+	Active    = "active"
+	Completed = "completed"
+	Retryable = "retryable"
+	Cancelled = "cancelled"
+	Discarded = "discarded"
+)
+
+// All lists every job state in lifecycle order.
+var All = []string{Scheduled, Available, Pending, Active, Completed, Retryable, Cancelled, Discarded}
+
+// transitions maps each state to the states a job may move to next.
+// Discarded jobs can be revived by a manual retry; completed and cancelled
+// jobs are final.
+var transitions = map[string][]string{
+	Scheduled: {Available, Cancelled},
+	Available: {Active, Cancelled},
+	Pending:   {Available, Cancelled},
+	Active:    {Completed, Retryable, Discarded, Cancelled},
+	Retryable: {Scheduled, Available, Discarded, Cancelled},
+	Discarded: {Available},
+	Completed: nil,
+	Cancelled: nil,
+}
+
+// UnknownError reports a state that is not part of the job lifecycle.
+type UnknownError struct {
+	State string
+}
+
+func (e *UnknownError) Error() string {
+	return fmt.Sprintf("unknown job state %q (valid states: %s)", e.State, strings.Join(All, ", "))
+}
+
+// Valid reports whether state is a known job state.
+func Valid(state string) bool {
+	_, ok := transitions[state]
+	return ok
+}
+
+// Validate returns an *UnknownError for the first unknown state.
+func Validate(states ...string) error {
+	for _, s := range states {
+		if !Valid(s) {
+			return &UnknownError{State: s}
+		}
+	}
+	return nil
+}
+
+// Terminal reports whether state ends normal processing. Discarded jobs are
+// terminal even though an operator can still retry them.
+func Terminal(state string) bool {
+	return state == Completed || state == Cancelled || state == Discarded
+}
+
+// Next returns the states a job in state may move to.
+func Next(state string) []string {
+	return append([]string(nil), transitions[state]...)
+}
+
+// CanTransition reports whether a job may move directly from one state to
+// another.
+func CanTransition(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
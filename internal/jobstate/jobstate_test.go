@@ -0,0 +1,61 @@
+package jobstate
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	if err := Validate(Completed, Discarded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := Validate(Completed, "complete")
+	var unknown *UnknownError
+	if !errors.As(err, &unknown) || unknown.State != "complete" {
+		t.Fatalf("err = %v, want UnknownError for \"complete\"", err)
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	for state := range transitions {
+		for _, next := range transitions[state] {
+			if !Valid(next) {
+				t.Errorf("%s -> %s targets an unknown state", state, next)
+			}
+		}
+	}
+	for _, s := range All {
+		if _, ok := transitions[s]; !ok {
+			t.Errorf("state %s has no transition entry", s)
+		}
+	}
+
+	cases := []struct {
+		from, to string
+		want     bool
+	}{
+		{Active, Completed, true},
+		{Active, Retryable, true},
+		{Discarded, Available, true},
+		{Completed, Available, false},
+		{Available, Completed, false},
+	}
+	for _, tc := range cases {
+		if got := CanTransition(tc.from, tc.to); got != tc.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestTerminal(t *testing.T) {
+	for _, s := range []string{Completed, Cancelled, Discarded} {
+		if !Terminal(s) {
+			t.Errorf("Terminal(%s) = false, want true", s)
+		}
+	}
+	for _, s := range []string{Active, Retryable, "bogus"} {
+		if Terminal(s) {
+			t.Errorf("Terminal(%s) = true, want false", s)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package migrate
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -184,6 +185,60 @@ func (b *BullMQSource) Export() ([]ExportedJob, error) {
 	return exported, nil
 }
 
+// ExportFailed exports the jobs in each queue's failed set, tagged with
+// source_state "failed".
+func (b *BullMQSource) ExportFailed() ([]ExportedJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	queueNames, err := b.discoverQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var exported []ExportedJob
+	for _, q := range queueNames {
+		jobIDs, err := b.rdb.ZRange(ctx, "bull:"+q+":failed", 0, -1).Result()
+		if err != nil {
+			return nil, fmt.Errorf("read BullMQ failed set for %s: %w", q, err)
+		}
+		for _, id := range jobIDs {
+			fields, err := b.rdb.HGetAll(ctx, "bull:"+q+":"+id).Result()
+			if err != nil {
+				continue
+			}
+			if ej, err := parseBullMQFailedJob(q, fields); err == nil {
+				exported = append(exported, *ej)
+			}
+		}
+	}
+	return exported, nil
+}
+
+// ParseBullMQFailedJob converts the hash fields of a job in a BullMQ failed
+// set into an ExportedJob. Exported for testing.
+func ParseBullMQFailedJob(queue string, fields map[string]string) (*ExportedJob, error) {
+	return parseBullMQFailedJob(queue, fields)
+}
+
+func parseBullMQFailedJob(queue string, fields map[string]string) (*ExportedJob, error) {
+	ej, err := parseBullMQJob(queue, fields)
+	if err != nil {
+		return nil, err
+	}
+	ej.Meta["source_state"] = "failed"
+	if reason := fields["failedReason"]; reason != "" {
+		ej.Meta["bullmq_failed_reason"] = reason
+	}
+	if n, err := strconv.Atoi(fields["attemptsMade"]); err == nil && n > 0 {
+		ej.Meta["bullmq_attempts_made"] = n
+	}
+	if ms, err := strconv.ParseInt(fields["finishedOn"], 10, 64); err == nil && ms > 0 {
+		ej.Meta["bullmq_failed_at"] = time.UnixMilli(ms).UTC().Format(time.RFC3339)
+	}
+	return ej, nil
+}
+
 // ParseBullMQJob converts BullMQ hash fields into an ExportedJob.
 // Exported for testing.
 func ParseBullMQJob(queue string, raw string) (*ExportedJob, error) {
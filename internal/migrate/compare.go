@@ -0,0 +1,155 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Queue change states reported by CompareAnalyses.
+const (
+	QueueAdded     = "added"
+	QueueRemoved   = "removed"
+	QueueChanged   = "changed"
+	QueueUnchanged = "unchanged"
+)
+
+// AnalysisDiff describes how a source changed between two analyses.
+type AnalysisDiff struct {
+	Source        string      `json:"source"`
+	PreviousTotal int         `json:"previous_total"`
+	CurrentTotal  int         `json:"current_total"`
+	TotalDelta    int         `json:"total_delta"`
+	Queues        []QueueDiff `json:"queues"`
+}
+
+// QueueDiff is the change in one queue. JobTypes lists only the job types
+// whose counts differ.
+type QueueDiff struct {
+	Name            string        `json:"name"`
+	Status          string        `json:"status"`
+	PreviousPending int           `json:"previous_pending"`
+	CurrentPending  int           `json:"current_pending"`
+	PendingDelta    int           `json:"pending_delta"`
+	JobTypes        []JobTypeDiff `json:"job_types,omitempty"`
+}
+
+// JobTypeDiff is the change in the pending count of one job type.
+type JobTypeDiff struct {
+	Type     string `json:"type"`
+	Previous int    `json:"previous"`
+	Current  int    `json:"current"`
+	Delta    int    `json:"delta"`
+}
+
+// Changed reports whether anything differs between the two analyses.
+func (d *AnalysisDiff) Changed() bool {
+	for _, q := range d.Queues {
+		if q.Status != QueueUnchanged {
+			return true
+		}
+	}
+	return d.TotalDelta != 0
+}
+
+// LoadAnalysis reads an AnalysisResult saved as JSON.
+func LoadAnalysis(path string) (*AnalysisResult, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read analysis: %w", err)
+	}
+	var result AnalysisResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("parse analysis %s: %w", path, err)
+	}
+	return &result, nil
+}
+
+// SaveAnalysis writes result to path as indented JSON.
+func SaveAnalysis(path string, result *AnalysisResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write analysis: %w", err)
+	}
+	return nil
+}
+
+// CompareAnalyses compares a previous analysis with a current one. Queues
+// are listed in name order.
+func CompareAnalyses(prev, cur *AnalysisResult) *AnalysisDiff {
+	diff := &AnalysisDiff{
+		Source:        cur.Source,
+		PreviousTotal: prev.TotalJobs,
+		CurrentTotal:  cur.TotalJobs,
+		TotalDelta:    cur.TotalJobs - prev.TotalJobs,
+	}
+
+	before := make(map[string]QueueAnalysis, len(prev.Queues))
+	for _, q := range prev.Queues {
+		before[q.Name] = q
+	}
+	after := make(map[string]QueueAnalysis, len(cur.Queues))
+	for _, q := range cur.Queues {
+		after[q.Name] = q
+	}
+
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p, inPrev := before[name]
+		c, inCur := after[name]
+		qd := QueueDiff{
+			Name:            name,
+			PreviousPending: p.PendingJobs,
+			CurrentPending:  c.PendingJobs,
+			PendingDelta:    c.PendingJobs - p.PendingJobs,
+			JobTypes:        compareJobTypes(p.JobTypes, c.JobTypes),
+		}
+		switch {
+		case !inPrev:
+			qd.Status = QueueAdded
+		case !inCur:
+			qd.Status = QueueRemoved
+		case qd.PendingDelta != 0 || len(qd.JobTypes) > 0:
+			qd.Status = QueueChanged
+		default:
+			qd.Status = QueueUnchanged
+		}
+		diff.Queues = append(diff.Queues, qd)
+	}
+	return diff
+}
+
+func compareJobTypes(prev, cur map[string]int) []JobTypeDiff {
+	types := make([]string, 0, len(prev)+len(cur))
+	for t := range prev {
+		types = append(types, t)
+	}
+	for t := range cur {
+		if _, ok := prev[t]; !ok {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+
+	var out []JobTypeDiff
+	for _, t := range types {
+		if prev[t] != cur[t] {
+			out = append(out, JobTypeDiff{Type: t, Previous: prev[t], Current: cur[t], Delta: cur[t] - prev[t]})
+		}
+	}
+	return out
+}
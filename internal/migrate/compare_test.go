@@ -0,0 +1,65 @@
+package migrate
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCompareAnalyses(t *testing.T) {
+	prev := &AnalysisResult{
+		Source:    "sidekiq",
+		TotalJobs: 15,
+		Queues: []QueueAnalysis{
+			{Name: "default", PendingJobs: 10, JobTypes: map[string]int{"HardWorker": 6, "Mailer": 4}},
+			{Name: "legacy", PendingJobs: 3, JobTypes: map[string]int{"OldJob": 3}},
+			{Name: "steady", PendingJobs: 2, JobTypes: map[string]int{"Ping": 2}},
+		},
+	}
+	cur := &AnalysisResult{
+		Source:    "sidekiq",
+		TotalJobs: 20,
+		Queues: []QueueAnalysis{
+			{Name: "default", PendingJobs: 12, JobTypes: map[string]int{"HardWorker": 6, "Mailer": 3, "Report": 3}},
+			{Name: "critical", PendingJobs: 6, JobTypes: map[string]int{"Alert": 6}},
+			{Name: "steady", PendingJobs: 2, JobTypes: map[string]int{"Ping": 2}},
+		},
+	}
+
+	diff := CompareAnalyses(prev, cur)
+	if diff.TotalDelta != 5 || !diff.Changed() {
+		t.Errorf("TotalDelta = %d, Changed = %v", diff.TotalDelta, diff.Changed())
+	}
+	want := []QueueDiff{
+		{Name: "critical", Status: QueueAdded, CurrentPending: 6, PendingDelta: 6,
+			JobTypes: []JobTypeDiff{{Type: "Alert", Current: 6, Delta: 6}}},
+		{Name: "default", Status: QueueChanged, PreviousPending: 10, CurrentPending: 12, PendingDelta: 2,
+			JobTypes: []JobTypeDiff{{Type: "Mailer", Previous: 4, Current: 3, Delta: -1}, {Type: "Report", Current: 3, Delta: 3}}},
+		{Name: "legacy", Status: QueueRemoved, PreviousPending: 3, PendingDelta: -3,
+			JobTypes: []JobTypeDiff{{Type: "OldJob", Previous: 3, Delta: -3}}},
+		{Name: "steady", Status: QueueUnchanged, PreviousPending: 2, CurrentPending: 2},
+	}
+	if !reflect.DeepEqual(diff.Queues, want) {
+		t.Errorf("queues =\n%+v\nwant\n%+v", diff.Queues, want)
+	}
+
+	if CompareAnalyses(cur, cur).Changed() {
+		t.Error("an analysis compared with itself should report no changes")
+	}
+}
+
+func TestSaveLoadAnalysis(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analysis.json")
+	in := &AnalysisResult{Source: "bullmq", Connection: "redis://x", TotalJobs: 1,
+		Queues: []QueueAnalysis{{Name: "q", PendingJobs: 1, JobTypes: map[string]int{"t": 1}}}, Summary: "ok"}
+	if err := SaveAnalysis(path, in); err != nil {
+		t.Fatal(err)
+	}
+	out, err := LoadAnalysis(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip = %+v, want %+v", out, in)
+	}
+}
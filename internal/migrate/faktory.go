@@ -1,9 +1,11 @@
 package migrate
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -14,15 +16,30 @@ type FaktorySource struct {
 	client   *http.Client
 }
 
-// NewFaktorySource creates a source that reads jobs from a Faktory server.
-func NewFaktorySource(baseURL, password string) (*FaktorySource, error) {
+// NewFaktorySource creates a source that reads jobs from a Faktory server's
+// web UI at baseURL, which must be an http:// or https:// URL. A non-empty
+// password is sent with every request. With useTLS the server is reached
+// over HTTPS, upgrading an http:// base URL if needed.
+func NewFaktorySource(baseURL, password string, useTLS bool) (*FaktorySource, error) {
 	if baseURL == "" {
 		baseURL = "http://localhost:7420"
 	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return nil, fmt.Errorf("faktory requires an http:// or https:// URL, got %q", baseURL)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	if useTLS {
+		if rest, ok := strings.CutPrefix(baseURL, "http://"); ok {
+			baseURL = "https://" + rest
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		client.Transport = transport
+	}
 	return &FaktorySource{
 		baseURL:  baseURL,
 		password: password,
-		client:   &http.Client{Timeout: 30 * time.Second},
+		client:   client,
 	}, nil
 }
 
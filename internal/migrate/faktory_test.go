@@ -0,0 +1,75 @@
+package migrate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// faktoryServer serves /api/info and records the password of each request.
+func faktoryServer(t *testing.T, password *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, *password, _ = r.BasicAuth()
+		json.NewEncoder(w).Encode(map[string]any{
+			"faktory": map[string]any{"queues": map[string]int{"default": 3}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFaktorySource_Password(t *testing.T) {
+	for _, password := range []string{"", "s3cret"} {
+		var got string
+		srv := faktoryServer(t, &got)
+
+		src, err := NewFaktorySource(srv.URL, password, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := src.Analyze()
+		if err != nil {
+			t.Fatalf("analyze: %v", err)
+		}
+		if result.TotalJobs != 3 {
+			t.Errorf("TotalJobs = %d, want 3", result.TotalJobs)
+		}
+		if got != password {
+			t.Errorf("server saw password %q, want %q", got, password)
+		}
+	}
+}
+
+func TestFaktorySource_TLS(t *testing.T) {
+	tests := []struct {
+		url, want string
+	}{
+		{"http://faktory:7420", "https://faktory:7420"},
+		{"https://faktory:7420", "https://faktory:7420"},
+	}
+	for _, tt := range tests {
+		src, err := NewFaktorySource(tt.url, "pw", true)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.url, err)
+		}
+		if src.baseURL != tt.want {
+			t.Errorf("baseURL = %q, want %q", src.baseURL, tt.want)
+		}
+		transport, ok := src.client.Transport.(*http.Transport)
+		if !ok || transport.TLSClientConfig == nil || transport.Proxy == nil {
+			t.Errorf("%s: expected a TLS transport cloned from the default", tt.url)
+		}
+	}
+
+	for _, useTLS := range []bool{false, true} {
+		if _, err := NewFaktorySource("redis://localhost:6379", "", useTLS); err == nil {
+			t.Errorf("tls=%v: expected error for a non-HTTP URL", useTLS)
+		}
+	}
+	src, _ := NewFaktorySource("", "", false)
+	if src.baseURL != "http://localhost:7420" || src.client.Transport != nil {
+		t.Errorf("default source = %q, transport %v", src.baseURL, src.client.Transport)
+	}
+}
@@ -23,31 +23,64 @@ type Poster interface {
 
 const importBatchSize = 100
 
-// ImportFile reads an NDJSON file and imports jobs via the OJS API in batches.
-func ImportFile(c Poster, filename string, progress func(imported, total int)) (*ImportResult, error) {
+// ImportFile reads an NDJSON file and imports jobs via the OJS API in batches,
+// renaming queues and types per rewrites.
+func ImportFile(c Poster, filename string, rewrites Rewrites, progress func(imported, total int)) (*ImportResult, error) {
 	f, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("open file: %w", err)
 	}
 	defer f.Close()
 
-	return importFromReader(c, f, progress)
+	decode := func(line []byte) (ExportedJob, error) {
+		var job ExportedJob
+		if err := json.Unmarshal(line, &job); err != nil {
+			return job, err
+		}
+		rewrites.Apply(&job)
+		return job, nil
+	}
+	return importFromReader(c, f, decode, progress)
+}
+
+// CountJobs returns the number of non-empty lines in an NDJSON file, the
+// total ImportFile will process.
+func CountJobs(filename string) (int, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			n++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return n, fmt.Errorf("read file: %w", err)
+	}
+	return n, nil
 }
 
-func importFromReader(c Poster, r io.Reader, progress func(imported, total int)) (*ImportResult, error) {
+// importFromReader decodes one job per non-empty line and posts them in
+// batches. Lines that fail to decode count as failed.
+func importFromReader(c Poster, r io.Reader, decode func(line []byte) (ExportedJob, error), progress func(imported, total int)) (*ImportResult, error) {
 	scanner := bufio.NewScanner(r)
 
 	result := &ImportResult{}
 	var batch []ExportedJob
 
 	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
+		line := scanner.Bytes()
+		if len(line) == 0 {
 			continue
 		}
 
-		var job ExportedJob
-		if err := json.Unmarshal([]byte(line), &job); err != nil {
+		job, err := decode(line)
+		if err != nil {
 			result.Failed++
 			result.Total++
 			continue
@@ -107,6 +140,9 @@ func sendBatch(c Poster, batch []ExportedJob) (success, failed int) {
 		if job.Meta != nil {
 			body["meta"] = job.Meta
 		}
+		if job.UniqueKey != "" {
+			body["options"].(map[string]any)["unique"] = map[string]any{"key": job.UniqueKey}
+		}
 
 		_, _, err := c.Post("/jobs", body)
 		if err != nil {
@@ -0,0 +1,74 @@
+package livemigrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// checkpoint is the progress persisted to Config.CheckpointFile so an
+// interrupted import can resume after the last job that reached the target.
+type checkpoint struct {
+	SourceURL      string `json:"source_url"`
+	TargetURL      string `json:"target_url"`
+	LastImportedID string `json:"last_imported_id"`
+	Imported       int64  `json:"imported"`
+}
+
+// loadCheckpoint reads a checkpoint, returning nil when the file does not
+// exist.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+	return &cp, nil
+}
+
+// save writes the checkpoint through a temporary file so a crash never
+// leaves a truncated one behind.
+func (cp *checkpoint) save(path string) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// resumeIndex returns the position in jobs just after the checkpoint's last
+// imported job.
+func (cp *checkpoint) resumeIndex(jobs []json.RawMessage) (int, error) {
+	for i, raw := range jobs {
+		var job struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(raw, &job) == nil && job.ID == cp.LastImportedID {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("checkpoint job %s is no longer in the source export", cp.LastImportedID)
+}
@@ -5,9 +5,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"os"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -33,21 +37,47 @@ type Config struct {
 	TargetKey string `json:"target_api_key,omitempty"`
 	BatchSize int    `json:"batch_size"`
 	DryRun    bool   `json:"dry_run"`
+	// VerifySample is how many imported jobs are fetched back from the
+	// target to confirm they landed intact. Defaults to 100.
+	VerifySample int `json:"verify_sample"`
+	// CheckpointFile, when set, records import progress every BatchSize
+	// jobs and when the import stops. A later Run with the same file skips
+	// the jobs imported before; the file is removed once a Run completes.
+	// Progress is recorded by job id, so jobs without an id imported after
+	// the last one that has an id are sent again on resume.
+	CheckpointFile string `json:"checkpoint_file,omitempty"`
 }
 
-// Stats tracks migration progress.
+// Stats tracks migration progress. The counters are atomic; mu guards the
+// phase and timestamps so progress can be read while a migration runs.
 type Stats struct {
-	Phase        Phase     `json:"phase"`
-	Exported     atomic.Int64
-	Imported     atomic.Int64
-	Verified     atomic.Int64
-	Errors       atomic.Int64
-	StartedAt    time.Time  `json:"started_at"`
-	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	mu          sync.RWMutex
+	Phase       Phase `json:"phase"`
+	Exported    atomic.Int64
+	Imported    atomic.Int64
+	Verified    atomic.Int64
+	Errors      atomic.Int64
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+func (s *Stats) setPhase(p Phase) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Phase = p
+	switch p {
+	case PhaseExporting:
+		s.StartedAt = time.Now()
+	case PhaseComplete:
+		now := time.Now()
+		s.CompletedAt = &now
+	}
 }
 
 // Snapshot returns a serializable copy.
 func (s *Stats) Snapshot() StatsSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return StatsSnapshot{
 		Phase:       s.Phase,
 		Exported:    s.Exported.Load(),
@@ -72,10 +102,22 @@ type StatsSnapshot struct {
 
 // Migration manages the backend-to-backend migration lifecycle.
 type Migration struct {
-	mu     sync.Mutex
 	config Config
 	stats  Stats
 	client *http.Client
+
+	// mu guards running and imported. It is only held briefly, never
+	// across requests, so a long import does not block other callers.
+	mu       sync.Mutex
+	running  bool
+	imported []importedJob
+}
+
+// importedJob records a job created on the target so it can be verified.
+type importedJob struct {
+	ID   string
+	Type any
+	Args any
 }
 
 // New creates a new live migration.
@@ -83,6 +125,9 @@ func New(cfg Config) *Migration {
 	if cfg.BatchSize <= 0 {
 		cfg.BatchSize = 100
 	}
+	if cfg.VerifySample <= 0 {
+		cfg.VerifySample = 100
+	}
 	return &Migration{
 		config: cfg,
 		client: &http.Client{Timeout: 30 * time.Second},
@@ -95,37 +140,51 @@ func (m *Migration) GetStats() StatsSnapshot {
 	return m.stats.Snapshot()
 }
 
-// Run executes the full migration: export → import → verify.
+// Run executes the full migration: export → import → verify. GetStats may
+// be called from another goroutine to follow its progress. With
+// Config.CheckpointFile set, a Run after a failed import resumes after the
+// last job that was imported.
 func (m *Migration) Run(ctx context.Context) error {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.stats.StartedAt = time.Now()
+	if m.running {
+		m.mu.Unlock()
+		return errors.New("migration already running")
+	}
+	m.running = true
+	m.imported = nil
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.running = false
+		m.mu.Unlock()
+	}()
 
 	// Phase 1: Export
-	m.stats.Phase = PhaseExporting
+	m.stats.setPhase(PhaseExporting)
 	jobs, err := m.exportJobs(ctx)
 	if err != nil {
-		m.stats.Phase = PhaseFailed
+		m.stats.setPhase(PhaseFailed)
 		return fmt.Errorf("export failed: %w", err)
 	}
 
 	// Phase 2: Import
-	m.stats.Phase = PhaseImporting
+	m.stats.setPhase(PhaseImporting)
 	if err := m.importJobs(ctx, jobs); err != nil {
-		m.stats.Phase = PhaseFailed
+		m.stats.setPhase(PhaseFailed)
 		return fmt.Errorf("import failed: %w", err)
 	}
 
 	// Phase 3: Verify
-	m.stats.Phase = PhaseVerifying
+	m.stats.setPhase(PhaseVerifying)
 	if err := m.verifyTarget(ctx); err != nil {
-		m.stats.Phase = PhaseFailed
+		m.stats.setPhase(PhaseFailed)
 		return fmt.Errorf("verify failed: %w", err)
 	}
 
-	m.stats.Phase = PhaseComplete
-	now := time.Now()
-	m.stats.CompletedAt = &now
+	if m.config.CheckpointFile != "" && !m.config.DryRun {
+		os.Remove(m.config.CheckpointFile)
+	}
+	m.stats.setPhase(PhaseComplete)
 	return nil
 }
 
@@ -146,11 +205,47 @@ func (m *Migration) exportJobs(ctx context.Context) ([]json.RawMessage, error) {
 	return result.Jobs, nil
 }
 
-func (m *Migration) importJobs(ctx context.Context, jobs []json.RawMessage) error {
-	for _, raw := range jobs {
-		if m.config.DryRun {
-			m.stats.Imported.Add(1)
-			continue
+// importJobs creates the exported jobs on the target. A job the target
+// rejects (a 4xx other than 408 or 429) is counted as an error and skipped.
+// If the target is unreachable, throttles or fails with a 5xx, the job is
+// retried with backoff. When it still fails, with a checkpoint file the
+// import stops before that job and saves the progress so far so the next Run
+// retries it; without one, a rerun would import everything again, so the
+// job is counted as an error and the import carries on.
+func (m *Migration) importJobs(ctx context.Context, jobs []json.RawMessage) (err error) {
+	if m.config.DryRun {
+		m.stats.Imported.Add(int64(len(jobs)))
+		return nil
+	}
+
+	var cp *checkpoint
+	if path := m.config.CheckpointFile; path != "" {
+		if cp, err = m.resume(path); err != nil {
+			return err
+		}
+		if cp.LastImportedID != "" {
+			start, err := cp.resumeIndex(jobs)
+			if err != nil {
+				return fmt.Errorf("%w; delete %s to start over", err, path)
+			}
+			jobs = jobs[start:]
+			m.stats.Imported.Store(cp.Imported)
+		}
+		defer func() {
+			if saveErr := cp.save(path); saveErr != nil && err == nil {
+				err = saveErr
+			}
+		}()
+	}
+
+	for i, raw := range jobs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if cp != nil && i > 0 && i%m.config.BatchSize == 0 {
+			if err := cp.save(m.config.CheckpointFile); err != nil {
+				return err
+			}
 		}
 
 		var job map[string]interface{}
@@ -169,24 +264,151 @@ func (m *Migration) importJobs(ctx context.Context, jobs []json.RawMessage) erro
 		}
 
 		body, _ := json.Marshal(req)
-		if _, err := m.request(ctx, "POST", m.config.TargetURL+"/ojs/v1/jobs", m.config.TargetKey, body); err != nil {
+		data, err := m.postJob(ctx, body)
+		var status *statusError
+		if errors.As(err, &status) && status.rejected() {
+			m.stats.Errors.Add(1)
+			continue
+		}
+		if err != nil {
+			if cp != nil || ctx.Err() != nil {
+				return fmt.Errorf("job %v: %w", job["id"], err)
+			}
 			m.stats.Errors.Add(1)
 			continue
 		}
 		m.stats.Imported.Add(1)
+		if id, _ := job["id"].(string); cp != nil && id != "" {
+			cp.LastImportedID = id
+			cp.Imported = m.stats.Imported.Load()
+		}
+		if id := createdJobID(data); id != "" {
+			m.mu.Lock()
+			m.imported = append(m.imported, importedJob{ID: id, Type: job["type"], Args: job["args"]})
+			m.mu.Unlock()
+		}
 	}
 	return nil
 }
 
+// importAttempts is how many times a job is sent before a transient failure
+// is given up on. importRetryDelay is the wait before the first retry; it
+// doubles for each one after.
+var (
+	importAttempts   = 4
+	importRetryDelay = 500 * time.Millisecond
+)
+
+// postJob creates one job on the target, retrying network errors, timeouts,
+// throttling and server errors with backoff.
+func (m *Migration) postJob(ctx context.Context, body []byte) ([]byte, error) {
+	delay := importRetryDelay
+	for attempt := 1; ; attempt++ {
+		data, err := m.request(ctx, "POST", m.config.TargetURL+"/ojs/v1/jobs", m.config.TargetKey, body)
+		var status *statusError
+		if err == nil || (errors.As(err, &status) && status.rejected()) || attempt == importAttempts {
+			return data, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// resume loads the checkpoint at path, or starts a new one, and checks it
+// belongs to this source and target.
+func (m *Migration) resume(path string) (*checkpoint, error) {
+	cp, err := loadCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+	if cp == nil {
+		return &checkpoint{SourceURL: m.config.SourceURL, TargetURL: m.config.TargetURL}, nil
+	}
+	if cp.SourceURL != m.config.SourceURL || cp.TargetURL != m.config.TargetURL {
+		return nil, fmt.Errorf("checkpoint %s is for a migration from %s to %s", path, cp.SourceURL, cp.TargetURL)
+	}
+	return cp, nil
+}
+
 func (m *Migration) verifyTarget(ctx context.Context) error {
 	_, err := m.request(ctx, "GET", m.config.TargetURL+"/ojs/v1/health", m.config.TargetKey, nil)
 	if err != nil {
 		return fmt.Errorf("target unhealthy: %w", err)
 	}
-	m.stats.Verified.Store(m.stats.Imported.Load())
+	if m.config.DryRun {
+		return nil
+	}
+
+	m.mu.Lock()
+	sample := sampleJobs(m.imported, m.config.VerifySample)
+	m.mu.Unlock()
+	for _, job := range sample {
+		if m.verifyJob(ctx, job) {
+			m.stats.Verified.Add(1)
+		} else {
+			m.stats.Errors.Add(1)
+		}
+	}
 	return nil
 }
 
+// verifyJob fetches an imported job from the target and reports whether it
+// exists with the type and args it was exported with.
+func (m *Migration) verifyJob(ctx context.Context, job importedJob) bool {
+	data, err := m.request(ctx, "GET", m.config.TargetURL+"/ojs/v1/jobs/"+job.ID, m.config.TargetKey, nil)
+	if err != nil {
+		return false
+	}
+	var got struct {
+		Type any `json:"type"`
+		Args any `json:"args"`
+		Job  *struct {
+			Type any `json:"type"`
+			Args any `json:"args"`
+		} `json:"job"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		return false
+	}
+	if got.Job != nil {
+		got.Type, got.Args = got.Job.Type, got.Job.Args
+	}
+	return reflect.DeepEqual(got.Type, job.Type) && reflect.DeepEqual(got.Args, job.Args)
+}
+
+// sampleJobs returns up to n jobs chosen at random, or all of them when
+// there are no more than n.
+func sampleJobs(jobs []importedJob, n int) []importedJob {
+	if len(jobs) <= n {
+		return jobs
+	}
+	sample := make([]importedJob, 0, n)
+	for _, i := range rand.Perm(len(jobs))[:n] {
+		sample = append(sample, jobs[i])
+	}
+	return sample
+}
+
+// createdJobID extracts the new job's ID from a create response, which is
+// either the job itself or wrapped as {"job": {...}}.
+func createdJobID(data []byte) string {
+	var resp struct {
+		ID  string `json:"id"`
+		Job struct {
+			ID string `json:"id"`
+		} `json:"job"`
+	}
+	json.Unmarshal(data, &resp)
+	if resp.ID != "" {
+		return resp.ID
+	}
+	return resp.Job.ID
+}
+
 func (m *Migration) request(ctx context.Context, method, url, apiKey string, body []byte) (json.RawMessage, error) {
 	var reader io.Reader
 	if body != nil {
@@ -210,7 +432,25 @@ func (m *Migration) request(ctx context.Context, method, url, apiKey string, bod
 
 	data, _ := io.ReadAll(resp.Body)
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(data))
+		return nil, &statusError{Code: resp.StatusCode, Body: string(data)}
 	}
 	return data, nil
 }
+
+// statusError is a response the server sent with an error status, as
+// opposed to a request that never got an answer.
+type statusError struct {
+	Code int
+	Body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.Code, e.Body)
+}
+
+// rejected reports whether the server refused the request itself, so that
+// sending it again would fail the same way. Timeouts, throttling and server
+// errors are worth retrying.
+func (e *statusError) rejected() bool {
+	return e.Code >= 400 && e.Code < 500 && e.Code != http.StatusRequestTimeout && e.Code != http.StatusTooManyRequests
+}
@@ -3,9 +3,17 @@ package livemigrate
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestMigrationDryRun(t *testing.T) {
@@ -140,3 +148,285 @@ func TestStatsSnapshot(t *testing.T) {
 		t.Errorf("expected idle, got %s", stats.Phase)
 	}
 }
+
+func TestMigrationVerifySampling(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"jobs": []map[string]any{
+				{"id": "j1", "type": "email.send", "queue": "default", "args": []any{"a@example.com"}},
+				{"id": "j2", "type": "image.resize", "queue": "media", "args": []any{100, 200}},
+				{"id": "j3", "type": "report.build", "queue": "default", "args": []any{"daily"}},
+				{"id": "j4", "type": "email.send", "queue": "default", "args": []any{"b@example.com"}},
+			},
+		})
+	}))
+	defer source.Close()
+
+	// The target acknowledges every job but has lost t2 and holds t3 with
+	// different args.
+	var n int
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ojs/v1/health":
+			json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
+		case "/ojs/v1/jobs":
+			n++
+			w.WriteHeader(201)
+			json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("t%d", n)})
+		case "/ojs/v1/jobs/t1":
+			json.NewEncoder(w).Encode(map[string]any{"job": map[string]any{"id": "t1", "type": "email.send", "args": []any{"a@example.com"}}})
+		case "/ojs/v1/jobs/t3":
+			json.NewEncoder(w).Encode(map[string]any{"id": "t3", "type": "report.build", "args": []any{"weekly"}})
+		case "/ojs/v1/jobs/t4":
+			json.NewEncoder(w).Encode(map[string]any{"id": "t4", "type": "email.send", "args": []any{"b@example.com"}})
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer target.Close()
+
+	m := New(Config{SourceURL: source.URL, TargetURL: target.URL})
+	if err := m.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	stats := m.GetStats()
+	if stats.Imported != 4 || stats.Verified != 2 || stats.Errors != 2 {
+		t.Errorf("imported=%d verified=%d errors=%d, want 4/2/2", stats.Imported, stats.Verified, stats.Errors)
+	}
+}
+
+func TestSampleJobs(t *testing.T) {
+	jobs := []importedJob{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}, {ID: "e"}}
+	if got := sampleJobs(jobs, 10); len(got) != 5 {
+		t.Errorf("expected all jobs when the sample is larger, got %d", len(got))
+	}
+	got := sampleJobs(jobs, 3)
+	seen := map[string]bool{}
+	for _, j := range got {
+		seen[j.ID] = true
+	}
+	if len(got) != 3 || len(seen) != 3 {
+		t.Errorf("expected 3 distinct jobs, got %v", got)
+	}
+}
+
+func TestMigrationResumeFromCheckpoint(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobs := make([]map[string]any, 5)
+		for i := range jobs {
+			jobs[i] = map[string]any{"id": fmt.Sprintf("j%d", i+1), "type": "email.send", "queue": "default", "args": []any{i + 1}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": jobs})
+	}))
+	defer source.Close()
+
+	// The target drops the connection on every attempt at the third job
+	// during the first run.
+	fastRetries(t)
+	var mu sync.Mutex
+	var received []float64
+	drops := importAttempts
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ojs/v1/health" {
+			json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.WriteHeader(404)
+			return
+		}
+		var job struct {
+			Args []float64 `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&job)
+		mu.Lock()
+		defer mu.Unlock()
+		if job.Args[0] == 3 && drops > 0 {
+			drops--
+			panic(http.ErrAbortHandler)
+		}
+		received = append(received, job.Args[0])
+		w.WriteHeader(201)
+		json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("t%v", job.Args[0])})
+	}))
+	defer target.Close()
+
+	path := filepath.Join(t.TempDir(), "migration.checkpoint")
+	cfg := Config{SourceURL: source.URL, TargetURL: target.URL, CheckpointFile: path}
+
+	m := New(cfg)
+	if err := m.Run(context.Background()); err == nil {
+		t.Fatal("expected the first run to fail mid-import")
+	}
+	if stats := m.GetStats(); stats.Phase != PhaseFailed || stats.Imported != 2 {
+		t.Fatalf("first run: phase=%s imported=%d", stats.Phase, stats.Imported)
+	}
+	cp, err := loadCheckpoint(path)
+	if err != nil || cp == nil || cp.LastImportedID != "j2" || cp.Imported != 2 {
+		t.Fatalf("checkpoint = %+v, %v", cp, err)
+	}
+
+	m = New(cfg)
+	if err := m.Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if want := []float64{1, 2, 3, 4, 5}; !reflect.DeepEqual(received, want) {
+		t.Errorf("target received %v, want %v (each job once)", received, want)
+	}
+	if stats := m.GetStats(); stats.Phase != PhaseComplete || stats.Imported != 5 {
+		t.Errorf("resumed run: phase=%s imported=%d", stats.Phase, stats.Imported)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected checkpoint to be removed after completion, got %v", err)
+	}
+}
+
+func TestMigrationCheckpointForOtherMigration(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"jobs": []map[string]any{{"id": "j1", "type": "t"}}})
+	}))
+	defer source.Close()
+
+	path := filepath.Join(t.TempDir(), "migration.checkpoint")
+	(&checkpoint{SourceURL: "http://elsewhere", TargetURL: "http://b", LastImportedID: "j1"}).save(path)
+
+	m := New(Config{SourceURL: source.URL, TargetURL: "http://b", CheckpointFile: path})
+	if err := m.Run(context.Background()); err == nil {
+		t.Error("expected error for a checkpoint from a different migration")
+	}
+}
+
+func TestMigrationStopsOnServerError(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobs := make([]map[string]any, 4)
+		for i := range jobs {
+			jobs[i] = map[string]any{"id": fmt.Sprintf("j%d", i+1), "type": "email.send", "queue": "default", "args": []any{i + 1}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": jobs})
+	}))
+	defer source.Close()
+
+	// The target rejects job 1 as invalid for good, and is unavailable
+	// for every attempt at job 3 during the first run.
+	fastRetries(t)
+	var mu sync.Mutex
+	var received []float64
+	unavailable := importAttempts
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ojs/v1/health" {
+			json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
+			return
+		}
+		if r.Method != http.MethodPost {
+			w.WriteHeader(404)
+			return
+		}
+		var job struct {
+			Args []float64 `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&job)
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case job.Args[0] == 1:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		case job.Args[0] == 3 && unavailable > 0:
+			unavailable--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received = append(received, job.Args[0])
+		w.WriteHeader(201)
+		json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("t%v", job.Args[0])})
+	}))
+	defer target.Close()
+
+	path := filepath.Join(t.TempDir(), "migration.checkpoint")
+	cfg := Config{SourceURL: source.URL, TargetURL: target.URL, CheckpointFile: path}
+
+	m := New(cfg)
+	if err := m.Run(context.Background()); err == nil {
+		t.Fatal("expected the 503 to stop the import")
+	}
+	if stats := m.GetStats(); stats.Imported != 1 || stats.Errors != 1 {
+		t.Fatalf("first run: imported=%d errors=%d, want 1/1", stats.Imported, stats.Errors)
+	}
+	if cp, _ := loadCheckpoint(path); cp == nil || cp.LastImportedID != "j2" {
+		t.Fatalf("checkpoint = %+v, want last imported j2", cp)
+	}
+
+	m = New(cfg)
+	if err := m.Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if want := []float64{2, 3, 4}; !reflect.DeepEqual(received, want) {
+		t.Errorf("target received %v, want %v", received, want)
+	}
+}
+
+// fastRetries shortens the import retry backoff for the test.
+func fastRetries(t *testing.T) {
+	t.Helper()
+	orig := importRetryDelay
+	importRetryDelay = time.Millisecond
+	t.Cleanup(func() { importRetryDelay = orig })
+}
+
+func TestMigrationRetriesWithoutCheckpoint(t *testing.T) {
+	fastRetries(t)
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobs := make([]map[string]any, 4)
+		for i := range jobs {
+			jobs[i] = map[string]any{"id": fmt.Sprintf("j%d", i+1), "type": "email.send", "queue": "default", "args": []any{i + 1}}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"jobs": jobs})
+	}))
+	defer source.Close()
+
+	// Job 2 is throttled twice and then accepted; job 3 always fails.
+	var mu sync.Mutex
+	var received []float64
+	throttled := 2
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ojs/v1/health" {
+			json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
+			return
+		}
+		if id, ok := strings.CutPrefix(r.URL.Path, "/ojs/v1/jobs/t"); ok {
+			n, _ := strconv.Atoi(id)
+			json.NewEncoder(w).Encode(map[string]any{"type": "email.send", "args": []any{n}})
+			return
+		}
+		var job struct {
+			Args []float64 `json:"args"`
+		}
+		json.NewDecoder(r.Body).Decode(&job)
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case job.Args[0] == 2 && throttled > 0:
+			throttled--
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		case job.Args[0] == 3:
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		received = append(received, job.Args[0])
+		w.WriteHeader(201)
+		json.NewEncoder(w).Encode(map[string]string{"id": fmt.Sprintf("t%v", job.Args[0])})
+	}))
+	defer target.Close()
+
+	m := New(Config{SourceURL: source.URL, TargetURL: target.URL})
+	if err := m.Run(context.Background()); err != nil {
+		t.Fatalf("without a checkpoint the import should carry on: %v", err)
+	}
+	if want := []float64{1, 2, 4}; !reflect.DeepEqual(received, want) {
+		t.Errorf("target received %v, want %v", received, want)
+	}
+	if stats := m.GetStats(); stats.Imported != 3 || stats.Errors != 1 {
+		t.Errorf("imported=%d errors=%d, want 3/1", stats.Imported, stats.Errors)
+	}
+}
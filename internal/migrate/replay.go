@@ -0,0 +1,90 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ReplayOptions adjusts jobs re-enqueued from an OJS-native export.
+type ReplayOptions struct {
+	Queue    string // replaces every job's queue when set
+	Priority *int   // replaces every job's priority when set
+	NoDedup  bool   // skips the unique key that guards against double replays
+}
+
+// nativeJob is a job envelope as returned by the OJS API, for example in
+// the files written by "ojs bulk archive". Queue and priority may appear at
+// the top level or under options.
+type nativeJob struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Queue    string          `json:"queue"`
+	Args     json.RawMessage `json:"args"`
+	Priority *int            `json:"priority"`
+	Meta     map[string]any  `json:"meta"`
+	Options  struct {
+		Queue    string `json:"queue"`
+		Priority *int   `json:"priority"`
+		Unique   struct {
+			Key string `json:"key"`
+		} `json:"unique"`
+	} `json:"options"`
+}
+
+// ParseReplayJob converts one line of an OJS-native NDJSON export into the
+// job to enqueue, applying opts. Unless opts.NoDedup is set the job keeps
+// its own unique key, or gets "replay:<id>", so replaying the same file
+// twice does not enqueue duplicates.
+func ParseReplayJob(line []byte, opts ReplayOptions) (ExportedJob, error) {
+	var n nativeJob
+	if err := json.Unmarshal(line, &n); err != nil {
+		return ExportedJob{}, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if n.Type == "" {
+		return ExportedJob{}, errors.New("missing job type")
+	}
+
+	job := ExportedJob{Type: n.Type, Queue: n.Queue, Args: n.Args, Priority: n.Priority, Meta: n.Meta}
+	if job.Queue == "" {
+		job.Queue = n.Options.Queue
+	}
+	if job.Priority == nil {
+		job.Priority = n.Options.Priority
+	}
+	if opts.Queue != "" {
+		job.Queue = opts.Queue
+	}
+	if opts.Priority != nil {
+		job.Priority = opts.Priority
+	}
+	if job.Queue == "" {
+		job.Queue = "default"
+	}
+	if len(job.Args) == 0 {
+		job.Args = json.RawMessage("[]")
+	}
+	if !opts.NoDedup {
+		job.UniqueKey = n.Options.Unique.Key
+		if job.UniqueKey == "" && n.ID != "" {
+			job.UniqueKey = "replay:" + n.ID
+		}
+	}
+	return job, nil
+}
+
+// ReplayFile re-enqueues the jobs of an OJS-native NDJSON export through
+// the same batching as ImportFile.
+func ReplayFile(c Poster, filename string, opts ReplayOptions, progress func(imported, total int)) (*ImportResult, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	decode := func(line []byte) (ExportedJob, error) {
+		return ParseReplayJob(line, opts)
+	}
+	return importFromReader(c, f, decode, progress)
+}
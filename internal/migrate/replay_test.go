@@ -0,0 +1,33 @@
+package migrate
+
+import "testing"
+
+func TestParseReplayJob(t *testing.T) {
+	line := []byte(`{"id":"j1","type":"email.send","state":"completed","args":["a@example.com"],"options":{"queue":"emails","priority":3}}`)
+
+	job, err := ParseReplayJob(line, ReplayOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.Type != "email.send" || job.Queue != "emails" || job.Priority == nil || *job.Priority != 3 {
+		t.Errorf("job = %+v", job)
+	}
+	if job.UniqueKey != "replay:j1" {
+		t.Errorf("unique key = %q, want replay:j1", job.UniqueKey)
+	}
+
+	prio := 8
+	job, _ = ParseReplayJob(line, ReplayOptions{Queue: "reprocess", Priority: &prio, NoDedup: true})
+	if job.Queue != "reprocess" || *job.Priority != 8 || job.UniqueKey != "" {
+		t.Errorf("overridden job = %+v", job)
+	}
+
+	job, _ = ParseReplayJob([]byte(`{"id":"j2","type":"t","options":{"unique":{"key":"order-7"}}}`), ReplayOptions{})
+	if job.UniqueKey != "order-7" || job.Queue != "default" || string(job.Args) != "[]" {
+		t.Errorf("job = %+v, want its own unique key and defaults", job)
+	}
+
+	if _, err := ParseReplayJob([]byte(`{"id":"j3"}`), ReplayOptions{}); err == nil {
+		t.Error("expected an error for a job without a type")
+	}
+}
@@ -0,0 +1,71 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RewriteRule renames a queue or job type during import. From matches a
+// value exactly or, with a trailing "*", by prefix. When both sides end in
+// "*" the matched prefix is replaced and the rest of the value kept, so
+// "legacy.*=app.*" turns "legacy.email" into "app.email".
+type RewriteRule struct {
+	From string
+	To   string
+}
+
+// ParseRewriteRule parses an "old=new" rule.
+func ParseRewriteRule(s string) (RewriteRule, error) {
+	from, to, ok := strings.Cut(s, "=")
+	if !ok || from == "" || to == "" {
+		return RewriteRule{}, fmt.Errorf("invalid rule %q (expected old=new)", s)
+	}
+	if strings.Contains(strings.TrimSuffix(from, "*"), "*") || strings.Contains(strings.TrimSuffix(to, "*"), "*") {
+		return RewriteRule{}, fmt.Errorf("invalid rule %q: \"*\" is only allowed at the end", s)
+	}
+	if strings.HasSuffix(to, "*") && !strings.HasSuffix(from, "*") {
+		return RewriteRule{}, fmt.Errorf("invalid rule %q: a prefix replacement needs a prefix pattern", s)
+	}
+	return RewriteRule{From: from, To: to}, nil
+}
+
+// Apply returns the rewritten value and whether the rule matched.
+func (r RewriteRule) Apply(v string) (string, bool) {
+	prefix, isPrefix := strings.CutSuffix(r.From, "*")
+	if !isPrefix {
+		if v != r.From {
+			return v, false
+		}
+		return r.To, true
+	}
+	rest, ok := strings.CutPrefix(v, prefix)
+	if !ok {
+		return v, false
+	}
+	if to, keep := strings.CutSuffix(r.To, "*"); keep {
+		return to + rest, true
+	}
+	return r.To, true
+}
+
+// Rewrites holds the queue and job type rules applied to each imported job.
+// The first matching rule in each list wins.
+type Rewrites struct {
+	Queue []RewriteRule
+	Type  []RewriteRule
+}
+
+// Apply rewrites job's queue and type in place.
+func (rw Rewrites) Apply(job *ExportedJob) {
+	job.Queue = applyFirst(rw.Queue, job.Queue)
+	job.Type = applyFirst(rw.Type, job.Type)
+}
+
+func applyFirst(rules []RewriteRule, v string) string {
+	for _, r := range rules {
+		if out, ok := r.Apply(v); ok {
+			return out
+		}
+	}
+	return v
+}
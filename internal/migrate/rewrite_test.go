@@ -0,0 +1,40 @@
+package migrate
+
+import "testing"
+
+func TestRewriteRules(t *testing.T) {
+	var rw Rewrites
+	for _, spec := range []string{"mailers=email", "legacy.*=app.*", "tmp*=scratch"} {
+		rule, err := ParseRewriteRule(spec)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		rw.Queue = append(rw.Queue, rule)
+		rw.Type = append(rw.Type, rule)
+	}
+
+	tests := map[string]string{
+		"mailers":       "email",
+		"mailers-2":     "mailers-2",
+		"legacy.email":  "app.email",
+		"legacy.":       "app.",
+		"tmp-1":         "scratch",
+		"default":       "default",
+		"legacy-report": "legacy-report",
+	}
+	for in, want := range tests {
+		job := ExportedJob{Queue: in, Type: in}
+		rw.Apply(&job)
+		if job.Queue != want || job.Type != want {
+			t.Errorf("%q -> queue %q, type %q, want %q", in, job.Queue, job.Type, want)
+		}
+	}
+}
+
+func TestParseRewriteRule_Invalid(t *testing.T) {
+	for _, spec := range []string{"mailers", "=email", "mailers=", "a*b=c", "a=b*", "a*=b*c"} {
+		if _, err := ParseRewriteRule(spec); err == nil {
+			t.Errorf("ParseRewriteRule(%q): expected error", spec)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/openjobspec/ojs-cli/internal/codegen"
+)
+
+// ValidateFileWithManifest validates an NDJSON file like ValidateFile and
+// additionally checks each job's type and args against a codegen manifest.
+func ValidateFileWithManifest(filename string, manifest *codegen.Manifest) (*ValidationResult, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+	defer f.Close()
+
+	return validate(f, manifest)
+}
+
+// validateAgainstManifest checks a structurally valid job against the job
+// type definitions in m. Args may be positional, in the manifest's arg
+// order, or a single object keyed by arg name.
+func validateAgainstManifest(job *ExportedJob, m *codegen.Manifest, line int) []ValidationError {
+	var def *codegen.JobTypeDef
+	for i := range m.JobTypes {
+		if m.JobTypes[i].Type == job.Type {
+			def = &m.JobTypes[i]
+			break
+		}
+	}
+	if def == nil {
+		return []ValidationError{{Line: line, Message: fmt.Sprintf("job type %q is not defined in the schema", job.Type)}}
+	}
+
+	var args []any
+	if err := json.Unmarshal(job.Args, &args); err != nil {
+		return nil // already reported by validateJob
+	}
+
+	var msgs []string
+	if named, ok := namedArgs(args, def); ok {
+		msgs = checkNamedArgs(named, def)
+	} else {
+		msgs = checkPositionalArgs(args, def)
+	}
+
+	errs := make([]ValidationError, len(msgs))
+	for i, msg := range msgs {
+		errs[i] = ValidationError{Line: line, Message: fmt.Sprintf("%s: %s", job.Type, msg)}
+	}
+	return errs
+}
+
+// namedArgs reports whether args is a single object carrying the manifest's
+// args by name, as the generated Go and Python helpers enqueue them.
+func namedArgs(args []any, def *codegen.JobTypeDef) (map[string]any, bool) {
+	if len(args) != 1 {
+		return nil, false
+	}
+	obj, ok := args[0].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	if len(def.Args) == 1 && def.Args[0].Type == "object" {
+		if _, named := obj[def.Args[0].Name]; !named {
+			return nil, false // the object is the single object-typed arg
+		}
+	}
+	return obj, true
+}
+
+func checkPositionalArgs(args []any, def *codegen.JobTypeDef) []string {
+	var msgs []string
+	for i, arg := range def.Args {
+		if i >= len(args) {
+			if arg.Required {
+				msgs = append(msgs, fmt.Sprintf("missing required arg %q (position %d)", arg.Name, i))
+			}
+			continue
+		}
+		if msg := checkArgType(arg, args[i]); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	if len(args) > len(def.Args) {
+		msgs = append(msgs, fmt.Sprintf("got %d args, schema defines %d", len(args), len(def.Args)))
+	}
+	return msgs
+}
+
+func checkNamedArgs(named map[string]any, def *codegen.JobTypeDef) []string {
+	var msgs []string
+	known := make(map[string]bool, len(def.Args))
+	for _, arg := range def.Args {
+		known[arg.Name] = true
+		v, ok := named[arg.Name]
+		if !ok {
+			if arg.Required {
+				msgs = append(msgs, fmt.Sprintf("missing required arg %q", arg.Name))
+			}
+			continue
+		}
+		if msg := checkArgType(arg, v); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+
+	var unknown []string
+	for name := range named {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		msgs = append(msgs, fmt.Sprintf("unknown args: %s", strings.Join(unknown, ", ")))
+	}
+	return msgs
+}
+
+// checkArgType returns a message if v does not match the arg's declared
+// type. Null is accepted for optional args.
+func checkArgType(arg codegen.ArgDef, v any) string {
+	if v == nil {
+		if arg.Required {
+			return fmt.Sprintf("arg %q is required but null", arg.Name)
+		}
+		return ""
+	}
+
+	var ok bool
+	switch arg.Type {
+	case "string", "":
+		_, ok = v.(string)
+	case "int":
+		n, isNum := v.(float64)
+		ok = isNum && n == math.Trunc(n)
+	case "float":
+		_, ok = v.(float64)
+	case "bool":
+		_, ok = v.(bool)
+	case "object":
+		_, ok = v.(map[string]any)
+	case "array":
+		_, ok = v.([]any)
+	default:
+		return "" // unknown types are not checked
+	}
+	if !ok {
+		return fmt.Sprintf("arg %q should be %s, got %s", arg.Name, arg.Type, jsonKind(v))
+	}
+	return ""
+}
+
+func jsonKind(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "int"
+		}
+		return "float"
+	case bool:
+		return "bool"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
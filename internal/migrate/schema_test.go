@@ -0,0 +1,63 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openjobspec/ojs-cli/internal/codegen"
+)
+
+var schemaManifest = &codegen.Manifest{
+	JobTypes: []codegen.JobTypeDef{
+		{Type: "email.send", Queue: "email", Args: []codegen.ArgDef{
+			{Name: "to", Type: "string", Required: true},
+			{Name: "retries", Type: "int"},
+		}},
+		{Type: "report.build", Queue: "default", Args: []codegen.ArgDef{
+			{Name: "filters", Type: "object", Required: true},
+		}},
+	},
+}
+
+func TestValidateFileWithManifest(t *testing.T) {
+	lines := []string{
+		`{"type":"email.send","queue":"email","args":["a@b.c",3]}`,
+		`{"type":"email.send","queue":"email","args":[{"to":"a@b.c"}]}`,
+		`{"type":"report.build","queue":"default","args":[{"since":"2024-01-01"}]}`,
+		`{"type":"email.send","queue":"email","args":[42,"three"]}`,
+		`{"type":"email.send","queue":"email","args":[{"to":"a@b.c","cc":"x"}]}`,
+		`{"type":"email.send","queue":"email","args":[]}`,
+		`{"type":"sms.send","queue":"sms","args":["+1"]}`,
+		`{"type":"email.send","queue":"email","args":["a@b.c",1.5,true]}`,
+	}
+	path := filepath.Join(t.TempDir(), "jobs.ndjson")
+	var data []byte
+	for _, l := range lines {
+		data = append(data, l+"\n"...)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ValidateFileWithManifest(path, schemaManifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Total != 8 || result.Valid != 3 || result.Invalid != 5 {
+		t.Errorf("total/valid/invalid = %d/%d/%d, want 8/3/5", result.Total, result.Valid, result.Invalid)
+	}
+	want := []ValidationError{
+		{Line: 4, Message: `email.send: arg "to" should be string, got int`},
+		{Line: 4, Message: `email.send: arg "retries" should be int, got string`},
+		{Line: 5, Message: "email.send: unknown args: cc"},
+		{Line: 6, Message: `email.send: missing required arg "to" (position 0)`},
+		{Line: 7, Message: `job type "sms.send" is not defined in the schema`},
+		{Line: 8, Message: `email.send: arg "retries" should be int, got float`},
+		{Line: 8, Message: "email.send: got 3 args, schema defines 2"},
+	}
+	if !reflect.DeepEqual(result.Errors, want) {
+		t.Errorf("errors =\n%v\nwant\n%v", result.Errors, want)
+	}
+}
@@ -38,6 +38,12 @@ type sidekiqJob struct {
 	JID        string          `json:"jid"`
 	EnqueuedAt float64         `json:"enqueued_at"`
 	At         float64         `json:"at,omitempty"`
+
+	// Set on jobs in the retry and dead sets.
+	ErrorMessage string  `json:"error_message,omitempty"`
+	ErrorClass   string  `json:"error_class,omitempty"`
+	RetryCount   int     `json:"retry_count,omitempty"`
+	FailedAt     float64 `json:"failed_at,omitempty"`
 }
 
 func (s *SidekiqSource) Analyze() (*AnalysisResult, error) {
@@ -131,11 +137,12 @@ func (s *SidekiqSource) Export() ([]ExportedJob, error) {
 		}
 	}
 
-	// Retry jobs
-	retries, err := s.rdb.ZRangeWithScores(ctx, "retry", 0, -1).Result()
+	// Retry jobs are still pending in Sidekiq, so they are exported by
+	// default, tagged with source_state "retry".
+	retries, err := s.rdb.ZRange(ctx, "retry", 0, -1).Result()
 	if err == nil {
-		for _, z := range retries {
-			if ej, err := parseSidekiqJob(z.Member.(string)); err == nil {
+		for _, raw := range retries {
+			if ej, err := parseSidekiqFailedJob(raw, "retry"); err == nil {
 				exported = append(exported, *ej)
 			}
 		}
@@ -144,6 +151,25 @@ func (s *SidekiqSource) Export() ([]ExportedJob, error) {
 	return exported, nil
 }
 
+// ExportFailed exports the jobs in the dead set, tagged with source_state
+// "dead". Retry jobs are already part of Export.
+func (s *SidekiqSource) ExportFailed() ([]ExportedJob, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	members, err := s.rdb.ZRange(ctx, "dead", 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("read dead set: %w", err)
+	}
+	var exported []ExportedJob
+	for _, raw := range members {
+		if ej, err := parseSidekiqFailedJob(raw, "dead"); err == nil {
+			exported = append(exported, *ej)
+		}
+	}
+	return exported, nil
+}
+
 // ParseSidekiqJob converts a raw Sidekiq JSON string into an ExportedJob.
 // Exported for testing.
 func ParseSidekiqJob(raw string) (*ExportedJob, error) {
@@ -177,6 +203,33 @@ func parseSidekiqJob(raw string) (*ExportedJob, error) {
 	return ej, nil
 }
 
+// ParseSidekiqFailedJob converts an entry of the Sidekiq retry or dead set
+// into an ExportedJob. Exported for testing.
+func ParseSidekiqFailedJob(raw, state string) (*ExportedJob, error) {
+	return parseSidekiqFailedJob(raw, state)
+}
+
+func parseSidekiqFailedJob(raw, state string) (*ExportedJob, error) {
+	ej, err := parseSidekiqJob(raw)
+	if err != nil {
+		return nil, err
+	}
+	var sj sidekiqJob
+	json.Unmarshal([]byte(raw), &sj)
+
+	ej.Meta["source_state"] = state
+	if sj.ErrorClass != "" || sj.ErrorMessage != "" {
+		ej.Meta["sidekiq_error"] = strings.TrimPrefix(sj.ErrorClass+": "+sj.ErrorMessage, ": ")
+	}
+	if sj.RetryCount > 0 {
+		ej.Meta["sidekiq_retry_count"] = sj.RetryCount
+	}
+	if sj.FailedAt > 0 {
+		ej.Meta["sidekiq_failed_at"] = time.Unix(0, int64(sj.FailedAt*float64(time.Second))).UTC().Format(time.RFC3339)
+	}
+	return ej, nil
+}
+
 // sidekiqClassToType converts a Ruby class name to an OJS job type.
 // e.g., "EmailWorker" → "email.worker", "Mailers::WelcomeEmail" → "mailers.welcome.email"
 func sidekiqClassToType(class string) string {
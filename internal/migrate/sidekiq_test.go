@@ -0,0 +1,157 @@
+package migrate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// fakeRedis is a minimal RESP2 server holding sets, lists and sorted sets
+// (in insertion order) for the commands the Sidekiq source sends. Anything
+// else, including the client's HELLO handshake, gets an error reply.
+type fakeRedis struct {
+	sets  map[string][]string
+	lists map[string][]string
+	zsets map[string][]string
+}
+
+func (f *fakeRedis) start(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return "redis://" + ln.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	for {
+		cmd, err := readRESPCommand(r)
+		if err != nil {
+			return
+		}
+		var reply []string
+		switch strings.ToUpper(cmd[0]) {
+		case "SMEMBERS":
+			reply = f.sets[cmd[1]]
+		case "LRANGE":
+			reply = f.lists[cmd[1]]
+		case "ZRANGE":
+			for i, m := range f.zsets[cmd[1]] {
+				reply = append(reply, m)
+				if strings.EqualFold(cmd[len(cmd)-1], "WITHSCORES") {
+					reply = append(reply, strconv.Itoa(i))
+				}
+			}
+		case "ZCARD":
+			fmt.Fprintf(conn, ":%d\r\n", len(f.zsets[cmd[1]]))
+			continue
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", cmd[0])
+			continue
+		}
+		fmt.Fprintf(conn, "*%d\r\n", len(reply))
+		for _, s := range reply {
+			fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(s), s)
+		}
+	}
+}
+
+func readRESPCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "*")))
+	if err != nil {
+		return nil, fmt.Errorf("unexpected line %q", line)
+	}
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func sidekiqFixture(t *testing.T) *SidekiqSource {
+	t.Helper()
+	f := &fakeRedis{
+		sets:  map[string][]string{"queues": {"default"}},
+		lists: map[string][]string{"queue:default": {`{"class":"EmailWorker","args":[1],"queue":"default","jid":"a1"}`}},
+		zsets: map[string][]string{
+			"schedule": {`{"class":"ReportWorker","args":[],"queue":"default","jid":"s1","at":1767225600}`},
+			"retry":    {`{"class":"EmailWorker","args":[2],"queue":"default","jid":"r1","retry_count":1,"error_class":"Net::ReadTimeout"}`},
+			"dead":     {`{"class":"EmailWorker","args":[3],"queue":"default","jid":"d1","error_message":"boom"}`},
+		},
+	}
+	src, err := NewSidekiqSource(f.start(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { src.Close() })
+	return src
+}
+
+func TestSidekiqSource_ExportIncludesRetries(t *testing.T) {
+	src := sidekiqFixture(t)
+
+	jobs, err := src.Export()
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	var jids []string
+	for _, j := range jobs {
+		jids = append(jids, j.Meta["sidekiq_jid"].(string))
+	}
+	if strings.Join(jids, ",") != "a1,s1,r1" {
+		t.Fatalf("exported jids = %v, want a1,s1,r1", jids)
+	}
+	if jobs[2].Meta["source_state"] != "retry" {
+		t.Errorf("retry job meta = %v", jobs[2].Meta)
+	}
+
+	analysis, err := src.Analyze()
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+	if analysis.TotalJobs != len(jobs) {
+		t.Errorf("analyze counts %d jobs, export returned %d", analysis.TotalJobs, len(jobs))
+	}
+}
+
+func TestSidekiqSource_ExportFailedOnlyDead(t *testing.T) {
+	src := sidekiqFixture(t)
+
+	jobs, err := src.ExportFailed()
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Meta["sidekiq_jid"] != "d1" || jobs[0].Meta["source_state"] != "dead" {
+		t.Errorf("ExportFailed = %+v, want only the dead job", jobs)
+	}
+}
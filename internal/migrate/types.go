@@ -26,6 +26,10 @@ type ExportedJob struct {
 	Priority    *int            `json:"priority,omitempty"`
 	ScheduledAt string          `json:"scheduled_at,omitempty"`
 	Meta        map[string]any  `json:"meta,omitempty"`
+
+	// UniqueKey deduplicates the job on enqueue. It is set when replaying
+	// OJS-native exports and is not part of the export format.
+	UniqueKey string `json:"-"`
 }
 
 // Source is the interface that migration source adapters implement.
@@ -34,3 +38,10 @@ type Source interface {
 	Export() ([]ExportedJob, error)
 	Close() error
 }
+
+// FailedJobExporter is implemented by sources that can also export jobs
+// from their dead-letter (or failed) sets. Each job's meta carries its
+// original state under "source_state".
+type FailedJobExporter interface {
+	ExportFailed() ([]ExportedJob, error)
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+
+	"github.com/openjobspec/ojs-cli/internal/codegen"
 )
 
 // ValidationResult holds the outcome of validating an exported NDJSON file.
@@ -30,10 +32,12 @@ func ValidateFile(filename string) (*ValidationResult, error) {
 	}
 	defer f.Close()
 
-	return validateFromReader(f)
+	return validate(f, nil)
 }
 
-func validateFromReader(r io.Reader) (*ValidationResult, error) {
+// validate checks each NDJSON line as an OJS job and, when manifest is
+// non-nil, against its job type definitions.
+func validate(r io.Reader, manifest *codegen.Manifest) (*ValidationResult, error) {
 	scanner := bufio.NewScanner(r)
 	result := &ValidationResult{}
 	lineNum := 0
@@ -57,7 +61,11 @@ func validateFromReader(r io.Reader) (*ValidationResult, error) {
 			continue
 		}
 
-		if errs := validateJob(&job, lineNum); len(errs) > 0 {
+		errs := validateJob(&job, lineNum)
+		if len(errs) == 0 && manifest != nil {
+			errs = validateAgainstManifest(&job, manifest, lineNum)
+		}
+		if len(errs) > 0 {
 			result.Invalid++
 			result.Errors = append(result.Errors, errs...)
 			continue
@@ -11,24 +11,83 @@ import (
 // Format controls the output format ("table" or "json").
 var Format = "table"
 
-// JSON prints data as formatted JSON.
+// MaxColumnWidth is the widest a table cell may be, in terminal columns,
+// before it is truncated with an ellipsis. Zero disables truncation.
+var MaxColumnWidth = 60
+
+// Wide disables table cell truncation (the --wide flag).
+var Wide bool
+
+// Quiet suppresses the informational messages printed by Success and Warn
+// (the --quiet flag). Data output and errors are unaffected.
+var Quiet bool
+
+// JSON prints data as formatted JSON. When Query is set, only the selected
+// value is printed, and a selected string is printed without quotes.
 func JSON(data any) error {
+	if Query != "" {
+		v, err := Select(data, Query)
+		if err != nil {
+			return err
+		}
+		if s, ok := v.(string); ok {
+			_, err := fmt.Println(s)
+			return err
+		}
+		data = v
+	}
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	return enc.Encode(data)
 }
 
-// Table prints rows in a table format with headers.
+// Table prints rows in a table format with headers. Cells wider than
+// MaxColumnWidth are truncated unless Wide is set.
 func Table(headers []string, rows [][]string) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
 	fmt.Fprintln(w, strings.Repeat("─", len(headers)*16))
 	for _, row := range rows {
-		fmt.Fprintln(w, strings.Join(row, "\t"))
+		fmt.Fprintln(w, strings.Join(fitCells(row), "\t"))
 	}
 	w.Flush()
 }
 
+func fitCells(row []string) []string {
+	out := make([]string, len(row))
+	for i, cell := range row {
+		out[i] = FitCell(cell)
+	}
+	return out
+}
+
+// FitCell truncates s to MaxColumnWidth unless Wide is set. Table applies it
+// to every cell; commands printing their own columns can use it directly.
+func FitCell(s string) string {
+	if Wide {
+		return s
+	}
+	return Truncate(s, MaxColumnWidth)
+}
+
+// Tabular is implemented by values that can render themselves as a table.
+type Tabular interface {
+	TableHeaders() []string
+	TableRows() [][]string
+}
+
+// Render prints v in the configured format: as JSON in JSON mode, and as a
+// table in table mode when v is Tabular. Other values are printed as JSON.
+func Render(v any) error {
+	if Format != "json" {
+		if t, ok := v.(Tabular); ok {
+			Table(t.TableHeaders(), t.TableRows())
+			return nil
+		}
+	}
+	return JSON(v)
+}
+
 // PrintResult prints data in the configured format.
 func PrintResult(data any, headers []string, toRow func(any) []string) error {
 	if Format == "json" {
@@ -50,10 +109,21 @@ func PrintResult(data any, headers []string, toRow func(any) []string) error {
 
 // Success prints a success message.
 func Success(format string, args ...any) {
+	if Quiet {
+		return
+	}
 	fmt.Printf("✓ "+format+"\n", args...)
 }
 
 // Warn prints a warning message.
 func Warn(format string, args ...any) {
+	if Quiet {
+		return
+	}
 	fmt.Fprintf(os.Stderr, "⚠ "+format+"\n", args...)
 }
+
+// Error prints an error to stderr. It is printed even when Quiet is set.
+func Error(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+}
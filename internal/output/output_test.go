@@ -2,6 +2,7 @@ package output
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"strings"
 	"testing"
@@ -64,3 +65,114 @@ func TestJSON(t *testing.T) {
 		t.Error("output missing value field")
 	}
 }
+
+type queueTable []string
+
+func (q queueTable) TableHeaders() []string { return []string{"NAME"} }
+
+func (q queueTable) TableRows() [][]string {
+	rows := make([][]string, len(q))
+	for i, name := range q {
+		rows[i] = []string{name}
+	}
+	return rows
+}
+
+func TestRender(t *testing.T) {
+	defer func(f string) { Format = f }(Format)
+
+	Format = "table"
+	out := captureOutput(t, func() { Render(queueTable{"default", "emails"}) })
+	if !strings.Contains(out, "NAME") || !strings.Contains(out, "emails") || strings.Contains(out, "[") {
+		t.Errorf("expected a table in table mode, got:\n%s", out)
+	}
+
+	out = captureOutput(t, func() { Render(map[string]int{"total": 2}) })
+	if !strings.Contains(out, `"total": 2`) {
+		t.Errorf("non-tabular values should fall back to JSON, got:\n%s", out)
+	}
+
+	Format = "json"
+	out = captureOutput(t, func() { Render(queueTable{"default"}) })
+	if strings.Join(strings.Fields(out), "") != `["default"]` {
+		t.Errorf("expected JSON in json mode, got:\n%s", out)
+	}
+}
+
+func TestTable_TruncatesWideCells(t *testing.T) {
+	defer func(w int, wide bool) { MaxColumnWidth, Wide = w, wide }(MaxColumnWidth, Wide)
+	long := "connection refused: dial tcp 10.0.0.12:6379: i/o timeout after 30s"
+
+	MaxColumnWidth = 20
+	out := captureOutput(t, func() { Table([]string{"ID", "ERROR"}, [][]string{{"job-1", long}}) })
+	if strings.Contains(out, long) || !strings.Contains(out, "connection refused:…") {
+		t.Errorf("expected the error cell truncated to 20 columns, got:\n%s", out)
+	}
+	if !strings.Contains(out, "ERROR") {
+		t.Errorf("headers must not be dropped, got:\n%s", out)
+	}
+
+	Wide = true
+	out = captureOutput(t, func() { Table([]string{"ID", "ERROR"}, [][]string{{"job-1", long}}) })
+	if !strings.Contains(out, long) {
+		t.Errorf("--wide should keep the full cell, got:\n%s", out)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"a longer value", 8, "a longe…"},
+		{"日本語のエラー", 7, "日本語…"},       // 2 columns per character
+		{"café au lait", 5, "café…"}, // é is one column
+		{"éééé", 3, "éé…"},
+		{"anything", 0, "anything"},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.in, tt.max)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+		}
+		if tt.max > 0 && DisplayWidth(got) > tt.max {
+			t.Errorf("Truncate(%q, %d) is %d columns wide", tt.in, tt.max, DisplayWidth(got))
+		}
+	}
+}
+
+func TestQuiet(t *testing.T) {
+	defer func(q bool, f string) { Quiet, Format = q, f }(Quiet, Format)
+	Quiet = true
+
+	oldErr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	out := captureOutput(t, func() {
+		Success("Queue %q paused", "emails")
+		Warn("worker %s is stale", "w-1")
+		Format = "json"
+		JSON(map[string]string{"state": "paused"})
+		Error(errors.New("queue not found"))
+	})
+	w.Close()
+	os.Stderr = oldErr
+	var stderr bytes.Buffer
+	stderr.ReadFrom(r)
+
+	if strings.Contains(out, "✓") {
+		t.Errorf("success message should be suppressed, got:\n%s", out)
+	}
+	if !strings.Contains(out, `"state": "paused"`) {
+		t.Errorf("data output must still print, got:\n%s", out)
+	}
+	if strings.Contains(stderr.String(), "stale") {
+		t.Errorf("warning should be suppressed, got:\n%s", stderr.String())
+	}
+	if stderr.String() != "Error: queue not found\n" {
+		t.Errorf("errors must still print, got stderr %q", stderr.String())
+	}
+}
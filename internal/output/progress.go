@@ -0,0 +1,114 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	progressBarWidth = 30
+	// progressRedraw limits how often an interactive bar is redrawn.
+	progressRedraw = 100 * time.Millisecond
+)
+
+// Progress reports the progress of a long-running operation on w. In
+// interactive mode it redraws a single bar line with percentage, rate and
+// ETA; in plain mode it prints one line per 10% step, for logs and pipes.
+// A nil *Progress discards all updates.
+type Progress struct {
+	w      io.Writer
+	label  string
+	total  int
+	plain  bool
+	now    func() time.Time
+	start  time.Time
+	drawn  time.Time
+	step   int // last 10% step printed in plain mode
+	active bool
+}
+
+// NewProgress starts a progress report for total units of work.
+func NewProgress(w io.Writer, label string, total int, plain bool) *Progress {
+	p := &Progress{w: w, label: label, total: total, plain: plain, now: time.Now}
+	p.start = p.now()
+	return p
+}
+
+// Update records that done units of work have completed.
+func (p *Progress) Update(done int) {
+	if p == nil {
+		return
+	}
+	now := p.now()
+	if p.plain {
+		step := 0
+		if p.total > 0 {
+			step = done * 10 / p.total
+		}
+		if step > p.step {
+			p.step = step
+			fmt.Fprintln(p.w, p.line(done, now.Sub(p.start)))
+		}
+		return
+	}
+	if done < p.total && now.Sub(p.drawn) < progressRedraw {
+		return
+	}
+	p.drawn = now
+	p.active = true
+	// Erase to end of line in case the new text is shorter.
+	fmt.Fprintf(p.w, "\r%s\x1b[K", p.bar(done, now.Sub(p.start)))
+}
+
+// Done finishes the report, ending an interactive bar's line.
+func (p *Progress) Done() {
+	if p == nil {
+		return
+	}
+	if p.active {
+		fmt.Fprintln(p.w)
+	}
+}
+
+func (p *Progress) bar(done int, elapsed time.Duration) string {
+	filled := 0
+	if p.total > 0 {
+		filled = min(done, p.total) * progressBarWidth / p.total
+	}
+	return fmt.Sprintf("%s [%s%s] %s", p.label,
+		strings.Repeat("█", filled), strings.Repeat("░", progressBarWidth-filled), p.line(done, elapsed))
+}
+
+func (p *Progress) line(done int, elapsed time.Duration) string {
+	s := fmt.Sprintf("%3.0f%% %d/%d", percent(done, p.total), done, p.total)
+	if p.plain {
+		s = p.label + " " + s
+	}
+	rate, eta, ok := ProgressStats(done, p.total, elapsed)
+	if !ok {
+		return s
+	}
+	return fmt.Sprintf("%s  %.0f/s  ETA %s", s, rate, eta)
+}
+
+func percent(done, total int) float64 {
+	if total <= 0 {
+		return 100
+	}
+	return float64(min(done, total)) * 100 / float64(total)
+}
+
+// ProgressStats returns the rate (units per second) and the estimated time
+// remaining after done of total units took elapsed. ok is false until there
+// is enough data for an estimate.
+func ProgressStats(done, total int, elapsed time.Duration) (rate float64, eta time.Duration, ok bool) {
+	if done <= 0 || elapsed <= 0 {
+		return 0, 0, false
+	}
+	rate = float64(done) / elapsed.Seconds()
+	remaining := max(total-done, 0)
+	eta = time.Duration(float64(remaining) / rate * float64(time.Second)).Round(time.Second)
+	return rate, eta, true
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressStats(t *testing.T) {
+	tests := []struct {
+		done, total int
+		elapsed     time.Duration
+		rate        float64
+		eta         time.Duration
+		ok          bool
+	}{
+		{250, 1000, 5 * time.Second, 50, 15 * time.Second, true},
+		{1000, 1000, 4 * time.Second, 250, 0, true},
+		{3, 10, 2 * time.Second, 1.5, 5 * time.Second, true},
+		{0, 1000, 5 * time.Second, 0, 0, false},
+		{10, 1000, 0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		rate, eta, ok := ProgressStats(tt.done, tt.total, tt.elapsed)
+		if rate != tt.rate || eta != tt.eta || ok != tt.ok {
+			t.Errorf("ProgressStats(%d, %d, %s) = %v, %s, %v; want %v, %s, %v",
+				tt.done, tt.total, tt.elapsed, rate, eta, ok, tt.rate, tt.eta, tt.ok)
+		}
+	}
+}
+
+// fakeProgress returns a Progress whose clock advances one second per read.
+func fakeProgress(buf *bytes.Buffer, total int, plain bool) *Progress {
+	p := NewProgress(buf, "Importing", total, plain)
+	clock := p.start
+	p.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	return p
+}
+
+func TestProgress_Plain(t *testing.T) {
+	var buf bytes.Buffer
+	p := fakeProgress(&buf, 100, true)
+	for done := 5; done <= 100; done += 5 {
+		p.Update(done)
+	}
+	p.Done()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 10 {
+		t.Fatalf("plain mode should print one line per 10%%, got %d:\n%s", len(lines), buf.String())
+	}
+	if want := "Importing  10% 10/100  5/s  ETA 18s"; lines[0] != want {
+		t.Errorf("first line = %q, want %q", lines[0], want)
+	}
+	if !strings.HasPrefix(lines[9], "Importing 100% 100/100") {
+		t.Errorf("last line = %q", lines[9])
+	}
+}
+
+func TestProgress_Bar(t *testing.T) {
+	var buf bytes.Buffer
+	p := fakeProgress(&buf, 4, false)
+	p.Update(2)
+	p.Update(4)
+	p.Done()
+
+	out := buf.String()
+	if !strings.Contains(out, "\rImporting ["+strings.Repeat("█", 15)+strings.Repeat("░", 15)+"]  50% 2/4") {
+		t.Errorf("half-way bar missing:\n%q", out)
+	}
+	if !strings.HasSuffix(out, "100% 4/4  2/s  ETA 0s\x1b[K\n") {
+		t.Errorf("final bar should end the line:\n%q", out)
+	}
+}
+
+func TestProgress_Nil(t *testing.T) {
+	var p *Progress
+	p.Update(1)
+	p.Done()
+}
@@ -0,0 +1,123 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Query, when set, is a path expression applied to JSON output so that only
+// the selected value is printed (see Select).
+var Query string
+
+// Select evaluates a GJSON-style path against data and returns the selected
+// value. Paths are dot-separated keys; on arrays a segment may be an index
+// ("items.0" or "items[0]"), "#" for the length, or "#" followed by more
+// segments to collect that path from every element ("items.#.id"). A literal
+// dot in a key is escaped as "\.".
+func Select(data any, expr string) (any, error) {
+	segs, err := parseQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := selectPath(normalize(data), segs)
+	if !ok {
+		return nil, fmt.Errorf("query %q matched nothing", expr)
+	}
+	return v, nil
+}
+
+// normalize converts typed values (structs, typed maps and slices) into the
+// generic form produced by json.Unmarshal, so Select can walk them.
+func normalize(data any) any {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var v any
+	if json.Unmarshal(raw, &v) != nil {
+		return data
+	}
+	return v
+}
+
+func parseQuery(expr string) ([]string, error) {
+	var segs []string
+	var cur strings.Builder
+	escaped, inBracket := false, false
+	flush := func() {
+		if cur.Len() > 0 {
+			segs = append(segs, cur.String())
+			cur.Reset()
+		}
+	}
+	for i := 0; i < len(expr); i++ {
+		ch := expr[i]
+		switch {
+		case escaped:
+			cur.WriteByte(ch)
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '.' && !inBracket:
+			if cur.Len() == 0 && i > 0 && expr[i-1] != ']' {
+				return nil, fmt.Errorf("invalid query %q: empty path segment", expr)
+			}
+			flush()
+		case ch == '[' && !inBracket:
+			flush()
+			inBracket = true
+		case ch == ']' && inBracket:
+			if cur.Len() == 0 {
+				return nil, fmt.Errorf("invalid query %q: empty index", expr)
+			}
+			flush()
+			inBracket = false
+		default:
+			cur.WriteByte(ch)
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("invalid query %q: trailing backslash", expr)
+	}
+	if inBracket {
+		return nil, fmt.Errorf("invalid query %q: unterminated [", expr)
+	}
+	flush()
+	return segs, nil
+}
+
+func selectPath(v any, segs []string) (any, bool) {
+	for i, seg := range segs {
+		switch t := v.(type) {
+		case map[string]any:
+			child, ok := t[seg]
+			if !ok {
+				return nil, false
+			}
+			v = child
+		case []any:
+			if seg == "#" {
+				if i == len(segs)-1 {
+					return float64(len(t)), true
+				}
+				out := []any{}
+				for _, elem := range t {
+					if sel, ok := selectPath(elem, segs[i+1:]); ok {
+						out = append(out, sel)
+					}
+				}
+				return out, true
+			}
+			n, err := strconv.Atoi(seg)
+			if err != nil || n < 0 || n >= len(t) {
+				return nil, false
+			}
+			v = t[n]
+		default:
+			return nil, false
+		}
+	}
+	return v, true
+}
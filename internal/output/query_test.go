@@ -0,0 +1,110 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleResponse = `{
+	"jobs": [
+		{"id": "j1", "state": "active", "args": ["a@b.c", 3], "meta": {"tenant": "acme"}},
+		{"id": "j2", "state": "completed", "args": [], "meta": {"tenant": "globex", "trace.id": "t-9"}}
+	],
+	"pagination": {"total": 2}
+}`
+
+func decodeSample(t *testing.T) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(sampleResponse), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	fn()
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	return buf.String()
+}
+
+func TestSelect(t *testing.T) {
+	data := decodeSample(t)
+	tests := []struct {
+		expr string
+		want any
+	}{
+		{"pagination.total", 2.0},
+		{".pagination.total", 2.0},
+		{"jobs.0.id", "j1"},
+		{"jobs[1].state", "completed"},
+		{"jobs[0].args[1]", 3.0},
+		{"jobs.#", 2.0},
+		{"jobs.#.id", []any{"j1", "j2"}},
+		{"jobs.#.meta.tenant", []any{"acme", "globex"}},
+		{"jobs.#.meta.trace\\.id", []any{"t-9"}},
+		{"jobs.1.meta.trace\\.id", "t-9"},
+		{"jobs.0.meta", map[string]any{"tenant": "acme"}},
+		{"jobs.1.args.#", 0.0},
+	}
+	for _, tt := range tests {
+		got, err := Select(data, tt.expr)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %#v, want %#v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestSelect_NoMatch(t *testing.T) {
+	data := decodeSample(t)
+	for _, expr := range []string{"nope", "jobs.5.id", "jobs.x", "pagination.total.more", "jobs[0"} {
+		if _, err := Select(data, expr); err == nil {
+			t.Errorf("%s: expected error", expr)
+		}
+	}
+}
+
+func TestSelect_TypedData(t *testing.T) {
+	type job struct {
+		ID string `json:"id"`
+	}
+	got, err := Select(map[string]any{"jobs": []job{{ID: "j1"}}}, "jobs.0.id")
+	if err != nil || got != "j1" {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
+
+func TestJSON_Query(t *testing.T) {
+	Query = "jobs.#.id"
+	defer func() { Query = "" }()
+	out := captureOutput(t, func() {
+		if err := JSON(decodeSample(t)); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.Join(strings.Fields(out), "") != `["j1","j2"]` {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	Query = "jobs.0.state"
+	out = captureOutput(t, func() { JSON(decodeSample(t)) })
+	if out != "active\n" {
+		t.Errorf("strings should print unquoted, got %q", out)
+	}
+}
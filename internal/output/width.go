@@ -0,0 +1,71 @@
+package output
+
+import "unicode"
+
+// ellipsis marks a truncated table cell.
+const ellipsis = "…"
+
+// wideRanges are the East Asian Wide and Fullwidth code point ranges, plus
+// emoji, which terminals render two columns wide.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x231A, 0x231B},   // watch, hourglass
+	{0x2E80, 0x303E},   // CJK radicals, Kangxi, CJK symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, CJK compatibility
+	{0x3400, 0x4DBF},   // CJK Extension A
+	{0x4E00, 0x9FFF},   // CJK Unified Ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE30, 0xFE4F},   // CJK compatibility forms
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x1F300, 0x1F64F}, // Misc symbols and pictographs, emoticons
+	{0x1F900, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x20000, 0x3FFFD}, // CJK Extensions B and beyond
+}
+
+// RuneWidth returns the number of terminal columns r occupies: 0 for
+// combining marks and invisible format characters, 2 for wide characters,
+// and 1 otherwise.
+func RuneWidth(r rune) int {
+	if r == 0 || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, rg := range wideRanges {
+		if r < rg[0] {
+			break
+		}
+		if r <= rg[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// DisplayWidth returns the number of terminal columns s occupies.
+func DisplayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += RuneWidth(r)
+	}
+	return w
+}
+
+// Truncate shortens s to at most max display columns, replacing the cut
+// tail with an ellipsis. Strings that fit, and max <= 0, are returned as is.
+func Truncate(s string, max int) string {
+	if max <= 0 || DisplayWidth(s) <= max {
+		return s
+	}
+	limit := max - 1 // room for the one-column ellipsis
+	w := 0
+	for i, r := range s {
+		rw := RuneWidth(r)
+		if w+rw > limit {
+			return s[:i] + ellipsis
+		}
+		w += rw
+	}
+	return s
+}